@@ -0,0 +1,11 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+// Subset of the Chainlink EACAggregatorProxy interface used to find the aggregator behind a proxy
+interface AggregatorProxyInterface {
+  function aggregator() external view returns (address);
+
+  function phaseId() external view returns (uint16);
+
+  function phaseAggregators(uint16 phaseId) external view returns (address);
+}
@@ -0,0 +1,274 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package aggregator_proxy_interface
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// AggregatorProxyInterfaceMetaData contains all meta data concerning the AggregatorProxyInterface contract.
+var AggregatorProxyInterfaceMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"aggregator\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"phaseId\",\"outputs\":[{\"internalType\":\"uint16\",\"name\":\"\",\"type\":\"uint16\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint16\",\"name\":\"phaseId\",\"type\":\"uint16\"}],\"name\":\"phaseAggregators\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// AggregatorProxyInterfaceABI is the input ABI used to generate the binding from.
+// Deprecated: Use AggregatorProxyInterfaceMetaData.ABI instead.
+var AggregatorProxyInterfaceABI = AggregatorProxyInterfaceMetaData.ABI
+
+// AggregatorProxyInterface is an auto generated Go binding around an Ethereum contract.
+type AggregatorProxyInterface struct {
+	AggregatorProxyInterfaceCaller     // Read-only binding to the contract
+	AggregatorProxyInterfaceTransactor // Write-only binding to the contract
+	AggregatorProxyInterfaceFilterer   // Log filterer for contract events
+}
+
+// AggregatorProxyInterfaceCaller is an auto generated read-only Go binding around an Ethereum contract.
+type AggregatorProxyInterfaceCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AggregatorProxyInterfaceTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type AggregatorProxyInterfaceTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AggregatorProxyInterfaceFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type AggregatorProxyInterfaceFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AggregatorProxyInterfaceSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type AggregatorProxyInterfaceSession struct {
+	Contract     *AggregatorProxyInterface // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts             // Call options to use throughout this session
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// AggregatorProxyInterfaceCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type AggregatorProxyInterfaceCallerSession struct {
+	Contract *AggregatorProxyInterfaceCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                   // Call options to use throughout this session
+}
+
+// AggregatorProxyInterfaceTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type AggregatorProxyInterfaceTransactorSession struct {
+	Contract     *AggregatorProxyInterfaceTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                   // Transaction auth options to use throughout this session
+}
+
+// AggregatorProxyInterfaceRaw is an auto generated low-level Go binding around an Ethereum contract.
+type AggregatorProxyInterfaceRaw struct {
+	Contract *AggregatorProxyInterface // Generic contract binding to access the raw methods on
+}
+
+// AggregatorProxyInterfaceCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type AggregatorProxyInterfaceCallerRaw struct {
+	Contract *AggregatorProxyInterfaceCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// AggregatorProxyInterfaceTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type AggregatorProxyInterfaceTransactorRaw struct {
+	Contract *AggregatorProxyInterfaceTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewAggregatorProxyInterface creates a new instance of AggregatorProxyInterface, bound to a specific deployed contract.
+func NewAggregatorProxyInterface(address common.Address, backend bind.ContractBackend) (*AggregatorProxyInterface, error) {
+	contract, err := bindAggregatorProxyInterface(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &AggregatorProxyInterface{AggregatorProxyInterfaceCaller: AggregatorProxyInterfaceCaller{contract: contract}, AggregatorProxyInterfaceTransactor: AggregatorProxyInterfaceTransactor{contract: contract}, AggregatorProxyInterfaceFilterer: AggregatorProxyInterfaceFilterer{contract: contract}}, nil
+}
+
+// NewAggregatorProxyInterfaceCaller creates a new read-only instance of AggregatorProxyInterface, bound to a specific deployed contract.
+func NewAggregatorProxyInterfaceCaller(address common.Address, caller bind.ContractCaller) (*AggregatorProxyInterfaceCaller, error) {
+	contract, err := bindAggregatorProxyInterface(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AggregatorProxyInterfaceCaller{contract: contract}, nil
+}
+
+// NewAggregatorProxyInterfaceTransactor creates a new write-only instance of AggregatorProxyInterface, bound to a specific deployed contract.
+func NewAggregatorProxyInterfaceTransactor(address common.Address, transactor bind.ContractTransactor) (*AggregatorProxyInterfaceTransactor, error) {
+	contract, err := bindAggregatorProxyInterface(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AggregatorProxyInterfaceTransactor{contract: contract}, nil
+}
+
+// NewAggregatorProxyInterfaceFilterer creates a new log filterer instance of AggregatorProxyInterface, bound to a specific deployed contract.
+func NewAggregatorProxyInterfaceFilterer(address common.Address, filterer bind.ContractFilterer) (*AggregatorProxyInterfaceFilterer, error) {
+	contract, err := bindAggregatorProxyInterface(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &AggregatorProxyInterfaceFilterer{contract: contract}, nil
+}
+
+// bindAggregatorProxyInterface binds a generic wrapper to an already deployed contract.
+func bindAggregatorProxyInterface(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := AggregatorProxyInterfaceMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AggregatorProxyInterface *AggregatorProxyInterfaceRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AggregatorProxyInterface.Contract.AggregatorProxyInterfaceCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AggregatorProxyInterface *AggregatorProxyInterfaceRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AggregatorProxyInterface.Contract.AggregatorProxyInterfaceTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AggregatorProxyInterface *AggregatorProxyInterfaceRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AggregatorProxyInterface.Contract.AggregatorProxyInterfaceTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AggregatorProxyInterface *AggregatorProxyInterfaceCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AggregatorProxyInterface.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AggregatorProxyInterface *AggregatorProxyInterfaceTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AggregatorProxyInterface.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AggregatorProxyInterface *AggregatorProxyInterfaceTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AggregatorProxyInterface.Contract.contract.Transact(opts, method, params...)
+}
+
+// Aggregator is a free data retrieval call binding the contract method 0x245a7bfc.
+//
+// Solidity: function aggregator() view returns(address)
+func (_AggregatorProxyInterface *AggregatorProxyInterfaceCaller) Aggregator(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _AggregatorProxyInterface.contract.Call(opts, &out, "aggregator")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Aggregator is a free data retrieval call binding the contract method 0x245a7bfc.
+//
+// Solidity: function aggregator() view returns(address)
+func (_AggregatorProxyInterface *AggregatorProxyInterfaceSession) Aggregator() (common.Address, error) {
+	return _AggregatorProxyInterface.Contract.Aggregator(&_AggregatorProxyInterface.CallOpts)
+}
+
+// Aggregator is a free data retrieval call binding the contract method 0x245a7bfc.
+//
+// Solidity: function aggregator() view returns(address)
+func (_AggregatorProxyInterface *AggregatorProxyInterfaceCallerSession) Aggregator() (common.Address, error) {
+	return _AggregatorProxyInterface.Contract.Aggregator(&_AggregatorProxyInterface.CallOpts)
+}
+
+// PhaseAggregators is a free data retrieval call binding the contract method 0xc1597304.
+//
+// Solidity: function phaseAggregators(uint16 phaseId) view returns(address)
+func (_AggregatorProxyInterface *AggregatorProxyInterfaceCaller) PhaseAggregators(opts *bind.CallOpts, phaseId uint16) (common.Address, error) {
+	var out []interface{}
+	err := _AggregatorProxyInterface.contract.Call(opts, &out, "phaseAggregators", phaseId)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// PhaseAggregators is a free data retrieval call binding the contract method 0xc1597304.
+//
+// Solidity: function phaseAggregators(uint16 phaseId) view returns(address)
+func (_AggregatorProxyInterface *AggregatorProxyInterfaceSession) PhaseAggregators(phaseId uint16) (common.Address, error) {
+	return _AggregatorProxyInterface.Contract.PhaseAggregators(&_AggregatorProxyInterface.CallOpts, phaseId)
+}
+
+// PhaseAggregators is a free data retrieval call binding the contract method 0xc1597304.
+//
+// Solidity: function phaseAggregators(uint16 phaseId) view returns(address)
+func (_AggregatorProxyInterface *AggregatorProxyInterfaceCallerSession) PhaseAggregators(phaseId uint16) (common.Address, error) {
+	return _AggregatorProxyInterface.Contract.PhaseAggregators(&_AggregatorProxyInterface.CallOpts, phaseId)
+}
+
+// PhaseId is a free data retrieval call binding the contract method 0x58303b10.
+//
+// Solidity: function phaseId() view returns(uint16)
+func (_AggregatorProxyInterface *AggregatorProxyInterfaceCaller) PhaseId(opts *bind.CallOpts) (uint16, error) {
+	var out []interface{}
+	err := _AggregatorProxyInterface.contract.Call(opts, &out, "phaseId")
+
+	if err != nil {
+		return *new(uint16), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint16)).(*uint16)
+
+	return out0, err
+
+}
+
+// PhaseId is a free data retrieval call binding the contract method 0x58303b10.
+//
+// Solidity: function phaseId() view returns(uint16)
+func (_AggregatorProxyInterface *AggregatorProxyInterfaceSession) PhaseId() (uint16, error) {
+	return _AggregatorProxyInterface.Contract.PhaseId(&_AggregatorProxyInterface.CallOpts)
+}
+
+// PhaseId is a free data retrieval call binding the contract method 0x58303b10.
+//
+// Solidity: function phaseId() view returns(uint16)
+func (_AggregatorProxyInterface *AggregatorProxyInterfaceCallerSession) PhaseId() (uint16, error) {
+	return _AggregatorProxyInterface.Contract.PhaseId(&_AggregatorProxyInterface.CallOpts)
+}
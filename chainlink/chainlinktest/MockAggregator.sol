@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+// Mock Chainlink aggregator for tests on a simulated chain. It implements AggregatorV3Interface and
+// the proxy's aggregator(), phaseId() and phaseAggregators(), acting as a proxy in phase 1 that
+// points at itself. Rounds are stored with updateRound.
+//
+// Regenerate mock_aggregator.go after changing this file:
+//   solc --optimize --abi --bin -o . MockAggregator.sol
+//   abigen --abi MockAggregator.abi --bin MockAggregator.bin --pkg chainlinktest --type MockAggregatorContract --out mock_aggregator.go
+contract MockAggregator {
+  struct Round {
+    int256 answer;
+    uint256 startedAt;
+    uint256 updatedAt;
+    uint80 answeredInRound;
+  }
+
+  uint16 public constant phaseId = 1;
+  uint256 public constant version = 4;
+
+  uint8 public immutable decimals;
+  string public description;
+
+  uint80 public latestRound;
+  mapping(uint80 => Round) internal rounds;
+
+  constructor(uint8 _decimals, string memory _description) {
+    decimals = _decimals;
+    description = _description;
+  }
+
+  // updateRound stores a round. The latest round only moves forward: storing a round older than
+  // it leaves latestRoundData() unchanged.
+  function updateRound(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound) external {
+    rounds[roundId] = Round(answer, startedAt, updatedAt, answeredInRound);
+    if (roundId > latestRound) {
+      latestRound = roundId;
+    }
+  }
+
+  function getRoundData(uint80 _roundId)
+    public
+    view
+    returns (uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+  {
+    Round memory round = rounds[_roundId];
+    require(round.updatedAt > 0, "No data present");
+    return (_roundId, round.answer, round.startedAt, round.updatedAt, round.answeredInRound);
+  }
+
+  function latestRoundData()
+    external
+    view
+    returns (uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+  {
+    return getRoundData(latestRound);
+  }
+
+  function aggregator() external view returns (address) {
+    return address(this);
+  }
+
+  function phaseAggregators(uint16 _phaseId) external view returns (address) {
+    return _phaseId == phaseId ? address(this) : address(0);
+  }
+}
@@ -0,0 +1,124 @@
+package chainlinktest
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MockPhaseID is the phase the mock aggregator reports when read as a proxy
+const MockPhaseID = 1
+
+// mockVersion is returned by version() of MockAggregator.sol, matching current OCR aggregators
+const mockVersion = 4
+
+// Round is a round stored in the mock aggregator
+type Round struct {
+	RoundID         *big.Int
+	Answer          *big.Int
+	StartedAt       time.Time // Zero stores 0
+	UpdatedAt       time.Time // Zero stores 0, which makes the round read as missing
+	AnsweredInRound *big.Int
+}
+
+// MockAggregator is a mock Chainlink aggregator deployed on a simulated Chain. It implements
+// AggregatorV3Interface and the proxy's aggregator(), phaseId() and phaseAggregators(), acting as
+// a proxy in phase MockPhaseID that points at itself, so it can be monitored like a real feed.
+type MockAggregator struct {
+	Address     common.Address
+	Decimals    uint8
+	Description string
+
+	chain    *Chain
+	contract *MockAggregatorContract
+
+	mu        sync.Mutex
+	lastRound uint64 // Aggregator round ID of the last round pushed with PushRound
+}
+
+// RoundID returns the proxy round ID of an aggregator round of the mock
+func RoundID(aggregatorRoundID uint64) *big.Int {
+	roundID := new(big.Int).Lsh(big.NewInt(MockPhaseID), 64)
+	return roundID.Or(roundID, new(big.Int).SetUint64(aggregatorRoundID))
+}
+
+// DeployAggregator deploys a mock aggregator with the given decimals and description. It has no
+// rounds until one is pushed; until then latestRoundData() reverts like a fresh aggregator.
+func (c *Chain) DeployAggregator(decimals uint8, description string) (*MockAggregator, error) {
+	address, tx, contract, err := DeployMockAggregatorContract(c.TransactOpts(), c.Client, decimals, description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy mock aggregator: %v", err)
+	}
+	if _, err := c.Mine(tx); err != nil {
+		return nil, fmt.Errorf("failed to deploy mock aggregator: %v", err)
+	}
+
+	return &MockAggregator{
+		Address:     address,
+		Decimals:    decimals,
+		Description: description,
+		chain:       c,
+		contract:    contract,
+	}, nil
+}
+
+// PushRound stores the next round with the given answer, started and updated at updatedAt, and
+// commits a block. It returns the proxy round ID of the new round.
+func (m *MockAggregator) PushRound(answer *big.Int, updatedAt time.Time) (*big.Int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	roundID := RoundID(m.lastRound + 1)
+	err := m.setRound(Round{
+		RoundID:         roundID,
+		Answer:          answer,
+		StartedAt:       updatedAt,
+		UpdatedAt:       updatedAt,
+		AnsweredInRound: roundID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	m.lastRound++
+	return roundID, nil
+}
+
+// SetRound stores an arbitrary round, e.g. a stale or incomplete one, and commits a block.
+// The latest round only moves forward: storing a round older than it leaves latestRoundData() unchanged.
+func (m *MockAggregator) SetRound(round Round) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.setRound(round)
+}
+
+// setRound sends the updateRound transaction, called with m.mu held
+func (m *MockAggregator) setRound(round Round) error {
+	if round.RoundID == nil || round.Answer == nil {
+		return fmt.Errorf("round ID and answer are required")
+	}
+	answeredInRound := round.AnsweredInRound
+	if answeredInRound == nil {
+		answeredInRound = round.RoundID
+	}
+
+	tx, err := m.contract.UpdateRound(m.chain.TransactOpts(), round.RoundID, round.Answer,
+		unixOrZero(round.StartedAt), unixOrZero(round.UpdatedAt), answeredInRound)
+	if err != nil {
+		return fmt.Errorf("failed to send updateRound: %v", err)
+	}
+	if _, err := m.chain.Mine(tx); err != nil {
+		return fmt.Errorf("updateRound failed: %v", err)
+	}
+	return nil
+}
+
+// unixOrZero converts a timestamp to the uint256 seconds stored on chain
+func unixOrZero(t time.Time) *big.Int {
+	if t.IsZero() {
+		return new(big.Int)
+	}
+	return big.NewInt(t.Unix())
+}
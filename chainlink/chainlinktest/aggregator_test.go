@@ -0,0 +1,127 @@
+package chainlinktest
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	aggregatorproxy "github.com/morpheum-labs/pricefeeding/aggregatorproxy"
+	aggregatorv3 "github.com/morpheum-labs/pricefeeding/aggregatorv3"
+)
+
+func newTestAggregator(t *testing.T) (*Chain, *MockAggregator) {
+	t.Helper()
+	chain, err := NewChain()
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	t.Cleanup(func() { chain.Close() })
+
+	mock, err := chain.DeployAggregator(8, "ETH / USD")
+	if err != nil {
+		t.Fatalf("Failed to deploy mock aggregator: %v", err)
+	}
+	return chain, mock
+}
+
+func TestMockAggregatorMetadata(t *testing.T) {
+	chain, mock := newTestAggregator(t)
+
+	aggregator, err := aggregatorv3.NewAggregatorV3InterfaceCaller(mock.Address, chain.Client)
+	if err != nil {
+		t.Fatalf("Failed to bind aggregator: %v", err)
+	}
+
+	decimals, err := aggregator.Decimals(&bind.CallOpts{})
+	if err != nil || decimals != 8 {
+		t.Errorf("decimals() = %d, %v; want 8", decimals, err)
+	}
+	description, err := aggregator.Description(&bind.CallOpts{})
+	if err != nil || description != "ETH / USD" {
+		t.Errorf("description() = %q, %v; want %q", description, err, "ETH / USD")
+	}
+	version, err := aggregator.Version(&bind.CallOpts{})
+	if err != nil || version.Int64() != mockVersion {
+		t.Errorf("version() = %v, %v; want %d", version, err, mockVersion)
+	}
+
+	// Without rounds latestRoundData reverts like a fresh aggregator
+	if _, err := aggregator.LatestRoundData(&bind.CallOpts{}); err == nil {
+		t.Error("Expected latestRoundData() to revert before the first round")
+	}
+}
+
+func TestMockAggregatorRounds(t *testing.T) {
+	chain, mock := newTestAggregator(t)
+
+	aggregator, err := aggregatorv3.NewAggregatorV3InterfaceCaller(mock.Address, chain.Client)
+	if err != nil {
+		t.Fatalf("Failed to bind aggregator: %v", err)
+	}
+
+	updatedAt := time.Unix(1700000000, 0)
+	first, err := mock.PushRound(big.NewInt(300000000000), updatedAt)
+	if err != nil {
+		t.Fatalf("PushRound failed: %v", err)
+	}
+	second, err := mock.PushRound(big.NewInt(-5), updatedAt.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("PushRound failed: %v", err)
+	}
+	if first.Cmp(RoundID(1)) != 0 || second.Cmp(RoundID(2)) != 0 {
+		t.Fatalf("Unexpected round IDs %s, %s", first, second)
+	}
+
+	latest, err := aggregator.LatestRoundData(&bind.CallOpts{})
+	if err != nil {
+		t.Fatalf("latestRoundData() failed: %v", err)
+	}
+	if latest.RoundId.Cmp(second) != 0 || latest.Answer.Int64() != -5 || latest.UpdatedAt.Int64() != updatedAt.Add(time.Minute).Unix() {
+		t.Errorf("Unexpected latest round %+v", latest)
+	}
+
+	round, err := aggregator.GetRoundData(&bind.CallOpts{}, first)
+	if err != nil {
+		t.Fatalf("getRoundData() failed: %v", err)
+	}
+	if round.Answer.Int64() != 300000000000 || round.AnsweredInRound.Cmp(first) != 0 {
+		t.Errorf("Unexpected first round %+v", round)
+	}
+
+	if _, err := aggregator.GetRoundData(&bind.CallOpts{}, RoundID(3)); err == nil {
+		t.Error("Expected getRoundData() to revert for a missing round")
+	}
+
+	// Rewriting an older round does not move the latest round back
+	if err := mock.SetRound(Round{RoundID: first, Answer: big.NewInt(1), UpdatedAt: updatedAt}); err != nil {
+		t.Fatalf("SetRound failed: %v", err)
+	}
+	latest, err = aggregator.LatestRoundData(&bind.CallOpts{})
+	if err != nil || latest.RoundId.Cmp(second) != 0 {
+		t.Errorf("Expected latest round to stay %s, got %+v (%v)", second, latest, err)
+	}
+}
+
+func TestMockAggregatorActsAsProxy(t *testing.T) {
+	chain, mock := newTestAggregator(t)
+
+	proxy, err := aggregatorproxy.NewAggregatorProxyInterfaceCaller(mock.Address, chain.Client)
+	if err != nil {
+		t.Fatalf("Failed to bind proxy: %v", err)
+	}
+
+	phaseID, err := proxy.PhaseId(&bind.CallOpts{})
+	if err != nil || phaseID != MockPhaseID {
+		t.Errorf("phaseId() = %d, %v; want %d", phaseID, err, MockPhaseID)
+	}
+	aggregator, err := proxy.Aggregator(&bind.CallOpts{})
+	if err != nil || aggregator != mock.Address {
+		t.Errorf("aggregator() = %s, %v; want %s", aggregator.Hex(), err, mock.Address.Hex())
+	}
+	phaseAggregator, err := proxy.PhaseAggregators(&bind.CallOpts{}, MockPhaseID)
+	if err != nil || phaseAggregator != mock.Address {
+		t.Errorf("phaseAggregators(%d) = %s, %v; want %s", MockPhaseID, phaseAggregator.Hex(), err, mock.Address.Hex())
+	}
+}
@@ -0,0 +1,25 @@
+// Package chainlinktest runs mock Chainlink contracts on go-ethereum's simulated backend, so feeds
+// can be tested end to end against a real EVM instead of a live RPC
+package chainlinktest
+
+import (
+	"github.com/morpheum-labs/pricefeeding/internal/evmtest"
+)
+
+// SimulatedChainID is the chain ID of go-ethereum's simulated backend
+const SimulatedChainID = evmtest.SimulatedChainID
+
+// Chain is a simulated chain with a funded account that deploys and updates mock contracts.
+// Every transaction is mined immediately. Close it when done.
+type Chain struct {
+	*evmtest.Chain
+}
+
+// NewChain starts a simulated chain
+func NewChain() (*Chain, error) {
+	chain, err := evmtest.NewChain()
+	if err != nil {
+		return nil, err
+	}
+	return &Chain{Chain: chain}, nil
+}
@@ -0,0 +1,561 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package chainlinktest
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// MockAggregatorContractMetaData contains all meta data concerning the MockAggregatorContract contract.
+var MockAggregatorContractMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"uint8\",\"name\":\"_decimals\",\"type\":\"uint8\"},{\"internalType\":\"string\",\"name\":\"_description\",\"type\":\"string\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"aggregator\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"description\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint80\",\"name\":\"_roundId\",\"type\":\"uint80\"}],\"name\":\"getRoundData\",\"outputs\":[{\"internalType\":\"uint80\",\"name\":\"roundId\",\"type\":\"uint80\"},{\"internalType\":\"int256\",\"name\":\"answer\",\"type\":\"int256\"},{\"internalType\":\"uint256\",\"name\":\"startedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"updatedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint80\",\"name\":\"answeredInRound\",\"type\":\"uint80\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"latestRound\",\"outputs\":[{\"internalType\":\"uint80\",\"name\":\"\",\"type\":\"uint80\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"latestRoundData\",\"outputs\":[{\"internalType\":\"uint80\",\"name\":\"roundId\",\"type\":\"uint80\"},{\"internalType\":\"int256\",\"name\":\"answer\",\"type\":\"int256\"},{\"internalType\":\"uint256\",\"name\":\"startedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"updatedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint80\",\"name\":\"answeredInRound\",\"type\":\"uint80\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint16\",\"name\":\"_phaseId\",\"type\":\"uint16\"}],\"name\":\"phaseAggregators\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"phaseId\",\"outputs\":[{\"internalType\":\"uint16\",\"name\":\"\",\"type\":\"uint16\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint80\",\"name\":\"roundId\",\"type\":\"uint80\"},{\"internalType\":\"int256\",\"name\":\"answer\",\"type\":\"int256\"},{\"internalType\":\"uint256\",\"name\":\"startedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"updatedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint80\",\"name\":\"answeredInRound\",\"type\":\"uint80\"}],\"name\":\"updateRound\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"version\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x60a060405234801561000f575f5ffd5b506040516107db3803806107db83398101604081905261002e9161005c565b60ff82166080525f61004082826101a9565b505050610263565b634e487b7160e01b5f52604160045260245ffd5b5f5f6040838503121561006d575f5ffd5b825160ff8116811461007d575f5ffd5b60208401519092506001600160401b03811115610098575f5ffd5b8301601f810185136100a8575f5ffd5b80516001600160401b038111156100c1576100c1610048565b604051601f8201601f19908116603f011681016001600160401b03811182821017156100ef576100ef610048565b604052818152828201602001871015610106575f5ffd5b8160208401602083015e5f602083830101528093505050509250929050565b600181811c9082168061013957607f821691505b60208210810361015757634e487b7160e01b5f52602260045260245ffd5b50919050565b601f8211156101a457805f5260205f20601f840160051c810160208510156101825750805b601f840160051c820191505b818110156101a1575f815560010161018e565b50505b505050565b81516001600160401b038111156101c2576101c2610048565b6101d6816101d08454610125565b8461015d565b6020601f821160018114610208575f83156101f15750848201515b5f19600385901b1c1916600184901b1784556101a1565b5f84815260208120601f198516915b828110156102375787850151825560209485019460019092019101610217565b508482101561025457868401515f19600387901b60f8161c191681555b50505050600190811b01905550565b60805161056161027a5f395f60c301526105615ff3fe608060405234801561000f575f5ffd5b506004361061009b575f3560e01c80637284e416116100635780637284e416146101535780639a6fc8f514610168578063c1597304146101af578063feaf968c146101c2578063feed993f146101ca575f5ffd5b8063245a7bfc1461009f578063313ce567146100be57806354fd4d50146100f757806358303b101461010d578063668a0f0214610128575b5f5ffd5b305b6040516001600160a01b0390911681526020015b60405180910390f35b6100e57f000000000000000000000000000000000000000000000000000000000000000081565b60405160ff90911681526020016100b5565b6100ff600481565b6040519081526020016100b5565b610115600181565b60405161ffff90911681526020016100b5565b60015461013b906001600160501b031681565b6040516001600160501b0390911681526020016100b5565b61015b6101df565b6040516100b59190610416565b61017b610176366004610466565b61026a565b604080516001600160501b03968716815260208101959095528401929092526060830152909116608082015260a0016100b5565b6100a16101bd366004610486565b610326565b61017b610341565b6101dd6101d83660046104a7565b610371565b005b5f80546101eb906104f3565b80601f0160208091040260200160405190810160405280929190818152602001828054610217906104f3565b80156102625780601f1061023957610100808354040283529160200191610262565b820191905f5260205f20905b81548152906001019060200180831161024557829003601f168201915b505050505081565b6001600160501b038082165f90815260026020818152604080842081516080810183528154815260018201549381019390935292830154908201819052600390920154909316606084015290918291829182918291906103025760405162461bcd60e51b815260206004820152600f60248201526e139bc819185d18481c1c995cd95b9d608a1b604482015260640160405180910390fd5b80516020820151604083015160609093015198999198909750919550909350915050565b5f61ffff8216600114610339575f61033b565b305b92915050565b6001545f908190819081908190610360906001600160501b031661026a565b945094509450945094509091929394565b6040805160808101825285815260208082018681528284018681526001600160501b03868116606086019081528b82165f8181526002968790529790972095518655925160018087019190915591519385019390935590516003909301805469ffffffffffffffffffff191693831693909317909255905416101561040f576001805469ffffffffffffffffffff19166001600160501b0387161790555b5050505050565b602081525f82518060208401528060208501604085015e5f604082850101526040601f19601f83011684010191505092915050565b80356001600160501b0381168114610461575f5ffd5b919050565b5f60208284031215610476575f5ffd5b61047f8261044b565b9392505050565b5f60208284031215610496575f5ffd5b813561ffff8116811461047f575f5ffd5b5f5f5f5f5f60a086880312156104bb575f5ffd5b6104c48661044b565b94506020860135935060408601359250606086013591506104e76080870161044b565b90509295509295909350565b600181811c9082168061050757607f821691505b60208210810361052557634e487b7160e01b5f52602260045260245ffd5b5091905056fea2646970667358221220cb47c0e0ae69e44086fea71d02c2fca319b807c3682fcd6b774c2370602b23e464736f6c634300081e0033",
+}
+
+// MockAggregatorContractABI is the input ABI used to generate the binding from.
+// Deprecated: Use MockAggregatorContractMetaData.ABI instead.
+var MockAggregatorContractABI = MockAggregatorContractMetaData.ABI
+
+// MockAggregatorContractBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use MockAggregatorContractMetaData.Bin instead.
+var MockAggregatorContractBin = MockAggregatorContractMetaData.Bin
+
+// DeployMockAggregatorContract deploys a new Ethereum contract, binding an instance of MockAggregatorContract to it.
+func DeployMockAggregatorContract(auth *bind.TransactOpts, backend bind.ContractBackend, _decimals uint8, _description string) (common.Address, *types.Transaction, *MockAggregatorContract, error) {
+	parsed, err := MockAggregatorContractMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(MockAggregatorContractBin), backend, _decimals, _description)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &MockAggregatorContract{MockAggregatorContractCaller: MockAggregatorContractCaller{contract: contract}, MockAggregatorContractTransactor: MockAggregatorContractTransactor{contract: contract}, MockAggregatorContractFilterer: MockAggregatorContractFilterer{contract: contract}}, nil
+}
+
+// MockAggregatorContract is an auto generated Go binding around an Ethereum contract.
+type MockAggregatorContract struct {
+	MockAggregatorContractCaller     // Read-only binding to the contract
+	MockAggregatorContractTransactor // Write-only binding to the contract
+	MockAggregatorContractFilterer   // Log filterer for contract events
+}
+
+// MockAggregatorContractCaller is an auto generated read-only Go binding around an Ethereum contract.
+type MockAggregatorContractCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// MockAggregatorContractTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type MockAggregatorContractTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// MockAggregatorContractFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type MockAggregatorContractFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// MockAggregatorContractSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type MockAggregatorContractSession struct {
+	Contract     *MockAggregatorContract // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts           // Call options to use throughout this session
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// MockAggregatorContractCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type MockAggregatorContractCallerSession struct {
+	Contract *MockAggregatorContractCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                 // Call options to use throughout this session
+}
+
+// MockAggregatorContractTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type MockAggregatorContractTransactorSession struct {
+	Contract     *MockAggregatorContractTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                 // Transaction auth options to use throughout this session
+}
+
+// MockAggregatorContractRaw is an auto generated low-level Go binding around an Ethereum contract.
+type MockAggregatorContractRaw struct {
+	Contract *MockAggregatorContract // Generic contract binding to access the raw methods on
+}
+
+// MockAggregatorContractCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type MockAggregatorContractCallerRaw struct {
+	Contract *MockAggregatorContractCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// MockAggregatorContractTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type MockAggregatorContractTransactorRaw struct {
+	Contract *MockAggregatorContractTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewMockAggregatorContract creates a new instance of MockAggregatorContract, bound to a specific deployed contract.
+func NewMockAggregatorContract(address common.Address, backend bind.ContractBackend) (*MockAggregatorContract, error) {
+	contract, err := bindMockAggregatorContract(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &MockAggregatorContract{MockAggregatorContractCaller: MockAggregatorContractCaller{contract: contract}, MockAggregatorContractTransactor: MockAggregatorContractTransactor{contract: contract}, MockAggregatorContractFilterer: MockAggregatorContractFilterer{contract: contract}}, nil
+}
+
+// NewMockAggregatorContractCaller creates a new read-only instance of MockAggregatorContract, bound to a specific deployed contract.
+func NewMockAggregatorContractCaller(address common.Address, caller bind.ContractCaller) (*MockAggregatorContractCaller, error) {
+	contract, err := bindMockAggregatorContract(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MockAggregatorContractCaller{contract: contract}, nil
+}
+
+// NewMockAggregatorContractTransactor creates a new write-only instance of MockAggregatorContract, bound to a specific deployed contract.
+func NewMockAggregatorContractTransactor(address common.Address, transactor bind.ContractTransactor) (*MockAggregatorContractTransactor, error) {
+	contract, err := bindMockAggregatorContract(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MockAggregatorContractTransactor{contract: contract}, nil
+}
+
+// NewMockAggregatorContractFilterer creates a new log filterer instance of MockAggregatorContract, bound to a specific deployed contract.
+func NewMockAggregatorContractFilterer(address common.Address, filterer bind.ContractFilterer) (*MockAggregatorContractFilterer, error) {
+	contract, err := bindMockAggregatorContract(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &MockAggregatorContractFilterer{contract: contract}, nil
+}
+
+// bindMockAggregatorContract binds a generic wrapper to an already deployed contract.
+func bindMockAggregatorContract(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := MockAggregatorContractMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_MockAggregatorContract *MockAggregatorContractRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _MockAggregatorContract.Contract.MockAggregatorContractCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_MockAggregatorContract *MockAggregatorContractRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _MockAggregatorContract.Contract.MockAggregatorContractTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_MockAggregatorContract *MockAggregatorContractRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _MockAggregatorContract.Contract.MockAggregatorContractTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_MockAggregatorContract *MockAggregatorContractCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _MockAggregatorContract.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_MockAggregatorContract *MockAggregatorContractTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _MockAggregatorContract.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_MockAggregatorContract *MockAggregatorContractTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _MockAggregatorContract.Contract.contract.Transact(opts, method, params...)
+}
+
+// Aggregator is a free data retrieval call binding the contract method 0x245a7bfc.
+//
+// Solidity: function aggregator() view returns(address)
+func (_MockAggregatorContract *MockAggregatorContractCaller) Aggregator(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _MockAggregatorContract.contract.Call(opts, &out, "aggregator")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Aggregator is a free data retrieval call binding the contract method 0x245a7bfc.
+//
+// Solidity: function aggregator() view returns(address)
+func (_MockAggregatorContract *MockAggregatorContractSession) Aggregator() (common.Address, error) {
+	return _MockAggregatorContract.Contract.Aggregator(&_MockAggregatorContract.CallOpts)
+}
+
+// Aggregator is a free data retrieval call binding the contract method 0x245a7bfc.
+//
+// Solidity: function aggregator() view returns(address)
+func (_MockAggregatorContract *MockAggregatorContractCallerSession) Aggregator() (common.Address, error) {
+	return _MockAggregatorContract.Contract.Aggregator(&_MockAggregatorContract.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_MockAggregatorContract *MockAggregatorContractCaller) Decimals(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _MockAggregatorContract.contract.Call(opts, &out, "decimals")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_MockAggregatorContract *MockAggregatorContractSession) Decimals() (uint8, error) {
+	return _MockAggregatorContract.Contract.Decimals(&_MockAggregatorContract.CallOpts)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x313ce567.
+//
+// Solidity: function decimals() view returns(uint8)
+func (_MockAggregatorContract *MockAggregatorContractCallerSession) Decimals() (uint8, error) {
+	return _MockAggregatorContract.Contract.Decimals(&_MockAggregatorContract.CallOpts)
+}
+
+// Description is a free data retrieval call binding the contract method 0x7284e416.
+//
+// Solidity: function description() view returns(string)
+func (_MockAggregatorContract *MockAggregatorContractCaller) Description(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _MockAggregatorContract.contract.Call(opts, &out, "description")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Description is a free data retrieval call binding the contract method 0x7284e416.
+//
+// Solidity: function description() view returns(string)
+func (_MockAggregatorContract *MockAggregatorContractSession) Description() (string, error) {
+	return _MockAggregatorContract.Contract.Description(&_MockAggregatorContract.CallOpts)
+}
+
+// Description is a free data retrieval call binding the contract method 0x7284e416.
+//
+// Solidity: function description() view returns(string)
+func (_MockAggregatorContract *MockAggregatorContractCallerSession) Description() (string, error) {
+	return _MockAggregatorContract.Contract.Description(&_MockAggregatorContract.CallOpts)
+}
+
+// GetRoundData is a free data retrieval call binding the contract method 0x9a6fc8f5.
+//
+// Solidity: function getRoundData(uint80 _roundId) view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_MockAggregatorContract *MockAggregatorContractCaller) GetRoundData(opts *bind.CallOpts, _roundId *big.Int) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	var out []interface{}
+	err := _MockAggregatorContract.contract.Call(opts, &out, "getRoundData", _roundId)
+
+	outstruct := new(struct {
+		RoundId         *big.Int
+		Answer          *big.Int
+		StartedAt       *big.Int
+		UpdatedAt       *big.Int
+		AnsweredInRound *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.RoundId = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Answer = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.StartedAt = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.UpdatedAt = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.AnsweredInRound = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetRoundData is a free data retrieval call binding the contract method 0x9a6fc8f5.
+//
+// Solidity: function getRoundData(uint80 _roundId) view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_MockAggregatorContract *MockAggregatorContractSession) GetRoundData(_roundId *big.Int) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	return _MockAggregatorContract.Contract.GetRoundData(&_MockAggregatorContract.CallOpts, _roundId)
+}
+
+// GetRoundData is a free data retrieval call binding the contract method 0x9a6fc8f5.
+//
+// Solidity: function getRoundData(uint80 _roundId) view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_MockAggregatorContract *MockAggregatorContractCallerSession) GetRoundData(_roundId *big.Int) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	return _MockAggregatorContract.Contract.GetRoundData(&_MockAggregatorContract.CallOpts, _roundId)
+}
+
+// LatestRound is a free data retrieval call binding the contract method 0x668a0f02.
+//
+// Solidity: function latestRound() view returns(uint80)
+func (_MockAggregatorContract *MockAggregatorContractCaller) LatestRound(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _MockAggregatorContract.contract.Call(opts, &out, "latestRound")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// LatestRound is a free data retrieval call binding the contract method 0x668a0f02.
+//
+// Solidity: function latestRound() view returns(uint80)
+func (_MockAggregatorContract *MockAggregatorContractSession) LatestRound() (*big.Int, error) {
+	return _MockAggregatorContract.Contract.LatestRound(&_MockAggregatorContract.CallOpts)
+}
+
+// LatestRound is a free data retrieval call binding the contract method 0x668a0f02.
+//
+// Solidity: function latestRound() view returns(uint80)
+func (_MockAggregatorContract *MockAggregatorContractCallerSession) LatestRound() (*big.Int, error) {
+	return _MockAggregatorContract.Contract.LatestRound(&_MockAggregatorContract.CallOpts)
+}
+
+// LatestRoundData is a free data retrieval call binding the contract method 0xfeaf968c.
+//
+// Solidity: function latestRoundData() view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_MockAggregatorContract *MockAggregatorContractCaller) LatestRoundData(opts *bind.CallOpts) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	var out []interface{}
+	err := _MockAggregatorContract.contract.Call(opts, &out, "latestRoundData")
+
+	outstruct := new(struct {
+		RoundId         *big.Int
+		Answer          *big.Int
+		StartedAt       *big.Int
+		UpdatedAt       *big.Int
+		AnsweredInRound *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.RoundId = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Answer = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.StartedAt = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.UpdatedAt = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.AnsweredInRound = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// LatestRoundData is a free data retrieval call binding the contract method 0xfeaf968c.
+//
+// Solidity: function latestRoundData() view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_MockAggregatorContract *MockAggregatorContractSession) LatestRoundData() (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	return _MockAggregatorContract.Contract.LatestRoundData(&_MockAggregatorContract.CallOpts)
+}
+
+// LatestRoundData is a free data retrieval call binding the contract method 0xfeaf968c.
+//
+// Solidity: function latestRoundData() view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_MockAggregatorContract *MockAggregatorContractCallerSession) LatestRoundData() (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	return _MockAggregatorContract.Contract.LatestRoundData(&_MockAggregatorContract.CallOpts)
+}
+
+// PhaseAggregators is a free data retrieval call binding the contract method 0xc1597304.
+//
+// Solidity: function phaseAggregators(uint16 _phaseId) view returns(address)
+func (_MockAggregatorContract *MockAggregatorContractCaller) PhaseAggregators(opts *bind.CallOpts, _phaseId uint16) (common.Address, error) {
+	var out []interface{}
+	err := _MockAggregatorContract.contract.Call(opts, &out, "phaseAggregators", _phaseId)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// PhaseAggregators is a free data retrieval call binding the contract method 0xc1597304.
+//
+// Solidity: function phaseAggregators(uint16 _phaseId) view returns(address)
+func (_MockAggregatorContract *MockAggregatorContractSession) PhaseAggregators(_phaseId uint16) (common.Address, error) {
+	return _MockAggregatorContract.Contract.PhaseAggregators(&_MockAggregatorContract.CallOpts, _phaseId)
+}
+
+// PhaseAggregators is a free data retrieval call binding the contract method 0xc1597304.
+//
+// Solidity: function phaseAggregators(uint16 _phaseId) view returns(address)
+func (_MockAggregatorContract *MockAggregatorContractCallerSession) PhaseAggregators(_phaseId uint16) (common.Address, error) {
+	return _MockAggregatorContract.Contract.PhaseAggregators(&_MockAggregatorContract.CallOpts, _phaseId)
+}
+
+// PhaseId is a free data retrieval call binding the contract method 0x58303b10.
+//
+// Solidity: function phaseId() view returns(uint16)
+func (_MockAggregatorContract *MockAggregatorContractCaller) PhaseId(opts *bind.CallOpts) (uint16, error) {
+	var out []interface{}
+	err := _MockAggregatorContract.contract.Call(opts, &out, "phaseId")
+
+	if err != nil {
+		return *new(uint16), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint16)).(*uint16)
+
+	return out0, err
+
+}
+
+// PhaseId is a free data retrieval call binding the contract method 0x58303b10.
+//
+// Solidity: function phaseId() view returns(uint16)
+func (_MockAggregatorContract *MockAggregatorContractSession) PhaseId() (uint16, error) {
+	return _MockAggregatorContract.Contract.PhaseId(&_MockAggregatorContract.CallOpts)
+}
+
+// PhaseId is a free data retrieval call binding the contract method 0x58303b10.
+//
+// Solidity: function phaseId() view returns(uint16)
+func (_MockAggregatorContract *MockAggregatorContractCallerSession) PhaseId() (uint16, error) {
+	return _MockAggregatorContract.Contract.PhaseId(&_MockAggregatorContract.CallOpts)
+}
+
+// Version is a free data retrieval call binding the contract method 0x54fd4d50.
+//
+// Solidity: function version() view returns(uint256)
+func (_MockAggregatorContract *MockAggregatorContractCaller) Version(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _MockAggregatorContract.contract.Call(opts, &out, "version")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Version is a free data retrieval call binding the contract method 0x54fd4d50.
+//
+// Solidity: function version() view returns(uint256)
+func (_MockAggregatorContract *MockAggregatorContractSession) Version() (*big.Int, error) {
+	return _MockAggregatorContract.Contract.Version(&_MockAggregatorContract.CallOpts)
+}
+
+// Version is a free data retrieval call binding the contract method 0x54fd4d50.
+//
+// Solidity: function version() view returns(uint256)
+func (_MockAggregatorContract *MockAggregatorContractCallerSession) Version() (*big.Int, error) {
+	return _MockAggregatorContract.Contract.Version(&_MockAggregatorContract.CallOpts)
+}
+
+// UpdateRound is a paid mutator transaction binding the contract method 0xfeed993f.
+//
+// Solidity: function updateRound(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound) returns()
+func (_MockAggregatorContract *MockAggregatorContractTransactor) UpdateRound(opts *bind.TransactOpts, roundId *big.Int, answer *big.Int, startedAt *big.Int, updatedAt *big.Int, answeredInRound *big.Int) (*types.Transaction, error) {
+	return _MockAggregatorContract.contract.Transact(opts, "updateRound", roundId, answer, startedAt, updatedAt, answeredInRound)
+}
+
+// UpdateRound is a paid mutator transaction binding the contract method 0xfeed993f.
+//
+// Solidity: function updateRound(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound) returns()
+func (_MockAggregatorContract *MockAggregatorContractSession) UpdateRound(roundId *big.Int, answer *big.Int, startedAt *big.Int, updatedAt *big.Int, answeredInRound *big.Int) (*types.Transaction, error) {
+	return _MockAggregatorContract.Contract.UpdateRound(&_MockAggregatorContract.TransactOpts, roundId, answer, startedAt, updatedAt, answeredInRound)
+}
+
+// UpdateRound is a paid mutator transaction binding the contract method 0xfeed993f.
+//
+// Solidity: function updateRound(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound) returns()
+func (_MockAggregatorContract *MockAggregatorContractTransactorSession) UpdateRound(roundId *big.Int, answer *big.Int, startedAt *big.Int, updatedAt *big.Int, answeredInRound *big.Int) (*types.Transaction, error) {
+	return _MockAggregatorContract.Contract.UpdateRound(&_MockAggregatorContract.TransactOpts, roundId, answer, startedAt, updatedAt, answeredInRound)
+}
@@ -1,6 +1,7 @@
 package chainlink
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -8,95 +9,81 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 
 	aggregatorv3 "github.com/morpheum-labs/pricefeeding/aggregatorv3"
+	"github.com/morpheum-labs/pricefeeding/internal/ctxutil"
 	"github.com/morpheum-labs/pricefeeding/types"
 )
 
 // RPCSwitcher is an interface for handling RPC endpoint switching
 type RPCSwitcher interface {
-	SwitchRPCEndpointImmediately(networkID uint64) error
-	GetBestClient(networkID uint64) (*ethclient.Client, error)
+	SwitchRPCEndpointImmediately(ctx context.Context, networkID uint64) error
+	GetBestClient(networkID uint64) (bind.ContractCaller, error)
 }
 
 // FetchPriceDataOptions contains options for fetching price data
 type FetchPriceDataOptions struct {
-	NetworkID      uint64
-	FeedAddress    string
-	Client         *ethclient.Client
-	RPCSwitcher    RPCSwitcher // Optional RPC switcher for retry logic
-	MaxRetries     int         // Maximum number of retries (default: 1)
-	RetryDelay     time.Duration // Delay between retries (default: 2 seconds)
+	NetworkID   uint64
+	FeedAddress string
+	Client      bind.ContractCaller
+	RPCSwitcher RPCSwitcher   // Optional RPC switcher for retry logic
+	MaxRetries  int           // Maximum number of retries (default: 1)
+	RetryDelay  time.Duration // Delay before the first retry, doubled on each further retry (default: 2 seconds)
+	// Optional policy deciding per error category whether to switch RPCs, back off or fail fast.
+	// nil uses DefaultRetryPolicy.
+	RetryPolicy *RetryPolicy
+	// Optional cache of aggregator metadata and bindings. Without it, decimals() is read on every fetch.
+	MetadataCache *MetadataCache
+	// Optional block to read the round at. nil reads at whatever block the RPC serves as latest.
+	Block *BlockRef
 }
 
 // FetchPriceData fetches price data from a Chainlink aggregator contract
-// This is the main entry point for fetching Chainlink price feeds
-func FetchPriceData(opts FetchPriceDataOptions) (*types.ChainlinkPrice, error) {
+// This is the main entry point for fetching Chainlink price feeds. ctx bounds every RPC call
+// and the wait between retries.
+func FetchPriceData(ctx context.Context, opts FetchPriceDataOptions) (*types.ChainlinkPrice, error) {
 	if opts.Client == nil {
 		return nil, fmt.Errorf("client cannot be nil")
 	}
 	if opts.FeedAddress == "" {
 		return nil, fmt.Errorf("feed address cannot be empty")
 	}
+	setRetryDefaults(&opts)
+
+	return fetchPriceDataWithRetry(ctx, opts, 1)
+}
+
+// setRetryDefaults fills in the default retry count and delay
+func setRetryDefaults(opts *FetchPriceDataOptions) {
 	if opts.MaxRetries == 0 {
 		opts.MaxRetries = 1 // Default to 1 retry
 	}
 	if opts.RetryDelay == 0 {
 		opts.RetryDelay = 2 * time.Second // Default 2 second delay
 	}
-
-	return fetchPriceDataWithRetry(opts, 1)
 }
 
 // fetchPriceDataWithRetry fetches price data with retry logic after RPC switching
-func fetchPriceDataWithRetry(opts FetchPriceDataOptions, attempt int) (*types.ChainlinkPrice, error) {
-	// Create the aggregator contract instance
-	contractAddress := common.HexToAddress(opts.FeedAddress)
-	aggregator, err := aggregatorv3.NewAggregatorV3Interface(contractAddress, opts.Client)
+func fetchPriceDataWithRetry(ctx context.Context, opts FetchPriceDataOptions, attempt int) (*types.ChainlinkPrice, error) {
+	// Create the aggregator contract instance (reused from the metadata cache when available)
+	aggregator, err := bindAggregator(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create aggregator contract: %v", err)
+		return nil, err
 	}
 
 	// Get the latest round data
-	roundData, err := aggregator.LatestRoundData(&bind.CallOpts{})
+	roundData, err := aggregator.LatestRoundData(callOpts(ctx, opts))
 	if err != nil {
-		// Check if this is the specific error code -32097 that requires immediate RPC switching
-		if IsErrorCode32097(err) && opts.RPCSwitcher != nil && attempt <= opts.MaxRetries {
-			log.Printf("Detected error code -32097 for network %d, triggering immediate RPC switch (attempt %d)", opts.NetworkID, attempt)
-			
-			// Trigger immediate RPC switching for this network
-			if err := opts.RPCSwitcher.SwitchRPCEndpointImmediately(opts.NetworkID); err != nil {
-				log.Printf("Failed to switch RPC endpoint for network %d: %v", opts.NetworkID, err)
-				return nil, fmt.Errorf("failed to get latest round data: %v", err)
-			}
-
-			// Get the new client
-			newClient, err := opts.RPCSwitcher.GetBestClient(opts.NetworkID)
-			if err != nil {
-				log.Printf("Failed to get new client for network %d: %v", opts.NetworkID, err)
-				return nil, fmt.Errorf("failed to get latest round data: %v", err)
-			}
-
-			// Wait a moment for the RPC switch to complete
-			time.Sleep(opts.RetryDelay)
-
-			// Update client in options for retry
-			opts.Client = newClient
-
-			// Retry with the new RPC endpoint
-			log.Printf("Retrying price fetch for network %d with new RPC endpoint (attempt %d)", opts.NetworkID, attempt+1)
-			return fetchPriceDataWithRetry(opts, attempt+1)
-		}
-		return nil, fmt.Errorf("failed to get latest round data: %v", err)
+		return retryFetch(ctx, opts, attempt, "failed to get latest round data", err)
+	}
+	if err := verifyBlockHash(ctx, opts); err != nil {
+		return nil, err
 	}
 
-	// Get decimals from contract
-	decimals, err := aggregator.Decimals(&bind.CallOpts{})
+	// Get decimals from the metadata cache or the contract
+	decimals, err := fetchDecimals(ctx, opts, aggregator)
 	if err != nil {
-		// Log warning and use default
-		log.Printf("Warning: Failed to get decimals for feed %s, using default -8: %v", opts.FeedAddress, err)
-		decimals = 8 // Default for most Chainlink feeds
+		return retryFetch(ctx, opts, attempt, fmt.Sprintf("failed to get decimals for feed %s", opts.FeedAddress), err)
 	}
 
 	// Convert to our ChainlinkPrice structure
@@ -111,12 +98,150 @@ func fetchPriceDataWithRetry(opts FetchPriceDataOptions, attempt int) (*types.Ch
 		NetworkID:       opts.NetworkID,
 		FeedAddress:     opts.FeedAddress,
 	}
+	if opts.Block != nil {
+		priceData.BlockNumber = opts.Block.Number
+		priceData.BlockHash = opts.Block.Hash
+	}
 
 	return priceData, nil
 }
 
+// callOpts returns the call options for reading round data, pinned to opts.Block when set. The
+// call is pinned by block hash so a reorg cannot swap the block under it; clients that cannot
+// call at a hash are pinned by number and checked afterwards by verifyBlockHash.
+func callOpts(ctx context.Context, opts FetchPriceDataOptions) *bind.CallOpts {
+	if opts.Block == nil {
+		return &bind.CallOpts{Context: ctx}
+	}
+	if pinsByHash(opts) {
+		return &bind.CallOpts{Context: ctx, BlockHash: common.HexToHash(opts.Block.Hash)}
+	}
+	return &bind.CallOpts{Context: ctx, BlockNumber: opts.Block.Number}
+}
+
+// pinsByHash reports whether reads of opts.Block are made at its hash rather than its number
+func pinsByHash(opts FetchPriceDataOptions) bool {
+	if opts.Block == nil || opts.Block.Hash == "" {
+		return false
+	}
+	_, ok := opts.Client.(bind.BlockHashContractCaller)
+	return ok
+}
+
+// verifyBlockHash checks, after a read pinned by number, that the block at that number still has
+// the pinned hash, so a read that landed on a reorged block is not reported as the pinned one
+func verifyBlockHash(ctx context.Context, opts FetchPriceDataOptions) error {
+	if opts.Block == nil || opts.Block.Hash == "" || pinsByHash(opts) {
+		return nil
+	}
+	headers, ok := opts.Client.(HeaderReader)
+	if !ok {
+		return nil
+	}
+
+	block, err := FetchBlockRef(ctx, headers, opts.Block.Number)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(block.Hash, opts.Block.Hash) {
+		return &ReorgError{Number: opts.Block.Number, Expected: opts.Block.Hash, Actual: block.Hash}
+	}
+	return nil
+}
+
+// retryFetch retries a failed price fetch as decided by prepareRetry. The returned error is
+// always a *FetchError carrying the category.
+func retryFetch(ctx context.Context, opts FetchPriceDataOptions, attempt int, op string, err error) (*types.ChainlinkPrice, error) {
+	if retryErr := prepareRetry(ctx, &opts, attempt, op, err); retryErr != nil {
+		return nil, retryErr
+	}
+
+	log.Printf("Retrying price fetch for feed %s on network %d (attempt %d)", opts.FeedAddress, opts.NetworkID, attempt+1)
+	return fetchPriceDataWithRetry(ctx, opts, attempt+1)
+}
+
+// prepareRetry classifies a failed call and, depending on the retry policy, switches opts.Client
+// to another RPC, backs off or gives up. It returns nil when the call should be retried and a
+// *FetchError carrying the category otherwise.
+func prepareRetry(ctx context.Context, opts *FetchPriceDataOptions, attempt int, op string, err error) error {
+	category := ClassifyError(err)
+	if ctx.Err() != nil {
+		category = ClassifyError(ctx.Err())
+	}
+	fetchErr := &FetchError{Op: op, Category: category, Err: err}
+
+	if attempt > opts.MaxRetries {
+		return fetchErr
+	}
+
+	policy := DefaultRetryPolicy()
+	if opts.RetryPolicy != nil {
+		policy = *opts.RetryPolicy
+	}
+	action := policy.ActionFor(category)
+	if action == RetrySwitch && opts.RPCSwitcher == nil {
+		action = RetryBackoff // Nothing to switch to, retry the same endpoint
+	}
+
+	switch action {
+	case RetrySwitch:
+		log.Printf("%s error on network %d, switching RPC endpoint (attempt %d): %v", category, opts.NetworkID, attempt, err)
+
+		// Trigger immediate RPC switching for this network
+		if switchErr := opts.RPCSwitcher.SwitchRPCEndpointImmediately(ctx, opts.NetworkID); switchErr != nil {
+			log.Printf("Failed to switch RPC endpoint for network %d: %v", opts.NetworkID, switchErr)
+			return fetchErr
+		}
+
+		// Get the new client
+		newClient, clientErr := opts.RPCSwitcher.GetBestClient(opts.NetworkID)
+		if clientErr != nil {
+			log.Printf("Failed to get new client for network %d: %v", opts.NetworkID, clientErr)
+			return fetchErr
+		}
+		opts.Client = newClient
+	case RetryBackoff:
+		log.Printf("%s error on network %d, backing off before retry (attempt %d): %v", category, opts.NetworkID, attempt, err)
+	default:
+		return fetchErr
+	}
+
+	// Exponential backoff: RetryDelay, 2*RetryDelay, 4*RetryDelay, ...
+	if sleepErr := ctxutil.Sleep(ctx, opts.RetryDelay<<(attempt-1)); sleepErr != nil {
+		return fetchErr
+	}
+	return nil
+}
+
+// bindAggregator returns the aggregator contract for the feed, using the metadata cache when configured
+func bindAggregator(opts FetchPriceDataOptions) (*aggregatorv3.AggregatorV3InterfaceCaller, error) {
+	if opts.MetadataCache != nil {
+		return opts.MetadataCache.Aggregator(opts.Client, opts.NetworkID, opts.FeedAddress)
+	}
+
+	aggregator, err := aggregatorv3.NewAggregatorV3InterfaceCaller(common.HexToAddress(opts.FeedAddress), opts.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aggregator contract: %v", err)
+	}
+	return aggregator, nil
+}
+
+// fetchDecimals returns the feed decimals, reading them from the contract only when they are not cached
+func fetchDecimals(ctx context.Context, opts FetchPriceDataOptions, aggregator *aggregatorv3.AggregatorV3InterfaceCaller) (uint8, error) {
+	if opts.MetadataCache != nil {
+		metadata, err := opts.MetadataCache.Get(ctx, opts.Client, opts.NetworkID, opts.FeedAddress)
+		if err != nil {
+			return 0, err
+		}
+		return metadata.Decimals, nil
+	}
+	return aggregator.Decimals(&bind.CallOpts{Context: ctx})
+}
+
 // IsErrorCode32097 checks if the error contains the specific error code -32097
 // This error code typically indicates execution reverted, which may require RPC switching
+//
+// Deprecated: it cannot tell a contract revert from a provider problem. Use ClassifyError.
 func IsErrorCode32097(err error) bool {
 	if err == nil {
 		return false
@@ -0,0 +1,186 @@
+package chainlink
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/morpheum-labs/pricefeeding/chainlink/chainlinktest"
+)
+
+func newSimulatedFeed(t *testing.T) (*chainlinktest.Chain, *chainlinktest.MockAggregator) {
+	t.Helper()
+	chain, err := chainlinktest.NewChain()
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	t.Cleanup(func() { chain.Close() })
+
+	mock, err := chain.DeployAggregator(8, "BTC / USD")
+	if err != nil {
+		t.Fatalf("Failed to deploy mock aggregator: %v", err)
+	}
+	return chain, mock
+}
+
+func TestFetchPriceDataSimulated(t *testing.T) {
+	chain, mock := newSimulatedFeed(t)
+	ctx := context.Background()
+
+	updatedAt := time.Now().Truncate(time.Second)
+	roundID, err := mock.PushRound(big.NewInt(6500000000000), updatedAt)
+	if err != nil {
+		t.Fatalf("PushRound failed: %v", err)
+	}
+
+	for _, cache := range []*MetadataCache{nil, NewMetadataCache(0)} {
+		price, err := FetchPriceData(ctx, FetchPriceDataOptions{
+			NetworkID:     chainlinktest.SimulatedChainID,
+			FeedAddress:   mock.Address.Hex(),
+			Client:        chain.Client,
+			MetadataCache: cache,
+		})
+		if err != nil {
+			t.Fatalf("FetchPriceData failed: %v", err)
+		}
+		if price.RoundID.Cmp(roundID) != 0 || price.Answer.Int64() != 6500000000000 || price.Exponent != -8 {
+			t.Errorf("Unexpected price %+v", price)
+		}
+		if price.UpdatedAt.Int64() != updatedAt.Unix() {
+			t.Errorf("Expected updatedAt %d, got %s", updatedAt.Unix(), price.UpdatedAt)
+		}
+	}
+}
+
+func TestFetchPriceDataAtBlockSimulated(t *testing.T) {
+	chain, mock := newSimulatedFeed(t)
+	ctx := context.Background()
+
+	first, err := mock.PushRound(big.NewInt(100), time.Now())
+	if err != nil {
+		t.Fatalf("PushRound failed: %v", err)
+	}
+	block, err := FetchBlockRef(ctx, chain.Client, nil)
+	if err != nil {
+		t.Fatalf("FetchBlockRef failed: %v", err)
+	}
+	if _, err := mock.PushRound(big.NewInt(200), time.Now()); err != nil {
+		t.Fatalf("PushRound failed: %v", err)
+	}
+
+	// Reading at the pinned block returns the round that was latest then
+	price, err := FetchPriceData(ctx, FetchPriceDataOptions{
+		NetworkID:   chainlinktest.SimulatedChainID,
+		FeedAddress: mock.Address.Hex(),
+		Client:      chain.Client,
+		Block:       block,
+	})
+	if err != nil {
+		t.Fatalf("FetchPriceData failed: %v", err)
+	}
+	if price.RoundID.Cmp(first) != 0 || price.Answer.Int64() != 100 {
+		t.Errorf("Expected round %s with answer 100 at block %s, got %+v", first, block.Number, price)
+	}
+}
+
+// numberPinnedClient hides the hash-based calls of a client, like RPC wrappers that only forward
+// CallContract and HeaderByNumber
+type numberPinnedClient struct {
+	bind.ContractCaller
+	HeaderReader
+}
+
+func TestFetchPriceDataAtReorgedBlockSimulated(t *testing.T) {
+	chain, mock := newSimulatedFeed(t)
+	ctx := context.Background()
+
+	if _, err := mock.PushRound(big.NewInt(100), time.Now()); err != nil {
+		t.Fatalf("PushRound failed: %v", err)
+	}
+	block, err := FetchBlockRef(ctx, chain.Client, nil)
+	if err != nil {
+		t.Fatalf("FetchBlockRef failed: %v", err)
+	}
+	// A block ref whose hash is no longer at its height, as after a reorg
+	reorged := &BlockRef{Number: block.Number, Hash: common.HexToHash("0x01").Hex(), Time: block.Time}
+
+	clients := map[string]bind.ContractCaller{
+		"hash":   chain.Client,
+		"number": numberPinnedClient{ContractCaller: chain.Client, HeaderReader: chain.Client},
+	}
+	for name, client := range clients {
+		opts := FetchPriceDataOptions{
+			NetworkID:   chainlinktest.SimulatedChainID,
+			FeedAddress: mock.Address.Hex(),
+			Client:      client,
+			Block:       block,
+			MaxRetries:  -1,
+		}
+		if _, err := FetchPriceData(ctx, opts); err != nil {
+			t.Fatalf("%s: FetchPriceData at the pinned block failed: %v", name, err)
+		}
+
+		opts.Block = reorged
+		if _, err := FetchPriceData(ctx, opts); err == nil {
+			t.Errorf("%s: Expected reading at a reorged block to fail", name)
+		}
+	}
+
+	// Reads pinned by number report the reorg explicitly
+	_, err = FetchPriceData(ctx, FetchPriceDataOptions{
+		NetworkID:   chainlinktest.SimulatedChainID,
+		FeedAddress: mock.Address.Hex(),
+		Client:      clients["number"],
+		Block:       reorged,
+	})
+	var reorgErr *ReorgError
+	if !errors.As(err, &reorgErr) || reorgErr.Actual != block.Hash {
+		t.Errorf("Expected a ReorgError, got %v", err)
+	}
+}
+
+func TestVerifyFeedSimulated(t *testing.T) {
+	chain, mock := newSimulatedFeed(t)
+	ctx := context.Background()
+
+	metadata, errs := VerifyFeed(ctx, chain.Client, chainlinktest.SimulatedChainID, mock.Address.Hex(),
+		ExpectedFeedMetadata{Symbol: "BTC/USD", Decimals: 8})
+	if len(errs) != 0 {
+		t.Fatalf("Expected feed to verify, got %v", errs)
+	}
+	if metadata.Description != "BTC / USD" || metadata.Decimals != 8 {
+		t.Errorf("Unexpected metadata %+v", metadata)
+	}
+
+	_, errs = VerifyFeed(ctx, chain.Client, chainlinktest.SimulatedChainID, mock.Address.Hex(),
+		ExpectedFeedMetadata{Symbol: "ETH/USD", Decimals: 18})
+	if len(errs) != 2 {
+		t.Errorf("Expected description and decimals mismatches, got %v", errs)
+	}
+}
+
+func TestFetchProxyPhaseSimulated(t *testing.T) {
+	chain, mock := newSimulatedFeed(t)
+	ctx := context.Background()
+
+	phase, err := FetchProxyPhase(ctx, chain.Client, chainlinktest.SimulatedChainID, mock.Address.Hex())
+	if err != nil {
+		t.Fatalf("FetchProxyPhase failed: %v", err)
+	}
+	if phase.PhaseID != chainlinktest.MockPhaseID || phase.Aggregator != mock.Address.Hex() {
+		t.Errorf("Unexpected phase %+v", phase)
+	}
+
+	roundID, err := mock.PushRound(big.NewInt(1), time.Now())
+	if err != nil {
+		t.Fatalf("PushRound failed: %v", err)
+	}
+	if PhaseIDFromRoundID(roundID) != phase.PhaseID {
+		t.Errorf("Expected pushed round %s to be in phase %d", roundID, phase.PhaseID)
+	}
+}
@@ -0,0 +1,217 @@
+package chainlink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrorCategory is the class of a failed RPC call, used to decide whether to retry, switch RPCs or give up
+type ErrorCategory string
+
+const (
+	// ErrorRateLimited means the provider throttled the request (HTTP 429, -32005)
+	ErrorRateLimited ErrorCategory = "rate_limited"
+	// ErrorTimeout means the request did not complete in time (deadline, network timeout, HTTP 408/504)
+	ErrorTimeout ErrorCategory = "timeout"
+	// ErrorNodeUnhealthy means the endpoint is broken, unreachable or missing state (5xx, -32603, -32601, -32097)
+	ErrorNodeUnhealthy ErrorCategory = "node_unhealthy"
+	// ErrorRevert means the contract call itself failed (execution reverted, no code at the address).
+	// Another RPC will give the same answer, e.g. for a wrong feed address.
+	ErrorRevert ErrorCategory = "revert"
+	// ErrorInvalidParams means the request was malformed (-32602, -32600, HTTP 400)
+	ErrorInvalidParams ErrorCategory = "invalid_params"
+	// ErrorCanceled means the caller cancelled the context
+	ErrorCanceled ErrorCategory = "canceled"
+	// ErrorUnknown is used for errors that match no other category
+	ErrorUnknown ErrorCategory = "unknown"
+)
+
+// JSON-RPC error codes used by the classifier
+const (
+	rpcCodeExecutionReverted = 3      // geth: execution reverted with revert data
+	rpcCodeServerError       = -32000 // generic server error, meaning depends on the message
+	rpcCodeLimitExceeded     = -32005 // EIP-1474: request exceeds defined limit
+	rpcCodeVMExecutionError  = -32015 // Nethermind/Parity: VM execution error
+	rpcCodeProviderError     = -32097 // provider-specific error that historically triggered an RPC switch
+	rpcCodeInvalidRequest    = -32600
+	rpcCodeMethodNotFound    = -32601
+	rpcCodeInvalidParams     = -32602
+	rpcCodeInternalError     = -32603
+)
+
+// FetchError is returned by FetchPriceData when an RPC call fails; Category tells why
+type FetchError struct {
+	Op       string // What failed, e.g. "failed to get latest round data"
+	Category ErrorCategory
+	Err      error
+}
+
+// Error implements the error interface
+func (e *FetchError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Op, e.Err)
+}
+
+// Unwrap returns the underlying RPC error
+func (e *FetchError) Unwrap() error {
+	return e.Err
+}
+
+// ClassifyError maps an RPC or contract call error to an ErrorCategory. Only typed errors are
+// looked at: context and network errors, rpc.HTTPError statuses and rpc.Error codes, whose server
+// message decides for the generic codes. Errors of any other type are ErrorUnknown. nil returns "".
+func ClassifyError(err error) ErrorCategory {
+	if err == nil {
+		return ""
+	}
+
+	var fetchErr *FetchError
+	if errors.As(err, &fetchErr) && fetchErr.Category != "" {
+		return fetchErr.Category
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return ErrorCanceled
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrorTimeout
+	}
+	if errors.Is(err, bind.ErrNoCode) {
+		return ErrorRevert
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return classifyHTTPStatus(httpErr.StatusCode)
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return classifyRPCCode(rpcErr.ErrorCode(), rpcErr.Error())
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return ErrorTimeout
+		}
+		return ErrorNodeUnhealthy
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, rpc.ErrClientQuit) {
+		return ErrorNodeUnhealthy
+	}
+
+	return ErrorUnknown
+}
+
+// classifyHTTPStatus maps the HTTP status of a failed JSON-RPC request to a category
+func classifyHTTPStatus(statusCode int) ErrorCategory {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return ErrorRateLimited
+	case statusCode == http.StatusRequestTimeout || statusCode == http.StatusGatewayTimeout:
+		return ErrorTimeout
+	case statusCode == http.StatusBadRequest:
+		return ErrorInvalidParams
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden || statusCode >= 500:
+		return ErrorNodeUnhealthy
+	default:
+		return ErrorUnknown
+	}
+}
+
+// classifyRPCCode maps a JSON-RPC error code to a category. The generic -32000 code is used by
+// clients for anything from reverts to missing state, so its message decides.
+func classifyRPCCode(code int, message string) ErrorCategory {
+	switch code {
+	case rpcCodeExecutionReverted, rpcCodeVMExecutionError:
+		return ErrorRevert
+	case rpcCodeLimitExceeded:
+		return ErrorRateLimited
+	case rpcCodeInvalidParams, rpcCodeInvalidRequest:
+		return ErrorInvalidParams
+	case rpcCodeMethodNotFound, rpcCodeInternalError, rpcCodeProviderError:
+		return ErrorNodeUnhealthy
+	case rpcCodeServerError:
+		if category := classifyServerMessage(message); category != ErrorUnknown {
+			return category
+		}
+		return ErrorNodeUnhealthy
+	default:
+		return classifyServerMessage(message)
+	}
+}
+
+// classifyServerMessage classifies the message of a JSON-RPC error by the phrases geth-compatible
+// servers use for codes that do not identify the problem on their own
+func classifyServerMessage(message string) ErrorCategory {
+	message = strings.ToLower(message)
+
+	switch {
+	case strings.HasPrefix(message, "execution reverted"):
+		return ErrorRevert
+	case strings.Contains(message, "too many requests") || strings.Contains(message, "rate limit") ||
+		strings.Contains(message, "limit exceeded"):
+		return ErrorRateLimited
+	case strings.Contains(message, "timeout") || strings.Contains(message, "timed out"):
+		return ErrorTimeout
+	case strings.HasPrefix(message, "invalid argument") || strings.HasPrefix(message, "invalid params"):
+		return ErrorInvalidParams
+	case strings.Contains(message, "header not found") || strings.Contains(message, "missing trie node"):
+		return ErrorNodeUnhealthy
+	default:
+		return ErrorUnknown
+	}
+}
+
+// RetryAction is what FetchPriceData does after a failed call
+type RetryAction string
+
+const (
+	// RetrySwitch switches to another RPC endpoint (through the RPCSwitcher) and retries
+	RetrySwitch RetryAction = "switch"
+	// RetryBackoff retries on the same endpoint after an exponentially growing delay
+	RetryBackoff RetryAction = "backoff"
+	// RetryFailFast returns the error immediately
+	RetryFailFast RetryAction = "fail_fast"
+)
+
+// RetryPolicy decides the RetryAction for each ErrorCategory
+type RetryPolicy struct {
+	Actions map[ErrorCategory]RetryAction
+	Default RetryAction // Used for categories without an entry
+}
+
+// DefaultRetryPolicy switches RPCs on node problems and timeouts, backs off when rate limited
+// and fails fast on reverts, invalid params and cancellation
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		Actions: map[ErrorCategory]RetryAction{
+			ErrorRateLimited:   RetryBackoff,
+			ErrorTimeout:       RetrySwitch,
+			ErrorNodeUnhealthy: RetrySwitch,
+			ErrorRevert:        RetryFailFast,
+			ErrorInvalidParams: RetryFailFast,
+			ErrorCanceled:      RetryFailFast,
+		},
+		Default: RetryBackoff,
+	}
+}
+
+// ActionFor returns the action for a category
+func (p RetryPolicy) ActionFor(category ErrorCategory) RetryAction {
+	if action, exists := p.Actions[category]; exists {
+		return action
+	}
+	if p.Default != "" {
+		return p.Default
+	}
+	return RetryFailFast
+}
@@ -0,0 +1,156 @@
+package chainlink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testRPCError implements rpc.Error
+type testRPCError struct {
+	code    int
+	message string
+}
+
+func (e *testRPCError) Error() string  { return e.message }
+func (e *testRPCError) ErrorCode() int { return e.code }
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCategory
+	}{
+		{"nil", nil, ""},
+		{"http 429", rpc.HTTPError{StatusCode: 429, Status: "429 Too Many Requests"}, ErrorRateLimited},
+		{"http 503", rpc.HTTPError{StatusCode: 503, Status: "503 Service Unavailable"}, ErrorNodeUnhealthy},
+		{"http 504", rpc.HTTPError{StatusCode: 504, Status: "504 Gateway Timeout"}, ErrorTimeout},
+		{"limit exceeded", &testRPCError{-32005, "limit exceeded"}, ErrorRateLimited},
+		{"invalid params", &testRPCError{-32602, "invalid argument 0"}, ErrorInvalidParams},
+		{"revert with data", &testRPCError{3, "execution reverted"}, ErrorRevert},
+		{"revert without data", &testRPCError{-32000, "execution reverted"}, ErrorRevert},
+		{"missing state", &testRPCError{-32000, "header not found"}, ErrorNodeUnhealthy},
+		{"provider error", &testRPCError{-32097, "internal provider error"}, ErrorNodeUnhealthy},
+		{"wrapped rpc error", fmt.Errorf("call failed: %w", &testRPCError{-32005, "limit exceeded"}), ErrorRateLimited},
+		{"deadline", context.DeadlineExceeded, ErrorTimeout},
+		{"canceled", context.Canceled, ErrorCanceled},
+		{"net timeout", &net.DNSError{Err: "i/o timeout", IsTimeout: true}, ErrorTimeout},
+		{"server rate limit message", &testRPCError{-32000, "rate limit reached"}, ErrorRateLimited},
+		{"metadata revert", fmt.Errorf("failed to get description: %w", &testRPCError{3, "execution reverted"}), ErrorRevert},
+		{"eof", fmt.Errorf("read failed: %w", io.ErrUnexpectedEOF), ErrorNodeUnhealthy},
+		{"untyped revert message", errors.New("failed to get description: execution reverted"), ErrorUnknown},
+		{"untyped status in message", errors.New("feed 0x429 is stale"), ErrorUnknown},
+		{"untyped eof in message", errors.New("reached the geoffrey feed"), ErrorUnknown},
+		{"unknown", errors.New("something odd"), ErrorUnknown},
+		{"fetch error", &FetchError{Op: "failed", Category: ErrorRateLimited, Err: errors.New("x")}, ErrorRateLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyError(tt.err); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// countingSwitcher records RPC switches and fails to provide a new client, ending the retry
+type countingSwitcher struct {
+	switches int
+}
+
+func (s *countingSwitcher) SwitchRPCEndpointImmediately(ctx context.Context, networkID uint64) error {
+	s.switches++
+	return nil
+}
+
+func (s *countingSwitcher) GetBestClient(networkID uint64) (bind.ContractCaller, error) {
+	return nil, errors.New("no client")
+}
+
+func TestRetryFetchFollowsPolicy(t *testing.T) {
+	opts := FetchPriceDataOptions{NetworkID: 42161, MaxRetries: 1, RetryDelay: time.Millisecond}
+
+	// A revert fails fast without touching the RPC switcher
+	switcher := &countingSwitcher{}
+	opts.RPCSwitcher = switcher
+	_, err := retryFetch(context.Background(), opts, 1, "failed to get latest round data", &testRPCError{3, "execution reverted"})
+	var fetchErr *FetchError
+	if !errors.As(err, &fetchErr) || fetchErr.Category != ErrorRevert {
+		t.Fatalf("Expected a revert FetchError, got %v", err)
+	}
+	if switcher.switches != 0 {
+		t.Errorf("Expected no RPC switch on revert, got %d", switcher.switches)
+	}
+
+	// An unhealthy node triggers a switch
+	_, err = retryFetch(context.Background(), opts, 1, "failed to get latest round data", rpc.HTTPError{StatusCode: 502, Status: "502 Bad Gateway"})
+	if ClassifyError(err) != ErrorNodeUnhealthy {
+		t.Errorf("Expected node_unhealthy, got %q", ClassifyError(err))
+	}
+	if switcher.switches != 1 {
+		t.Errorf("Expected 1 RPC switch, got %d", switcher.switches)
+	}
+
+	// A custom policy can turn switching off
+	policy := RetryPolicy{Actions: map[ErrorCategory]RetryAction{ErrorNodeUnhealthy: RetryFailFast}}
+	opts.RetryPolicy = &policy
+	retryFetch(context.Background(), opts, 1, "failed to get latest round data", rpc.HTTPError{StatusCode: 502, Status: "502 Bad Gateway"})
+	if switcher.switches != 1 {
+		t.Errorf("Expected the custom policy to fail fast, got %d switches", switcher.switches)
+	}
+}
+
+// failingCaller fails every contract call with err
+type failingCaller struct {
+	err error
+}
+
+func (c failingCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return nil, c.err
+}
+
+func (c failingCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return nil, c.err
+}
+
+func TestFetchFeedMetadataWithRetryFollowsPolicy(t *testing.T) {
+	switcher := &countingSwitcher{}
+	opts := FetchPriceDataOptions{
+		NetworkID:   42161,
+		FeedAddress: "0x6ce185860a4963106506C203335A2910413708e9",
+		RPCSwitcher: switcher,
+		MaxRetries:  1,
+		RetryDelay:  time.Millisecond,
+	}
+
+	// A revert fails fast and keeps its category through the metadata error wrapping
+	opts.Client = failingCaller{err: &testRPCError{3, "execution reverted"}}
+	_, err := FetchFeedMetadataWithRetry(context.Background(), opts)
+	if ClassifyError(err) != ErrorRevert {
+		t.Fatalf("Expected revert, got %q (%v)", ClassifyError(err), err)
+	}
+	if switcher.switches != 0 {
+		t.Errorf("Expected no RPC switch on revert, got %d", switcher.switches)
+	}
+
+	// An unhealthy node switches RPCs like a price read
+	opts.Client = failingCaller{err: rpc.HTTPError{StatusCode: 502, Status: "502 Bad Gateway"}}
+	_, errs := VerifyFeedWithRetry(context.Background(), opts, ExpectedFeedMetadata{Symbol: "BTC/USD"})
+	if len(errs) != 1 || errs[0].Kind != FeedValidationNotAggregator || ClassifyError(errs[0]) != ErrorNodeUnhealthy {
+		t.Fatalf("Expected a node_unhealthy validation error, got %v", errs)
+	}
+	if switcher.switches != 1 {
+		t.Errorf("Expected 1 RPC switch, got %d", switcher.switches)
+	}
+}
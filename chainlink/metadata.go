@@ -0,0 +1,183 @@
+package chainlink
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	aggregatorv3 "github.com/morpheum-labs/pricefeeding/aggregatorv3"
+)
+
+// FeedMetadata contains the static metadata exposed by a Chainlink aggregator
+type FeedMetadata struct {
+	Description string   // e.g. "BTC / USD"
+	Decimals    uint8    // Number of decimals in the answer
+	Version     *big.Int // Aggregator version
+}
+
+// ExpectedFeedMetadata contains the configured values a feed is checked against
+type ExpectedFeedMetadata struct {
+	Symbol   string // Configured symbol (e.g. "BTC/USD"), empty skips the description check
+	Decimals int    // Configured decimals, 0 skips the decimals check
+}
+
+// FeedValidationKind identifies the type of a feed validation failure
+type FeedValidationKind string
+
+const (
+	// FeedValidationNotAggregator means the address did not answer the aggregator calls
+	// (wrong proxy address, wrong chain or a contract that is not a Chainlink feed)
+	FeedValidationNotAggregator FeedValidationKind = "not_aggregator"
+	// FeedValidationSymbolMismatch means description() does not match the configured symbol
+	FeedValidationSymbolMismatch FeedValidationKind = "symbol_mismatch"
+	// FeedValidationDecimalsMismatch means decimals() does not match the configured decimals
+	FeedValidationDecimalsMismatch FeedValidationKind = "decimals_mismatch"
+)
+
+// FeedValidationError describes a mismatch between a feed's on-chain metadata and its configuration
+type FeedValidationError struct {
+	NetworkID   uint64
+	FeedAddress string
+	Kind        FeedValidationKind
+	Expected    string
+	Actual      string
+	Err         error // Underlying RPC error, set for FeedValidationNotAggregator
+}
+
+// Error implements the error interface
+func (e *FeedValidationError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("feed %s on network %d failed validation (%s): %v", e.FeedAddress, e.NetworkID, e.Kind, e.Err)
+	}
+	return fmt.Sprintf("feed %s on network %d failed validation (%s): expected %q, got %q", e.FeedAddress, e.NetworkID, e.Kind, e.Expected, e.Actual)
+}
+
+// Unwrap returns the underlying RPC error, if any
+func (e *FeedValidationError) Unwrap() error {
+	return e.Err
+}
+
+// FetchFeedMetadata reads description(), decimals() and version() from a Chainlink aggregator
+func FetchFeedMetadata(ctx context.Context, client bind.ContractCaller, feedAddress string) (*FeedMetadata, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+	if feedAddress == "" {
+		return nil, fmt.Errorf("feed address cannot be empty")
+	}
+
+	aggregator, err := aggregatorv3.NewAggregatorV3InterfaceCaller(common.HexToAddress(feedAddress), client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aggregator contract: %w", err)
+	}
+
+	description, err := aggregator.Description(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get description: %w", err)
+	}
+
+	decimals, err := aggregator.Decimals(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get decimals: %w", err)
+	}
+
+	version, err := aggregator.Version(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version: %w", err)
+	}
+
+	return &FeedMetadata{
+		Description: description,
+		Decimals:    decimals,
+		Version:     version,
+	}, nil
+}
+
+// FetchFeedMetadataWithRetry reads the metadata of opts.FeedAddress like FetchFeedMetadata,
+// retrying failed calls with the retry policy and RPC switching of FetchPriceData. Only the
+// client, retry and network fields of opts are used.
+func FetchFeedMetadataWithRetry(ctx context.Context, opts FetchPriceDataOptions) (*FeedMetadata, error) {
+	if opts.Client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+	if opts.FeedAddress == "" {
+		return nil, fmt.Errorf("feed address cannot be empty")
+	}
+	setRetryDefaults(&opts)
+
+	for attempt := 1; ; attempt++ {
+		metadata, err := FetchFeedMetadata(ctx, opts.Client, opts.FeedAddress)
+		if err == nil {
+			return metadata, nil
+		}
+		if retryErr := prepareRetry(ctx, &opts, attempt, "failed to fetch feed metadata", err); retryErr != nil {
+			return nil, retryErr
+		}
+		log.Printf("Retrying metadata fetch for feed %s on network %d (attempt %d)", opts.FeedAddress, opts.NetworkID, attempt+1)
+	}
+}
+
+// VerifyFeed fetches the metadata of a feed and validates it against the expected configuration.
+// The returned metadata is nil when the address is not a live aggregator.
+func VerifyFeed(ctx context.Context, client bind.ContractCaller, networkID uint64, feedAddress string, expected ExpectedFeedMetadata) (*FeedMetadata, []*FeedValidationError) {
+	metadata, err := FetchFeedMetadata(ctx, client, feedAddress)
+	return verifyFetchedMetadata(networkID, feedAddress, expected, metadata, err)
+}
+
+// VerifyFeedWithRetry is VerifyFeed with the retry policy and RPC switching of FetchPriceData,
+// so a failing endpoint is switched away from instead of marking the feed as not an aggregator
+func VerifyFeedWithRetry(ctx context.Context, opts FetchPriceDataOptions, expected ExpectedFeedMetadata) (*FeedMetadata, []*FeedValidationError) {
+	metadata, err := FetchFeedMetadataWithRetry(ctx, opts)
+	return verifyFetchedMetadata(opts.NetworkID, opts.FeedAddress, expected, metadata, err)
+}
+
+// verifyFetchedMetadata validates fetched metadata, or reports the fetch error as FeedValidationNotAggregator
+func verifyFetchedMetadata(networkID uint64, feedAddress string, expected ExpectedFeedMetadata, metadata *FeedMetadata, err error) (*FeedMetadata, []*FeedValidationError) {
+	if err != nil {
+		return nil, []*FeedValidationError{{
+			NetworkID:   networkID,
+			FeedAddress: feedAddress,
+			Kind:        FeedValidationNotAggregator,
+			Err:         err,
+		}}
+	}
+	return metadata, ValidateFeedMetadata(networkID, feedAddress, expected, metadata)
+}
+
+// ValidateFeedMetadata compares on-chain feed metadata with the expected configuration
+func ValidateFeedMetadata(networkID uint64, feedAddress string, expected ExpectedFeedMetadata, metadata *FeedMetadata) []*FeedValidationError {
+	var validationErrors []*FeedValidationError
+
+	if expected.Symbol != "" && normalizeFeedSymbol(expected.Symbol) != normalizeFeedSymbol(metadata.Description) {
+		validationErrors = append(validationErrors, &FeedValidationError{
+			NetworkID:   networkID,
+			FeedAddress: feedAddress,
+			Kind:        FeedValidationSymbolMismatch,
+			Expected:    expected.Symbol,
+			Actual:      metadata.Description,
+		})
+	}
+
+	if expected.Decimals != 0 && expected.Decimals != int(metadata.Decimals) {
+		validationErrors = append(validationErrors, &FeedValidationError{
+			NetworkID:   networkID,
+			FeedAddress: feedAddress,
+			Kind:        FeedValidationDecimalsMismatch,
+			Expected:    strconv.Itoa(expected.Decimals),
+			Actual:      strconv.Itoa(int(metadata.Decimals)),
+		})
+	}
+
+	return validationErrors
+}
+
+// normalizeFeedSymbol makes "BTC/USD" and the on-chain description "BTC / USD" comparable
+func normalizeFeedSymbol(symbol string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(symbol), ""))
+}
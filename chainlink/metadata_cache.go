@@ -0,0 +1,143 @@
+package chainlink
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	aggregatorv3 "github.com/morpheum-labs/pricefeeding/aggregatorv3"
+)
+
+// DefaultMetadataTTL is how long cached aggregator metadata is trusted before it is read again
+const DefaultMetadataTTL = 24 * time.Hour
+
+// MetadataCache caches static aggregator metadata (decimals, description, version) and the
+// bound aggregator contract per (network, feed), so polling only needs latestRoundData().
+// Entries are refreshed when their TTL expires or when they are invalidated, e.g. after
+// an aggregator upgrade.
+type MetadataCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[metadataCacheKey]*metadataCacheEntry
+}
+
+// metadataCacheKey identifies a feed on a network
+type metadataCacheKey struct {
+	networkID   uint64
+	feedAddress string
+}
+
+// metadataCacheEntry holds the cached metadata and contract binding of a single feed
+type metadataCacheEntry struct {
+	metadata   *FeedMetadata
+	fetchedAt  time.Time
+	client     bind.ContractCaller                       // Client the binding was created with
+	aggregator *aggregatorv3.AggregatorV3InterfaceCaller // Bound contract, rebuilt when the client changes
+}
+
+// NewMetadataCache creates a new metadata cache. A ttl of 0 uses DefaultMetadataTTL.
+func NewMetadataCache(ttl time.Duration) *MetadataCache {
+	if ttl == 0 {
+		ttl = DefaultMetadataTTL
+	}
+	return &MetadataCache{
+		ttl:     ttl,
+		entries: make(map[metadataCacheKey]*metadataCacheEntry),
+	}
+}
+
+// newMetadataCacheKey normalizes the feed address so checksummed and lowercase addresses share an entry
+func newMetadataCacheKey(networkID uint64, feedAddress string) metadataCacheKey {
+	return metadataCacheKey{networkID: networkID, feedAddress: strings.ToLower(feedAddress)}
+}
+
+// Get returns the cached metadata for a feed, reading it from the aggregator when it is
+// missing or older than the TTL
+func (mc *MetadataCache) Get(ctx context.Context, client bind.ContractCaller, networkID uint64, feedAddress string) (*FeedMetadata, error) {
+	key := newMetadataCacheKey(networkID, feedAddress)
+
+	mc.mu.RLock()
+	entry, exists := mc.entries[key]
+	if exists && entry.metadata != nil && time.Since(entry.fetchedAt) < mc.ttl {
+		metadata := entry.metadata
+		mc.mu.RUnlock()
+		return metadata, nil
+	}
+	mc.mu.RUnlock()
+
+	metadata, err := FetchFeedMetadata(ctx, client, feedAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	mc.Set(networkID, feedAddress, metadata)
+	return metadata, nil
+}
+
+// Set stores metadata that was read elsewhere (e.g. during feed validation)
+func (mc *MetadataCache) Set(networkID uint64, feedAddress string, metadata *FeedMetadata) {
+	key := newMetadataCacheKey(networkID, feedAddress)
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	entry, exists := mc.entries[key]
+	if !exists {
+		entry = &metadataCacheEntry{}
+		mc.entries[key] = entry
+	}
+	entry.metadata = metadata
+	entry.fetchedAt = time.Now()
+}
+
+// Aggregator returns the bound aggregator contract for a feed, reusing the binding as long as
+// the client has not changed (e.g. after an RPC switch)
+func (mc *MetadataCache) Aggregator(client bind.ContractCaller, networkID uint64, feedAddress string) (*aggregatorv3.AggregatorV3InterfaceCaller, error) {
+	key := newMetadataCacheKey(networkID, feedAddress)
+
+	mc.mu.RLock()
+	entry, exists := mc.entries[key]
+	if exists && entry.aggregator != nil && entry.client == client {
+		aggregator := entry.aggregator
+		mc.mu.RUnlock()
+		return aggregator, nil
+	}
+	mc.mu.RUnlock()
+
+	aggregator, err := aggregatorv3.NewAggregatorV3InterfaceCaller(common.HexToAddress(feedAddress), client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aggregator contract: %v", err)
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	entry, exists = mc.entries[key]
+	if !exists {
+		entry = &metadataCacheEntry{}
+		mc.entries[key] = entry
+	}
+	entry.client = client
+	entry.aggregator = aggregator
+
+	return aggregator, nil
+}
+
+// Invalidate drops the cached metadata and binding of a feed so the next read refreshes them
+func (mc *MetadataCache) Invalidate(networkID uint64, feedAddress string) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	delete(mc.entries, newMetadataCacheKey(networkID, feedAddress))
+}
+
+// InvalidateAll drops every cached entry
+func (mc *MetadataCache) InvalidateAll() {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.entries = make(map[metadataCacheKey]*metadataCacheEntry)
+}
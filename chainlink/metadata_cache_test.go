@@ -0,0 +1,209 @@
+package chainlink
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/morpheum-labs/pricefeeding/chainlink/chainlinktest"
+)
+
+// countingCaller counts the contract calls made through a caller
+type countingCaller struct {
+	bind.ContractCaller
+	calls atomic.Int64
+}
+
+func (c *countingCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.calls.Add(1)
+	return c.ContractCaller.CallContract(ctx, call, blockNumber)
+}
+
+func TestMetadataCacheGetHit(t *testing.T) {
+	chain, mock := newSimulatedFeed(t)
+	caller := &countingCaller{ContractCaller: chain.Client}
+	cache := NewMetadataCache(0)
+	ctx := context.Background()
+
+	metadata, err := cache.Get(ctx, caller, chainlinktest.SimulatedChainID, mock.Address.Hex())
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if metadata.Decimals != 8 || metadata.Description != "BTC / USD" {
+		t.Errorf("Unexpected metadata %+v", metadata)
+	}
+	calls := caller.calls.Load()
+	if calls == 0 {
+		t.Fatalf("Expected the first Get to read the aggregator")
+	}
+
+	// Lowercase and checksummed addresses share an entry, so neither reads the aggregator again
+	for _, address := range []string{mock.Address.Hex(), strings.ToLower(mock.Address.Hex())} {
+		cached, err := cache.Get(ctx, caller, chainlinktest.SimulatedChainID, address)
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if cached != metadata {
+			t.Errorf("Expected the cached metadata for %s", address)
+		}
+	}
+	if got := caller.calls.Load(); got != calls {
+		t.Errorf("Expected cache hits to make no calls, got %d more", got-calls)
+	}
+
+	// Another network is a separate entry
+	if _, err := cache.Get(ctx, caller, chainlinktest.SimulatedChainID+1, mock.Address.Hex()); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if caller.calls.Load() == calls {
+		t.Errorf("Expected a read for another network")
+	}
+}
+
+func TestMetadataCacheExpires(t *testing.T) {
+	chain, mock := newSimulatedFeed(t)
+	caller := &countingCaller{ContractCaller: chain.Client}
+	cache := NewMetadataCache(time.Millisecond)
+	ctx := context.Background()
+
+	if _, err := cache.Get(ctx, caller, chainlinktest.SimulatedChainID, mock.Address.Hex()); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	calls := caller.calls.Load()
+
+	time.Sleep(5 * time.Millisecond)
+	if _, err := cache.Get(ctx, caller, chainlinktest.SimulatedChainID, mock.Address.Hex()); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if caller.calls.Load() == calls {
+		t.Errorf("Expected an expired entry to be read again")
+	}
+}
+
+func TestMetadataCacheInvalidate(t *testing.T) {
+	chain, mock := newSimulatedFeed(t)
+	caller := &countingCaller{ContractCaller: chain.Client}
+	cache := NewMetadataCache(0)
+	ctx := context.Background()
+	address := mock.Address.Hex()
+
+	// Metadata set elsewhere is served without a read until it is invalidated
+	stale := &FeedMetadata{Description: "ETH / USD", Decimals: 18}
+	cache.Set(chainlinktest.SimulatedChainID, address, stale)
+	metadata, err := cache.Get(ctx, caller, chainlinktest.SimulatedChainID, address)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if metadata != stale || caller.calls.Load() != 0 {
+		t.Fatalf("Expected the stored metadata without a read, got %+v after %d calls", metadata, caller.calls.Load())
+	}
+
+	aggregator, err := cache.Aggregator(caller, chainlinktest.SimulatedChainID, address)
+	if err != nil {
+		t.Fatalf("Aggregator failed: %v", err)
+	}
+
+	cache.Invalidate(chainlinktest.SimulatedChainID, strings.ToLower(address))
+
+	metadata, err = cache.Get(ctx, caller, chainlinktest.SimulatedChainID, address)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if metadata.Description != "BTC / USD" || metadata.Decimals != 8 {
+		t.Errorf("Expected metadata read from the aggregator, got %+v", metadata)
+	}
+	rebound, err := cache.Aggregator(caller, chainlinktest.SimulatedChainID, address)
+	if err != nil {
+		t.Fatalf("Aggregator failed: %v", err)
+	}
+	if rebound == aggregator {
+		t.Errorf("Expected Invalidate to drop the binding")
+	}
+
+	// InvalidateAll drops every entry
+	cache.Set(chainlinktest.SimulatedChainID, address, stale)
+	cache.InvalidateAll()
+	metadata, err = cache.Get(ctx, caller, chainlinktest.SimulatedChainID, address)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if metadata == stale {
+		t.Errorf("Expected InvalidateAll to drop the stored metadata")
+	}
+}
+
+func TestMetadataCacheAggregatorRebindsOnClientChange(t *testing.T) {
+	chain, mock := newSimulatedFeed(t)
+	cache := NewMetadataCache(0)
+	address := mock.Address.Hex()
+
+	first, err := cache.Aggregator(chain.Client, chainlinktest.SimulatedChainID, address)
+	if err != nil {
+		t.Fatalf("Aggregator failed: %v", err)
+	}
+	same, err := cache.Aggregator(chain.Client, chainlinktest.SimulatedChainID, address)
+	if err != nil {
+		t.Fatalf("Aggregator failed: %v", err)
+	}
+	if same != first {
+		t.Errorf("Expected the binding to be reused for the same client")
+	}
+
+	switched, err := cache.Aggregator(&countingCaller{ContractCaller: chain.Client}, chainlinktest.SimulatedChainID, address)
+	if err != nil {
+		t.Fatalf("Aggregator failed: %v", err)
+	}
+	if switched == first {
+		t.Errorf("Expected a new binding after the client changed")
+	}
+}
+
+func TestMetadataCacheConcurrentAccess(t *testing.T) {
+	chain, mock := newSimulatedFeed(t)
+	cache := NewMetadataCache(0)
+	ctx := context.Background()
+	address := mock.Address.Hex()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				switch (i + j) % 4 {
+				case 0:
+					metadata, err := cache.Get(ctx, chain.Client, chainlinktest.SimulatedChainID, address)
+					if err != nil {
+						errs <- err
+						return
+					}
+					if metadata.Decimals != 8 {
+						t.Errorf("Unexpected decimals %d", metadata.Decimals)
+					}
+				case 1:
+					if _, err := cache.Aggregator(chain.Client, chainlinktest.SimulatedChainID, address); err != nil {
+						errs <- err
+						return
+					}
+				case 2:
+					cache.Set(chainlinktest.SimulatedChainID, address, &FeedMetadata{Description: "BTC / USD", Decimals: 8})
+				case 3:
+					cache.Invalidate(chainlinktest.SimulatedChainID, address)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("Concurrent access failed: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package chainlink
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestValidateFeedMetadataMatch(t *testing.T) {
+	metadata := &FeedMetadata{Description: "BTC / USD", Decimals: 8, Version: big.NewInt(4)}
+	expected := ExpectedFeedMetadata{Symbol: "BTC/USD", Decimals: 8}
+
+	validationErrors := ValidateFeedMetadata(42161, "0x6ce185860a4963106506C203335A2910413708e9", expected, metadata)
+	if len(validationErrors) != 0 {
+		t.Fatalf("Expected no validation errors, got %v", validationErrors)
+	}
+}
+
+func TestValidateFeedMetadataMismatch(t *testing.T) {
+	metadata := &FeedMetadata{Description: "ETH / USD", Decimals: 18, Version: big.NewInt(4)}
+	expected := ExpectedFeedMetadata{Symbol: "BTC/USD", Decimals: 8}
+
+	validationErrors := ValidateFeedMetadata(42161, "0x6ce185860a4963106506C203335A2910413708e9", expected, metadata)
+	if len(validationErrors) != 2 {
+		t.Fatalf("Expected 2 validation errors, got %d", len(validationErrors))
+	}
+
+	if validationErrors[0].Kind != FeedValidationSymbolMismatch {
+		t.Errorf("Expected kind %s, got %s", FeedValidationSymbolMismatch, validationErrors[0].Kind)
+	}
+	if validationErrors[1].Kind != FeedValidationDecimalsMismatch {
+		t.Errorf("Expected kind %s, got %s", FeedValidationDecimalsMismatch, validationErrors[1].Kind)
+	}
+	if validationErrors[1].Expected != "8" || validationErrors[1].Actual != "18" {
+		t.Errorf("Expected decimals 8 vs 18, got %s vs %s", validationErrors[1].Expected, validationErrors[1].Actual)
+	}
+}
+
+func TestValidateFeedMetadataSkipsUnsetExpectations(t *testing.T) {
+	metadata := &FeedMetadata{Description: "NVDA / USD", Decimals: 2, Version: big.NewInt(4)}
+
+	validationErrors := ValidateFeedMetadata(42161, "0x4881A4418b5F2460B21d6F08CD5aA0678a7f262F", ExpectedFeedMetadata{}, metadata)
+	if len(validationErrors) != 0 {
+		t.Fatalf("Expected no validation errors, got %v", validationErrors)
+	}
+}
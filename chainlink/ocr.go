@@ -0,0 +1,204 @@
+package chainlink
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// transmissionLogRange is the largest block range requested per eth_getLogs call, as public RPCs cap it
+const transmissionLogRange = 2000
+
+// ocr1AggregatorABI is the NewTransmission event and transmitter list of OCR1 (AccessControlledOffchainAggregator) aggregators
+const ocr1AggregatorABI = `[
+{"anonymous":false,"inputs":[{"indexed":true,"name":"aggregatorRoundId","type":"uint32"},{"indexed":false,"name":"answer","type":"int192"},{"indexed":false,"name":"transmitter","type":"address"},{"indexed":false,"name":"observations","type":"int192[]"},{"indexed":false,"name":"observers","type":"bytes"},{"indexed":false,"name":"rawReportContext","type":"bytes32"}],"name":"NewTransmission","type":"event"},
+{"inputs":[],"name":"transmitters","outputs":[{"name":"","type":"address[]"}],"stateMutability":"view","type":"function"}
+]`
+
+// ocr2AggregatorABI is the NewTransmission event and transmitter list of OCR2 (OCR2Aggregator) aggregators
+const ocr2AggregatorABI = `[
+{"anonymous":false,"inputs":[{"indexed":true,"name":"aggregatorRoundId","type":"uint32"},{"indexed":false,"name":"answer","type":"int192"},{"indexed":false,"name":"transmitter","type":"address"},{"indexed":false,"name":"observationsTimestamp","type":"uint32"},{"indexed":false,"name":"observations","type":"int192[]"},{"indexed":false,"name":"observers","type":"bytes"},{"indexed":false,"name":"juelsPerFeeCoin","type":"int192"},{"indexed":false,"name":"configDigest","type":"bytes32"},{"indexed":false,"name":"epochAndRound","type":"uint40"}],"name":"NewTransmission","type":"event"},
+{"inputs":[],"name":"getTransmitters","outputs":[{"name":"","type":"address[]"}],"stateMutability":"view","type":"function"}
+]`
+
+var (
+	ocr1AggregatorABIParsed = mustParseABI(ocr1AggregatorABI)
+	ocr2AggregatorABIParsed = mustParseABI(ocr2AggregatorABI)
+
+	// OCR1TransmissionTopic and OCR2TransmissionTopic identify NewTransmission logs of each OCR version
+	OCR1TransmissionTopic = ocr1AggregatorABIParsed.Events["NewTransmission"].ID
+	OCR2TransmissionTopic = ocr2AggregatorABIParsed.Events["NewTransmission"].ID
+)
+
+// mustParseABI parses a JSON ABI, panicking on invalid input
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// Transmission is a decoded NewTransmission event: the report an OCR aggregator accepted for a round
+type Transmission struct {
+	OCRVersion            int // 1 or 2
+	AggregatorRoundID     uint32
+	Answer                *big.Int
+	Transmitter           common.Address
+	Observations          []*big.Int // Sorted ascending; the answer is the median
+	Observers             []uint8    // Oracle index of each observation
+	ObservationsTimestamp uint32     // OCR2 only
+	ConfigDigest          string     // 0x-prefixed; 16 bytes for OCR1, 32 bytes for OCR2
+	Epoch                 uint32
+	Round                 uint8
+	BlockNumber           uint64
+	TxHash                common.Hash
+}
+
+// DecodeTransmission decodes an OCR1 or OCR2 NewTransmission log
+func DecodeTransmission(log gethtypes.Log) (*Transmission, error) {
+	if len(log.Topics) < 2 {
+		return nil, fmt.Errorf("log %s is not a NewTransmission event", log.TxHash.Hex())
+	}
+
+	transmission := &Transmission{
+		AggregatorRoundID: uint32(new(big.Int).SetBytes(log.Topics[1].Bytes()).Uint64()),
+		BlockNumber:       log.BlockNumber,
+		TxHash:            log.TxHash,
+	}
+
+	switch log.Topics[0] {
+	case OCR1TransmissionTopic:
+		var event struct {
+			Answer           *big.Int
+			Transmitter      common.Address
+			Observations     []*big.Int
+			Observers        []byte
+			RawReportContext [32]byte
+		}
+		if err := ocr1AggregatorABIParsed.UnpackIntoInterface(&event, "NewTransmission", log.Data); err != nil {
+			return nil, fmt.Errorf("failed to decode OCR1 transmission: %v", err)
+		}
+		// rawReportContext: 11 bytes padding, 16 bytes config digest, 4 bytes epoch, 1 byte round
+		reportContext := event.RawReportContext
+		transmission.OCRVersion = 1
+		transmission.Answer = event.Answer
+		transmission.Transmitter = event.Transmitter
+		transmission.Observations = event.Observations
+		transmission.Observers = event.Observers
+		transmission.ConfigDigest = "0x" + hex.EncodeToString(reportContext[11:27])
+		transmission.Epoch = binary.BigEndian.Uint32(reportContext[27:31])
+		transmission.Round = reportContext[31]
+
+	case OCR2TransmissionTopic:
+		var event struct {
+			Answer                *big.Int
+			Transmitter           common.Address
+			ObservationsTimestamp uint32
+			Observations          []*big.Int
+			Observers             []byte
+			JuelsPerFeeCoin       *big.Int
+			ConfigDigest          [32]byte
+			EpochAndRound         *big.Int
+		}
+		if err := ocr2AggregatorABIParsed.UnpackIntoInterface(&event, "NewTransmission", log.Data); err != nil {
+			return nil, fmt.Errorf("failed to decode OCR2 transmission: %v", err)
+		}
+		epochAndRound := event.EpochAndRound.Uint64()
+		transmission.OCRVersion = 2
+		transmission.Answer = event.Answer
+		transmission.Transmitter = event.Transmitter
+		transmission.ObservationsTimestamp = event.ObservationsTimestamp
+		transmission.Observations = event.Observations
+		transmission.Observers = event.Observers
+		transmission.ConfigDigest = "0x" + hex.EncodeToString(event.ConfigDigest[:])
+		transmission.Epoch = uint32(epochAndRound >> 8)
+		transmission.Round = uint8(epochAndRound)
+
+	default:
+		return nil, fmt.Errorf("log %s is not a NewTransmission event", log.TxHash.Hex())
+	}
+
+	if len(transmission.Observers) != len(transmission.Observations) {
+		return nil, fmt.Errorf("transmission of round %d has %d observations but %d observers",
+			transmission.AggregatorRoundID, len(transmission.Observations), len(transmission.Observers))
+	}
+	return transmission, nil
+}
+
+// FetchTransmissions reads the NewTransmission events an OCR aggregator emitted between two blocks
+// (inclusive), oldest first. The range is split into chunks public RPCs accept.
+func FetchTransmissions(ctx context.Context, client bind.ContractFilterer, aggregatorAddress string, fromBlock, toBlock uint64) ([]*Transmission, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+	if fromBlock > toBlock {
+		return nil, fmt.Errorf("invalid block range %d-%d", fromBlock, toBlock)
+	}
+
+	var transmissions []*Transmission
+	for start := fromBlock; start <= toBlock; start += transmissionLogRange {
+		end := start + transmissionLogRange - 1
+		if end > toBlock {
+			end = toBlock
+		}
+
+		logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: []common.Address{common.HexToAddress(aggregatorAddress)},
+			Topics:    [][]common.Hash{{OCR1TransmissionTopic, OCR2TransmissionTopic}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read transmissions of %s in blocks %d-%d: %v", aggregatorAddress, start, end, err)
+		}
+
+		for _, log := range logs {
+			if log.Removed {
+				continue
+			}
+			transmission, err := DecodeTransmission(log)
+			if err != nil {
+				return nil, err
+			}
+			transmissions = append(transmissions, transmission)
+		}
+	}
+	return transmissions, nil
+}
+
+// FetchTransmitters returns the transmitter of each oracle of an OCR aggregator, indexed like the
+// observers of its transmissions. OCR2's getTransmitters() is tried before OCR1's transmitters().
+func FetchTransmitters(ctx context.Context, client bind.ContractCaller, aggregatorAddress string) ([]common.Address, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+
+	address := common.HexToAddress(aggregatorAddress)
+	var lastErr error
+	for _, candidate := range []struct {
+		abi    abi.ABI
+		method string
+	}{
+		{ocr2AggregatorABIParsed, "getTransmitters"},
+		{ocr1AggregatorABIParsed, "transmitters"},
+	} {
+		var out []interface{}
+		contract := bind.NewBoundContract(address, candidate.abi, client, nil, nil)
+		if err := contract.Call(&bind.CallOpts{Context: ctx}, &out, candidate.method); err != nil {
+			lastErr = err
+			continue
+		}
+		return out[0].([]common.Address), nil
+	}
+	return nil, fmt.Errorf("failed to read transmitters of %s: %v", aggregatorAddress, lastErr)
+}
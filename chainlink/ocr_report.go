@@ -0,0 +1,159 @@
+package chainlink
+
+import (
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// OracleDeviation is how far one oracle's observation was from the median of its round
+type OracleDeviation struct {
+	Oracle       uint8 // Oracle index, as in Transmission.Observers
+	Observation  *big.Int
+	DeviationBps float64 // Signed deviation from the median in basis points
+}
+
+// RoundAnalytics summarizes the observations of one transmitted round
+type RoundAnalytics struct {
+	AggregatorRoundID uint32
+	BlockNumber       uint64
+	Answer            *big.Int
+	Median            *big.Int
+	Min               *big.Int
+	Max               *big.Int
+	SpreadBps         float64 // (max - min) / |median| in basis points
+	Transmitter       common.Address
+	Deviations        []OracleDeviation
+}
+
+// OracleStats summarizes one oracle over every analyzed round
+type OracleStats struct {
+	Oracle              uint8
+	Transmitter         common.Address // Zero when the aggregator's transmitter list is unknown
+	Observations        int            // Rounds the oracle's observation was included in
+	Participation       float64        // Observations / analyzed rounds
+	Transmissions       int            // Rounds the oracle transmitted, counted only when its transmitter is known
+	MeanAbsDeviationBps float64
+	MaxAbsDeviationBps  float64
+}
+
+// TransmissionReport is the analysis of the transmissions of one aggregator
+type TransmissionReport struct {
+	Aggregator    string
+	FromBlock     uint64
+	ToBlock       uint64
+	Rounds        []RoundAnalytics
+	Oracles       []OracleStats // Sorted by oracle index
+	MeanSpreadBps float64
+	MaxSpreadBps  float64
+}
+
+// AnalyzeTransmission computes the spread of a round's observations and each oracle's deviation
+// from the median. The median is the middle observation, which is how OCR aggregators pick the answer.
+// Deviations is left empty when the observations cannot be matched to Observers one to one.
+func AnalyzeTransmission(t *Transmission) RoundAnalytics {
+	analytics := RoundAnalytics{
+		AggregatorRoundID: t.AggregatorRoundID,
+		BlockNumber:       t.BlockNumber,
+		Answer:            t.Answer,
+		Transmitter:       t.Transmitter,
+	}
+	if len(t.Observations) == 0 {
+		return analytics
+	}
+
+	sorted := make([]*big.Int, len(t.Observations))
+	copy(sorted, t.Observations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+
+	analytics.Median = sorted[len(sorted)/2]
+	analytics.Min = sorted[0]
+	analytics.Max = sorted[len(sorted)-1]
+	analytics.SpreadBps = ratioBps(new(big.Int).Sub(analytics.Max, analytics.Min), analytics.Median)
+
+	if len(t.Observers) != len(t.Observations) {
+		return analytics
+	}
+	analytics.Deviations = make([]OracleDeviation, len(t.Observations))
+	for i, observation := range t.Observations {
+		analytics.Deviations[i] = OracleDeviation{
+			Oracle:       t.Observers[i],
+			Observation:  observation,
+			DeviationBps: ratioBps(new(big.Int).Sub(observation, analytics.Median), analytics.Median),
+		}
+	}
+	return analytics
+}
+
+// AnalyzeTransmissions builds a report over transmissions of one aggregator. transmitters, as
+// returned by FetchTransmitters, attributes transmissions to oracles; it may be nil.
+func AnalyzeTransmissions(aggregator string, fromBlock, toBlock uint64, transmissions []*Transmission, transmitters []common.Address) *TransmissionReport {
+	report := &TransmissionReport{Aggregator: aggregator, FromBlock: fromBlock, ToBlock: toBlock}
+
+	oracleIndex := make(map[common.Address]uint8, len(transmitters))
+	for i, transmitter := range transmitters {
+		oracleIndex[transmitter] = uint8(i)
+	}
+
+	stats := make(map[uint8]*OracleStats)
+	oracle := func(index uint8) *OracleStats {
+		if stats[index] == nil {
+			stats[index] = &OracleStats{Oracle: index}
+			if int(index) < len(transmitters) {
+				stats[index].Transmitter = transmitters[index]
+			}
+		}
+		return stats[index]
+	}
+	// Every known oracle is reported, including ones that never observed
+	for i := range transmitters {
+		oracle(uint8(i))
+	}
+
+	var spreadSum float64
+	for _, transmission := range transmissions {
+		round := AnalyzeTransmission(transmission)
+		report.Rounds = append(report.Rounds, round)
+
+		spreadSum += round.SpreadBps
+		report.MaxSpreadBps = math.Max(report.MaxSpreadBps, round.SpreadBps)
+
+		for _, deviation := range round.Deviations {
+			s := oracle(deviation.Oracle)
+			s.Observations++
+			abs := math.Abs(deviation.DeviationBps)
+			s.MeanAbsDeviationBps += abs // Summed here, averaged below
+			s.MaxAbsDeviationBps = math.Max(s.MaxAbsDeviationBps, abs)
+		}
+		if index, known := oracleIndex[transmission.Transmitter]; known {
+			oracle(index).Transmissions++
+		}
+	}
+
+	if len(report.Rounds) > 0 {
+		report.MeanSpreadBps = spreadSum / float64(len(report.Rounds))
+	}
+	for _, s := range stats {
+		if s.Observations > 0 {
+			s.MeanAbsDeviationBps /= float64(s.Observations)
+		}
+		if len(report.Rounds) > 0 {
+			s.Participation = float64(s.Observations) / float64(len(report.Rounds))
+		}
+		report.Oracles = append(report.Oracles, *s)
+	}
+	sort.Slice(report.Oracles, func(i, j int) bool { return report.Oracles[i].Oracle < report.Oracles[j].Oracle })
+
+	return report
+}
+
+// ratioBps returns value / |base| in basis points, 0 when base is 0
+func ratioBps(value, base *big.Int) float64 {
+	if base.Sign() == 0 {
+		return 0
+	}
+	ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(value), new(big.Float).SetInt(new(big.Int).Abs(base))).Float64()
+	return ratio * 10000
+}
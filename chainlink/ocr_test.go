@@ -0,0 +1,190 @@
+package chainlink
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+func bigInts(values ...int64) []*big.Int {
+	result := make([]*big.Int, len(values))
+	for i, v := range values {
+		result[i] = big.NewInt(v)
+	}
+	return result
+}
+
+func ocr1TransmissionLog(t *testing.T, roundID uint32, answer int64, transmitter common.Address, observations []*big.Int, observers []byte) gethtypes.Log {
+	t.Helper()
+	var reportContext [32]byte
+	copy(reportContext[11:27], common.FromHex("0x000102030405060708090a0b0c0d0e0f"))
+	reportContext[30] = 7 // Epoch
+	reportContext[31] = 2 // Round
+
+	data, err := ocr1AggregatorABIParsed.Events["NewTransmission"].Inputs.NonIndexed().Pack(
+		big.NewInt(answer), transmitter, observations, observers, reportContext)
+	if err != nil {
+		t.Fatalf("Failed to pack OCR1 transmission: %v", err)
+	}
+	return gethtypes.Log{
+		Topics:      []common.Hash{OCR1TransmissionTopic, common.BigToHash(big.NewInt(int64(roundID)))},
+		Data:        data,
+		BlockNumber: 100,
+	}
+}
+
+func ocr2TransmissionLog(t *testing.T, roundID uint32, answer int64, transmitter common.Address, observations []*big.Int, observers []byte) gethtypes.Log {
+	t.Helper()
+	data, err := ocr2AggregatorABIParsed.Events["NewTransmission"].Inputs.NonIndexed().Pack(
+		big.NewInt(answer), transmitter, uint32(1700000000), observations, observers,
+		big.NewInt(1), [32]byte{0xaa}, big.NewInt(9<<8|3))
+	if err != nil {
+		t.Fatalf("Failed to pack OCR2 transmission: %v", err)
+	}
+	return gethtypes.Log{
+		Topics:      []common.Hash{OCR2TransmissionTopic, common.BigToHash(big.NewInt(int64(roundID)))},
+		Data:        data,
+		BlockNumber: 200,
+	}
+}
+
+func TestDecodeTransmission(t *testing.T) {
+	transmitter := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+
+	ocr1, err := DecodeTransmission(ocr1TransmissionLog(t, 42, 101, transmitter, bigInts(99, 101, 104), []byte{2, 0, 1}))
+	if err != nil {
+		t.Fatalf("Failed to decode OCR1 transmission: %v", err)
+	}
+	if ocr1.OCRVersion != 1 || ocr1.AggregatorRoundID != 42 || ocr1.Answer.Int64() != 101 || ocr1.Transmitter != transmitter {
+		t.Errorf("Unexpected OCR1 transmission %+v", ocr1)
+	}
+	if ocr1.ConfigDigest != "0x000102030405060708090a0b0c0d0e0f" || ocr1.Epoch != 7 || ocr1.Round != 2 {
+		t.Errorf("Unexpected OCR1 report context: digest %s epoch %d round %d", ocr1.ConfigDigest, ocr1.Epoch, ocr1.Round)
+	}
+	if len(ocr1.Observations) != 3 || ocr1.Observers[0] != 2 {
+		t.Errorf("Unexpected OCR1 observations %v observers %v", ocr1.Observations, ocr1.Observers)
+	}
+
+	ocr2, err := DecodeTransmission(ocr2TransmissionLog(t, 7, -5, transmitter, bigInts(-6, -5, -5, -4), []byte{3, 1, 0, 2}))
+	if err != nil {
+		t.Fatalf("Failed to decode OCR2 transmission: %v", err)
+	}
+	if ocr2.OCRVersion != 2 || ocr2.AggregatorRoundID != 7 || ocr2.Answer.Int64() != -5 || ocr2.ObservationsTimestamp != 1700000000 {
+		t.Errorf("Unexpected OCR2 transmission %+v", ocr2)
+	}
+	if ocr2.Epoch != 9 || ocr2.Round != 3 || ocr2.ConfigDigest[:4] != "0xaa" {
+		t.Errorf("Unexpected OCR2 report context: digest %s epoch %d round %d", ocr2.ConfigDigest, ocr2.Epoch, ocr2.Round)
+	}
+
+	// Other events are rejected
+	other := ocr2TransmissionLog(t, 7, 1, transmitter, bigInts(1), []byte{0})
+	other.Topics[0] = common.HexToHash("0x01")
+	if _, err := DecodeTransmission(other); err == nil {
+		t.Error("Expected an unrelated event to be rejected")
+	}
+
+	// Observers must match observations
+	if _, err := DecodeTransmission(ocr2TransmissionLog(t, 7, 1, transmitter, bigInts(1, 2), []byte{0})); err == nil {
+		t.Error("Expected a transmission with missing observers to be rejected")
+	}
+}
+
+func TestAnalyzeTransmissions(t *testing.T) {
+	transmitters := []common.Address{
+		common.HexToAddress("0x00000000000000000000000000000000000000a0"),
+		common.HexToAddress("0x00000000000000000000000000000000000000a1"),
+		common.HexToAddress("0x00000000000000000000000000000000000000a2"),
+		common.HexToAddress("0x00000000000000000000000000000000000000a3"),
+	}
+	transmissions := []*Transmission{
+		{AggregatorRoundID: 1, Answer: big.NewInt(10000), Transmitter: transmitters[0],
+			Observations: bigInts(9900, 10000, 10100), Observers: []uint8{0, 1, 2}},
+		{AggregatorRoundID: 2, Answer: big.NewInt(10000), Transmitter: transmitters[1],
+			Observations: bigInts(9950, 10000, 10000), Observers: []uint8{2, 0, 1}},
+	}
+
+	round := AnalyzeTransmission(transmissions[0])
+	if round.Median.Int64() != 10000 || round.Min.Int64() != 9900 || round.Max.Int64() != 10100 {
+		t.Errorf("Unexpected round statistics %+v", round)
+	}
+	if math.Abs(round.SpreadBps-200) > 1e-9 {
+		t.Errorf("Expected a 200 bps spread, got %f", round.SpreadBps)
+	}
+	if math.Abs(round.Deviations[0].DeviationBps+100) > 1e-9 || round.Deviations[0].Oracle != 0 {
+		t.Errorf("Expected oracle 0 to deviate by -100 bps, got %+v", round.Deviations[0])
+	}
+
+	// A transmission whose observers do not match its observations has no per-oracle deviations
+	mismatched := AnalyzeTransmission(&Transmission{AggregatorRoundID: 3, Answer: big.NewInt(10000),
+		Observations: bigInts(9900, 10000, 10100), Observers: []uint8{0}})
+	if mismatched.Median.Int64() != 10000 || math.Abs(mismatched.SpreadBps-200) > 1e-9 || len(mismatched.Deviations) != 0 {
+		t.Errorf("Expected the spread without deviations, got %+v", mismatched)
+	}
+
+	report := AnalyzeTransmissions("0xagg", 1, 2, transmissions, transmitters)
+	if len(report.Rounds) != 2 || len(report.Oracles) != 4 {
+		t.Fatalf("Expected 2 rounds and 4 oracles, got %d and %d", len(report.Rounds), len(report.Oracles))
+	}
+	if math.Abs(report.MeanSpreadBps-125) > 1e-9 || math.Abs(report.MaxSpreadBps-200) > 1e-9 {
+		t.Errorf("Unexpected spreads: mean %f max %f", report.MeanSpreadBps, report.MaxSpreadBps)
+	}
+
+	oracle2 := report.Oracles[2]
+	if oracle2.Observations != 2 || oracle2.Participation != 1 || math.Abs(oracle2.MaxAbsDeviationBps-100) > 1e-9 ||
+		math.Abs(oracle2.MeanAbsDeviationBps-75) > 1e-9 {
+		t.Errorf("Unexpected stats for oracle 2: %+v", oracle2)
+	}
+	if report.Oracles[0].Transmissions != 1 || report.Oracles[1].Transmissions != 1 || report.Oracles[2].Transmissions != 0 {
+		t.Errorf("Unexpected transmission counts %+v", report.Oracles)
+	}
+	if absent := report.Oracles[3]; absent.Observations != 0 || absent.Participation != 0 || absent.Transmitter != transmitters[3] {
+		t.Errorf("Expected oracle 3 to be reported with no participation, got %+v", absent)
+	}
+}
+
+// rangeFilterer records the block ranges of FilterLogs calls and returns one log per call
+type rangeFilterer struct {
+	ranges [][2]uint64
+	log    gethtypes.Log
+}
+
+func (f *rangeFilterer) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]gethtypes.Log, error) {
+	f.ranges = append(f.ranges, [2]uint64{query.FromBlock.Uint64(), query.ToBlock.Uint64()})
+	return []gethtypes.Log{f.log}, nil
+}
+
+func (f *rangeFilterer) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- gethtypes.Log) (ethereum.Subscription, error) {
+	return event.NewSubscription(func(<-chan struct{}) error { return nil }), nil
+}
+
+func TestFetchTransmissionsChunksRange(t *testing.T) {
+	filterer := &rangeFilterer{log: ocr2TransmissionLog(t, 1, 1, common.Address{}, bigInts(1), []byte{0})}
+
+	transmissions, err := FetchTransmissions(context.Background(), filterer, "0x01", 1000, 1000+2*transmissionLogRange)
+	if err != nil {
+		t.Fatalf("FetchTransmissions failed: %v", err)
+	}
+	want := [][2]uint64{{1000, 2999}, {3000, 4999}, {5000, 5000}}
+	if len(filterer.ranges) != len(want) {
+		t.Fatalf("Expected ranges %v, got %v", want, filterer.ranges)
+	}
+	for i := range want {
+		if filterer.ranges[i] != want[i] {
+			t.Errorf("Expected ranges %v, got %v", want, filterer.ranges)
+			break
+		}
+	}
+	if len(transmissions) != len(want) {
+		t.Errorf("Expected %d transmissions, got %d", len(want), len(transmissions))
+	}
+
+	if _, err := FetchTransmissions(context.Background(), filterer, "0x01", 10, 9); err == nil {
+		t.Error("Expected an inverted range to be rejected")
+	}
+}
@@ -0,0 +1,99 @@
+package chainlink
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	aggregatorproxy "github.com/morpheum-labs/pricefeeding/aggregatorproxy"
+)
+
+// phaseOffset is the bit position of the phase ID in a proxy round ID: roundId = phaseId << 64 | aggregatorRoundId
+const phaseOffset = 64
+
+// PhaseInfo is the aggregator a feed proxy points to during one phase
+type PhaseInfo struct {
+	NetworkID   uint64
+	FeedAddress string    // Proxy address
+	PhaseID     uint16    // Incremented by the proxy every time its aggregator is replaced
+	Aggregator  string    // Aggregator address of the phase
+	ObservedAt  time.Time // When the phase was first seen by the monitor
+}
+
+// PhaseIDFromRoundID returns the phase a proxy round ID belongs to
+func PhaseIDFromRoundID(roundID *big.Int) uint16 {
+	if roundID == nil {
+		return 0
+	}
+	return uint16(new(big.Int).Rsh(roundID, phaseOffset).Uint64())
+}
+
+// AggregatorRoundID returns the round ID of a proxy round on its phase's aggregator
+func AggregatorRoundID(roundID *big.Int) uint64 {
+	if roundID == nil {
+		return 0
+	}
+	return new(big.Int).And(roundID, new(big.Int).SetUint64(^uint64(0))).Uint64()
+}
+
+// ProxyRoundID builds the proxy round ID of an aggregator round in a phase
+func ProxyRoundID(phaseID uint16, aggregatorRoundID uint64) *big.Int {
+	roundID := new(big.Int).Lsh(big.NewInt(int64(phaseID)), phaseOffset)
+	return roundID.Or(roundID, new(big.Int).SetUint64(aggregatorRoundID))
+}
+
+// FetchProxyPhase reads the current aggregator and phase of a feed proxy
+func FetchProxyPhase(ctx context.Context, client bind.ContractCaller, networkID uint64, proxyAddress string) (*PhaseInfo, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+
+	proxy, err := aggregatorproxy.NewAggregatorProxyInterfaceCaller(common.HexToAddress(proxyAddress), client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aggregator proxy contract: %v", err)
+	}
+
+	callOpts := &bind.CallOpts{Context: ctx}
+	phaseID, err := proxy.PhaseId(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read phase of proxy %s: %w", proxyAddress, err)
+	}
+	aggregator, err := proxy.Aggregator(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read aggregator of proxy %s: %w", proxyAddress, err)
+	}
+
+	return &PhaseInfo{
+		NetworkID:   networkID,
+		FeedAddress: proxyAddress,
+		PhaseID:     phaseID,
+		Aggregator:  aggregator.Hex(),
+		ObservedAt:  time.Now(),
+	}, nil
+}
+
+// FetchPhaseAggregator reads the aggregator a feed proxy used in a past phase
+func FetchPhaseAggregator(ctx context.Context, client bind.ContractCaller, proxyAddress string, phaseID uint16) (string, error) {
+	if client == nil {
+		return "", fmt.Errorf("client cannot be nil")
+	}
+
+	proxy, err := aggregatorproxy.NewAggregatorProxyInterfaceCaller(common.HexToAddress(proxyAddress), client)
+	if err != nil {
+		return "", fmt.Errorf("failed to create aggregator proxy contract: %v", err)
+	}
+
+	aggregator, err := proxy.PhaseAggregators(&bind.CallOpts{Context: ctx}, phaseID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read aggregator of phase %d of proxy %s: %w", phaseID, proxyAddress, err)
+	}
+	if aggregator == (common.Address{}) {
+		return "", fmt.Errorf("proxy %s has no aggregator for phase %d", proxyAddress, phaseID)
+	}
+
+	return aggregator.Hex(), nil
+}
@@ -0,0 +1,28 @@
+package chainlink
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestProxyRoundIDPhases(t *testing.T) {
+	// ETH/USD on Ethereum, phase 6 round 5495
+	roundID, _ := new(big.Int).SetString("110680464442257315191", 10)
+
+	if phaseID := PhaseIDFromRoundID(roundID); phaseID != 6 {
+		t.Errorf("Expected phase 6, got %d", phaseID)
+	}
+	if aggregatorRoundID := AggregatorRoundID(roundID); aggregatorRoundID != 5495 {
+		t.Errorf("Expected aggregator round 5495, got %d", aggregatorRoundID)
+	}
+	if rebuilt := ProxyRoundID(6, 5495); rebuilt.Cmp(roundID) != 0 {
+		t.Errorf("Expected proxy round ID %s, got %s", roundID, rebuilt)
+	}
+
+	if phaseID := PhaseIDFromRoundID(big.NewInt(42)); phaseID != 0 {
+		t.Errorf("Expected aggregator round IDs to be in phase 0, got %d", phaseID)
+	}
+	if phaseID := PhaseIDFromRoundID(nil); phaseID != 0 {
+		t.Errorf("Expected nil round ID to be in phase 0, got %d", phaseID)
+	}
+}
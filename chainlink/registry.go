@@ -0,0 +1,144 @@
+package chainlink
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	aggregatorproxy "github.com/morpheum-labs/pricefeeding/aggregatorproxy"
+	feedregistry "github.com/morpheum-labs/pricefeeding/feedregistry"
+)
+
+// FeedRegistryAddresses lists the Chainlink Feed Registry contracts by network ID
+var FeedRegistryAddresses = map[uint64]string{
+	1: "0x47Fb2585D2C56Fe188D0E6ec628a38b74fCeeeDf", // Ethereum Mainnet
+}
+
+// Denomination addresses used by the Feed Registry for assets that have no token address
+// (chainlink/contracts Denominations.sol)
+var Denominations = map[string]string{
+	"ETH": "0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE",
+	"BTC": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB",
+	"USD": "0x0000000000000000000000000000000000000348",
+	"GBP": "0x000000000000000000000000000000000000033a",
+	"EUR": "0x00000000000000000000000000000000000003d2",
+	"JPY": "0x0000000000000000000000000000000000000188",
+}
+
+// ParseRegistryAsset converts a Feed Registry asset, given as a denomination name (e.g. "USD")
+// or a token address, to its address
+func ParseRegistryAsset(asset string) (common.Address, error) {
+	if address, exists := Denominations[strings.ToUpper(strings.TrimSpace(asset))]; exists {
+		return common.HexToAddress(address), nil
+	}
+	if !common.IsHexAddress(asset) {
+		return common.Address{}, fmt.Errorf("invalid registry asset %q: not a denomination or address", asset)
+	}
+	return common.HexToAddress(asset), nil
+}
+
+// ResolveRegistryFeed returns the aggregator the Feed Registry currently uses for a (base, quote) pair
+func ResolveRegistryFeed(ctx context.Context, client bind.ContractCaller, registryAddress string, base, quote common.Address) (common.Address, error) {
+	if client == nil {
+		return common.Address{}, fmt.Errorf("client cannot be nil")
+	}
+
+	registry, err := feedregistry.NewFeedRegistryInterfaceCaller(common.HexToAddress(registryAddress), client)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to create feed registry contract: %v", err)
+	}
+
+	aggregator, err := registry.GetFeed(&bind.CallOpts{Context: ctx}, base, quote)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to resolve feed %s/%s from registry: %v", base.Hex(), quote.Hex(), err)
+	}
+	if aggregator == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("feed registry has no feed for %s/%s", base.Hex(), quote.Hex())
+	}
+
+	return aggregator, nil
+}
+
+// FetchProxyAggregator returns the aggregator a feed proxy currently points to
+func FetchProxyAggregator(ctx context.Context, client bind.ContractCaller, proxyAddress string) (common.Address, error) {
+	if client == nil {
+		return common.Address{}, fmt.Errorf("client cannot be nil")
+	}
+
+	proxy, err := aggregatorproxy.NewAggregatorProxyInterfaceCaller(common.HexToAddress(proxyAddress), client)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to create aggregator proxy contract: %v", err)
+	}
+
+	aggregator, err := proxy.Aggregator(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to read aggregator of proxy %s: %v", proxyAddress, err)
+	}
+
+	return aggregator, nil
+}
+
+// RegistryFeedRef is a configured feed to compare against the Feed Registry
+type RegistryFeedRef struct {
+	Name  string
+	Proxy string // Proxy address from the configuration
+	Base  string // Registry base asset (denomination name or address)
+	Quote string // Registry quote asset (denomination name or address)
+}
+
+// RegistryDiff compares the aggregator behind a configured proxy with the one the registry resolves
+type RegistryDiff struct {
+	Name               string
+	Proxy              string
+	ProxyAggregator    string // Aggregator the configured proxy points to
+	RegistryAggregator string // Aggregator the registry returns for (base, quote)
+	Match              bool
+	Err                error // Set when either side could not be read
+}
+
+// DiffRegistryFeeds resolves each configured feed through the Feed Registry and reports whether
+// its configured proxy points to the same aggregator
+func DiffRegistryFeeds(ctx context.Context, client bind.ContractCaller, registryAddress string, feeds []RegistryFeedRef) []RegistryDiff {
+	diffs := make([]RegistryDiff, 0, len(feeds))
+	for _, feed := range feeds {
+		diff := RegistryDiff{Name: feed.Name, Proxy: feed.Proxy}
+
+		base, err := ParseRegistryAsset(feed.Base)
+		if err != nil {
+			diff.Err = err
+			diffs = append(diffs, diff)
+			continue
+		}
+		quote, err := ParseRegistryAsset(feed.Quote)
+		if err != nil {
+			diff.Err = err
+			diffs = append(diffs, diff)
+			continue
+		}
+
+		registryAggregator, err := ResolveRegistryFeed(ctx, client, registryAddress, base, quote)
+		if err != nil {
+			diff.Err = err
+			diffs = append(diffs, diff)
+			continue
+		}
+		diff.RegistryAggregator = registryAggregator.Hex()
+
+		if feed.Proxy != "" {
+			proxyAggregator, err := FetchProxyAggregator(ctx, client, feed.Proxy)
+			if err != nil {
+				diff.Err = err
+				diffs = append(diffs, diff)
+				continue
+			}
+			diff.ProxyAggregator = proxyAggregator.Hex()
+			diff.Match = proxyAggregator == registryAggregator
+		}
+
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
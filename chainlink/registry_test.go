@@ -0,0 +1,51 @@
+package chainlink
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseRegistryAsset(t *testing.T) {
+	tests := []struct {
+		asset   string
+		want    common.Address
+		wantErr bool
+	}{
+		{asset: "USD", want: common.HexToAddress("0x0000000000000000000000000000000000000348")},
+		{asset: "eth", want: common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")},
+		{asset: " BTC ", want: common.HexToAddress("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB")},
+		{asset: "0x514910771AF9Ca656af840dff83E8264EcF986CA", want: common.HexToAddress("0x514910771AF9Ca656af840dff83E8264EcF986CA")},
+		{asset: "DOGE", wantErr: true},
+		{asset: "0x1234", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRegistryAsset(tt.asset)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRegistryAsset(%q) error = %v, wantErr %v", tt.asset, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRegistryAsset(%q) = %s, want %s", tt.asset, got.Hex(), tt.want.Hex())
+		}
+	}
+}
+
+func TestDiffRegistryFeedsReportsErrors(t *testing.T) {
+	feeds := []RegistryFeedRef{
+		{Name: "bad", Proxy: "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419", Base: "DOGE", Quote: "USD"},
+		{Name: "noclient", Proxy: "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419", Base: "ETH", Quote: "USD"},
+	}
+
+	diffs := DiffRegistryFeeds(context.Background(), nil, FeedRegistryAddresses[1], feeds)
+	if len(diffs) != len(feeds) {
+		t.Fatalf("Expected %d diffs, got %d", len(feeds), len(diffs))
+	}
+	for _, diff := range diffs {
+		if diff.Err == nil || diff.Match {
+			t.Errorf("Expected %s to report an error and no match, got %+v", diff.Name, diff)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package chainlink
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// DefaultMaxClockSkew is how far in the future a round's updatedAt may be before it is rejected,
+// to tolerate small clock differences between this host and the chain
+const DefaultMaxClockSkew = 30 * time.Second
+
+// RoundValidationKind identifies the type of a round sanity check failure
+type RoundValidationKind string
+
+const (
+	// RoundIncomplete means updatedAt is 0, i.e. the round has not been answered yet
+	RoundIncomplete RoundValidationKind = "incomplete_round"
+	// RoundStaleCarryOver means answeredInRound < roundId, i.e. the answer was carried over from an earlier round
+	RoundStaleCarryOver RoundValidationKind = "stale_carry_over"
+	// RoundNonPositiveAnswer means the answer is zero or negative
+	RoundNonPositiveAnswer RoundValidationKind = "non_positive_answer"
+	// RoundFutureTimestamp means updatedAt is later than now plus the allowed clock skew
+	RoundFutureTimestamp RoundValidationKind = "future_timestamp"
+)
+
+// RoundValidationError describes a round that failed a sanity check
+type RoundValidationError struct {
+	NetworkID   uint64
+	FeedAddress string
+	RoundID     *big.Int
+	Kind        RoundValidationKind
+	Detail      string
+}
+
+// Error implements the error interface
+func (e *RoundValidationError) Error() string {
+	return fmt.Sprintf("round %v of feed %s on network %d failed validation (%s): %s", e.RoundID, e.FeedAddress, e.NetworkID, e.Kind, e.Detail)
+}
+
+// ValidateRound runs the standard Chainlink round sanity checks on a price read from latestRoundData().
+// A maxClockSkew of 0 uses DefaultMaxClockSkew. It returns nil when the round passes every check.
+func ValidateRound(price *types.ChainlinkPrice, now time.Time, maxClockSkew time.Duration) []*RoundValidationError {
+	if maxClockSkew == 0 {
+		maxClockSkew = DefaultMaxClockSkew
+	}
+
+	var validationErrors []*RoundValidationError
+	fail := func(kind RoundValidationKind, format string, args ...interface{}) {
+		validationErrors = append(validationErrors, &RoundValidationError{
+			NetworkID:   price.NetworkID,
+			FeedAddress: price.FeedAddress,
+			RoundID:     price.RoundID,
+			Kind:        kind,
+			Detail:      fmt.Sprintf(format, args...),
+		})
+	}
+
+	if price.UpdatedAt == nil || price.UpdatedAt.Sign() == 0 {
+		fail(RoundIncomplete, "updatedAt is 0")
+	} else if updatedAt := time.Unix(price.UpdatedAt.Int64(), 0); updatedAt.After(now.Add(maxClockSkew)) {
+		fail(RoundFutureTimestamp, "updatedAt %s is after %s", updatedAt.Format(time.RFC3339), now.Format(time.RFC3339))
+	}
+
+	if price.RoundID != nil && price.AnsweredInRound != nil && price.AnsweredInRound.Cmp(price.RoundID) < 0 {
+		fail(RoundStaleCarryOver, "answeredInRound %s < roundId %s", price.AnsweredInRound.String(), price.RoundID.String())
+	}
+
+	if price.Answer == nil || price.Answer.Sign() <= 0 {
+		fail(RoundNonPositiveAnswer, "answer %v is not positive", price.Answer)
+	}
+
+	return validationErrors
+}
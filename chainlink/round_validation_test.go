@@ -0,0 +1,60 @@
+package chainlink
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+func TestValidateRound(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	validRound := func() *types.ChainlinkPrice {
+		return &types.ChainlinkPrice{
+			RoundID:         big.NewInt(100),
+			Answer:          big.NewInt(6500000000000),
+			StartedAt:       big.NewInt(now.Unix() - 60),
+			UpdatedAt:       big.NewInt(now.Unix() - 60),
+			AnsweredInRound: big.NewInt(100),
+			NetworkID:       42161,
+			FeedAddress:     "0x6ce185860a4963106506C203335A2910413708e9",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *types.ChainlinkPrice)
+		want   []RoundValidationKind
+	}{
+		{"valid", func(p *types.ChainlinkPrice) {}, nil},
+		{"incomplete", func(p *types.ChainlinkPrice) { p.UpdatedAt = big.NewInt(0) }, []RoundValidationKind{RoundIncomplete}},
+		{"stale carry-over", func(p *types.ChainlinkPrice) { p.AnsweredInRound = big.NewInt(99) }, []RoundValidationKind{RoundStaleCarryOver}},
+		{"zero answer", func(p *types.ChainlinkPrice) { p.Answer = big.NewInt(0) }, []RoundValidationKind{RoundNonPositiveAnswer}},
+		{"negative answer", func(p *types.ChainlinkPrice) { p.Answer = big.NewInt(-1) }, []RoundValidationKind{RoundNonPositiveAnswer}},
+		{"future timestamp", func(p *types.ChainlinkPrice) { p.UpdatedAt = big.NewInt(now.Unix() + 3600) }, []RoundValidationKind{RoundFutureTimestamp}},
+		{"within clock skew", func(p *types.ChainlinkPrice) { p.UpdatedAt = big.NewInt(now.Unix() + 10) }, nil},
+		{"multiple failures", func(p *types.ChainlinkPrice) {
+			p.UpdatedAt = big.NewInt(0)
+			p.Answer = big.NewInt(0)
+		}, []RoundValidationKind{RoundIncomplete, RoundNonPositiveAnswer}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price := validRound()
+			tt.modify(price)
+
+			validationErrors := ValidateRound(price, now, 0)
+			if len(validationErrors) != len(tt.want) {
+				t.Fatalf("Expected %d validation errors, got %v", len(tt.want), validationErrors)
+			}
+			for i, kind := range tt.want {
+				if validationErrors[i].Kind != kind {
+					t.Errorf("Expected kind %s, got %s", kind, validationErrors[i].Kind)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package chainlink
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	aggregatorv3 "github.com/morpheum-labs/pricefeeding/aggregatorv3"
+)
+
+// DefaultSequencerGracePeriod is how long prices stay untrusted after an L2 sequencer comes back up
+const DefaultSequencerGracePeriod = time.Hour
+
+// DefaultSequencerUptimeFeeds lists the Chainlink L2 Sequencer Uptime Feed proxies by network ID
+var DefaultSequencerUptimeFeeds = map[uint64]string{
+	42161: "0xFdB631F5EE196F0ed6FAa767959853A9F217697D", // Arbitrum One
+	10:    "0x371EAD81c9102C9BF4874A9075FFFf170F2Ee389", // Optimism
+	8453:  "0xBCF85224fc0756B9Fa45aA7892530B47e10b6433", // Base
+}
+
+// SequencerStatus is the state of an L2 sequencer as reported by its uptime feed
+type SequencerStatus struct {
+	NetworkID   uint64
+	FeedAddress string
+	Up          bool          // Answer 0 means the sequencer is up, 1 means it is down
+	StatusSince time.Time     // startedAt of the latest round: when the current status began
+	CheckedAt   time.Time     // When the uptime feed was read
+	GracePeriod time.Duration // How long prices stay untrusted after the sequencer comes back up
+}
+
+// InGracePeriod reports whether the sequencer came back up less than GracePeriod before now
+func (s *SequencerStatus) InGracePeriod(now time.Time) bool {
+	return s.Up && now.Sub(s.StatusSince) < s.GracePeriod
+}
+
+// Trusted reports whether prices on the network can be relied on at the given time
+func (s *SequencerStatus) Trusted(now time.Time) bool {
+	return s.Up && !s.InGracePeriod(now)
+}
+
+// UntrustedReason describes why prices on the network are untrusted, or "" when they are trusted
+func (s *SequencerStatus) UntrustedReason(now time.Time) string {
+	if !s.Up {
+		return fmt.Sprintf("L2 sequencer down since %s", s.StatusSince.Format(time.RFC3339))
+	}
+	if s.InGracePeriod(now) {
+		return fmt.Sprintf("L2 sequencer grace period until %s", s.StatusSince.Add(s.GracePeriod).Format(time.RFC3339))
+	}
+	return ""
+}
+
+// FetchSequencerStatus reads an L2 Sequencer Uptime Feed
+func FetchSequencerStatus(ctx context.Context, client bind.ContractCaller, networkID uint64, feedAddress string, gracePeriod time.Duration) (*SequencerStatus, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+
+	feed, err := aggregatorv3.NewAggregatorV3InterfaceCaller(common.HexToAddress(feedAddress), client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sequencer uptime feed contract: %v", err)
+	}
+
+	roundData, err := feed.LatestRoundData(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sequencer uptime feed: %v", err)
+	}
+
+	// startedAt is 0 when the round is invalid (e.g. before the feed is initialized on Arbitrum)
+	if roundData.StartedAt == nil || roundData.StartedAt.Sign() == 0 {
+		return nil, fmt.Errorf("sequencer uptime feed %s on network %d returned an invalid round", feedAddress, networkID)
+	}
+
+	return &SequencerStatus{
+		NetworkID:   networkID,
+		FeedAddress: feedAddress,
+		Up:          roundData.Answer.Sign() == 0,
+		StatusSince: time.Unix(roundData.StartedAt.Int64(), 0),
+		CheckedAt:   time.Now(),
+		GracePeriod: gracePeriod,
+	}, nil
+}
@@ -0,0 +1,31 @@
+package chainlink
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSequencerStatusTrust(t *testing.T) {
+	now := time.Now()
+
+	down := &SequencerStatus{Up: false, StatusSince: now.Add(-5 * time.Minute), GracePeriod: time.Hour}
+	if down.Trusted(now) {
+		t.Error("Expected prices to be untrusted while the sequencer is down")
+	}
+
+	recovering := &SequencerStatus{Up: true, StatusSince: now.Add(-10 * time.Minute), GracePeriod: time.Hour}
+	if !recovering.InGracePeriod(now) || recovering.Trusted(now) {
+		t.Error("Expected prices to be untrusted during the grace period")
+	}
+	if recovering.UntrustedReason(now) == "" {
+		t.Error("Expected an untrusted reason during the grace period")
+	}
+
+	healthy := &SequencerStatus{Up: true, StatusSince: now.Add(-2 * time.Hour), GracePeriod: time.Hour}
+	if !healthy.Trusted(now) {
+		t.Error("Expected prices to be trusted after the grace period")
+	}
+	if reason := healthy.UntrustedReason(now); reason != "" {
+		t.Errorf("Expected no untrusted reason, got %q", reason)
+	}
+}
@@ -0,0 +1,53 @@
+package chainlink
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// HeaderReader reads block headers. It is satisfied by *ethclient.Client and every bind.ContractBackend.
+type HeaderReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*gethtypes.Header, error)
+}
+
+// BlockRef identifies the block a set of feeds was read at
+type BlockRef struct {
+	Number *big.Int
+	Hash   string
+	Time   time.Time // Block timestamp
+}
+
+// ReorgError is returned when a pinned block was replaced by a reorg while feeds were read at it
+type ReorgError struct {
+	Number   *big.Int
+	Expected string // Hash the block was pinned at
+	Actual   string // Hash now at the same height
+}
+
+// Error implements the error interface
+func (e *ReorgError) Error() string {
+	return fmt.Sprintf("block %s was reorged: pinned hash %s, now %s", e.Number.String(), e.Expected, e.Actual)
+}
+
+// FetchBlockRef reads the header of a block so feeds can be read at it. A nil blockNumber
+// resolves the latest block, giving one consistent block for a snapshot of several feeds.
+func FetchBlockRef(ctx context.Context, client HeaderReader, blockNumber *big.Int) (*BlockRef, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+
+	header, err := client.HeaderByNumber(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block header: %v", err)
+	}
+
+	return &BlockRef{
+		Number: header.Number,
+		Hash:   header.Hash().Hex(),
+		Time:   time.Unix(int64(header.Time), 0),
+	}, nil
+}
@@ -0,0 +1,19 @@
+package chainlink
+
+import (
+	"context"
+	"math/big"
+	"testing"
+)
+
+func TestCallOptsPinnedToBlock(t *testing.T) {
+	if opts := callOpts(context.Background(), FetchPriceDataOptions{}); opts.BlockNumber != nil {
+		t.Errorf("Expected latest block without a BlockRef, got %s", opts.BlockNumber.String())
+	}
+
+	block := &BlockRef{Number: big.NewInt(250000000), Hash: "0xabc"}
+	opts := callOpts(context.Background(), FetchPriceDataOptions{Block: block})
+	if opts.BlockNumber == nil || opts.BlockNumber.Cmp(block.Number) != 0 {
+		t.Errorf("Expected call pinned to block %s, got %v", block.Number.String(), opts.BlockNumber)
+	}
+}
@@ -0,0 +1,276 @@
+// Package streams decodes and verifies Chainlink Data Streams reports
+package streams
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// Report schema versions, encoded in the first two bytes of the feed ID
+const (
+	SchemaV3 uint16 = 3 // Crypto: benchmark price, bid and ask
+	SchemaV4 uint16 = 4 // Real world assets: price and market status
+)
+
+// DefaultExponent is the exponent of Data Streams prices, which carry 18 decimals
+const DefaultExponent = -18
+
+// Market status values of v4 reports
+const (
+	MarketStatusUnknown uint32 = 0
+	MarketStatusClosed  uint32 = 1
+	MarketStatusOpen    uint32 = 2
+)
+
+// FullReport is the signed payload returned by the Data Streams API and passed to the verifier contract
+type FullReport struct {
+	ReportContext [3][32]byte // Config digest, epoch and round, extra hash
+	ReportBlob    []byte      // ABI-encoded report, decoded by DecodeReport
+	RawRs         [][32]byte  // r values of the signatures
+	RawSs         [][32]byte  // s values of the signatures
+	RawVs         [32]byte    // Recovery IDs (0/1) of the signatures, one byte each
+}
+
+// ConfigDigest returns the digest of the DON configuration that signed the report
+func (r *FullReport) ConfigDigest() [32]byte {
+	return r.ReportContext[0]
+}
+
+// ReportV3 is a v3 (crypto) report
+type ReportV3 struct {
+	FeedID                [32]byte
+	ValidFromTimestamp    uint32
+	ObservationsTimestamp uint32
+	NativeFee             *big.Int
+	LinkFee               *big.Int
+	ExpiresAt             uint32
+	BenchmarkPrice        *big.Int
+	Bid                   *big.Int
+	Ask                   *big.Int
+}
+
+// ReportV4 is a v4 (real world asset) report
+type ReportV4 struct {
+	FeedID                [32]byte
+	ValidFromTimestamp    uint32
+	ObservationsTimestamp uint32
+	NativeFee             *big.Int
+	LinkFee               *big.Int
+	ExpiresAt             uint32
+	Price                 *big.Int
+	MarketStatus          uint32
+}
+
+// Report is a decoded report of any supported schema
+type Report interface {
+	Schema() uint16
+	// ToPrice converts the report to a PriceInfo the price cache can store
+	ToPrice(networkID uint64, exponent int) *types.StreamsPrice
+}
+
+// Schema returns SchemaV3
+func (r *ReportV3) Schema() uint16 { return SchemaV3 }
+
+// Schema returns SchemaV4
+func (r *ReportV4) Schema() uint16 { return SchemaV4 }
+
+// ToPrice converts the report to a StreamsPrice carrying the benchmark price, bid and ask
+func (r *ReportV3) ToPrice(networkID uint64, exponent int) *types.StreamsPrice {
+	return &types.StreamsPrice{
+		FeedID:                FeedIDHex(r.FeedID),
+		Schema:                SchemaV3,
+		Price:                 r.BenchmarkPrice,
+		Bid:                   r.Bid,
+		Ask:                   r.Ask,
+		Exponent:              exponent,
+		ValidFrom:             time.Unix(int64(r.ValidFromTimestamp), 0),
+		ObservationsTimestamp: time.Unix(int64(r.ObservationsTimestamp), 0),
+		ExpiresAt:             time.Unix(int64(r.ExpiresAt), 0),
+		Timestamp:             time.Now(),
+		NetworkID:             networkID,
+	}
+}
+
+// ToPrice converts the report to a StreamsPrice carrying the price and market status
+func (r *ReportV4) ToPrice(networkID uint64, exponent int) *types.StreamsPrice {
+	return &types.StreamsPrice{
+		FeedID:                FeedIDHex(r.FeedID),
+		Schema:                SchemaV4,
+		Price:                 r.Price,
+		MarketStatus:          r.MarketStatus,
+		Exponent:              exponent,
+		ValidFrom:             time.Unix(int64(r.ValidFromTimestamp), 0),
+		ObservationsTimestamp: time.Unix(int64(r.ObservationsTimestamp), 0),
+		ExpiresAt:             time.Unix(int64(r.ExpiresAt), 0),
+		Timestamp:             time.Now(),
+		NetworkID:             networkID,
+	}
+}
+
+var (
+	bytes32Type      = mustType("bytes32")
+	bytes32x3Type    = mustType("bytes32[3]")
+	bytes32SliceType = mustType("bytes32[]")
+	bytesType        = mustType("bytes")
+	uint32Type       = mustType("uint32")
+	uint192Type      = mustType("uint192")
+	int192Type       = mustType("int192")
+
+	fullReportArgs = abi.Arguments{
+		{Name: "reportContext", Type: bytes32x3Type},
+		{Name: "reportBlob", Type: bytesType},
+		{Name: "rawRs", Type: bytes32SliceType},
+		{Name: "rawSs", Type: bytes32SliceType},
+		{Name: "rawVs", Type: bytes32Type},
+	}
+
+	reportV3Args = abi.Arguments{
+		{Name: "feedId", Type: bytes32Type},
+		{Name: "validFromTimestamp", Type: uint32Type},
+		{Name: "observationsTimestamp", Type: uint32Type},
+		{Name: "nativeFee", Type: uint192Type},
+		{Name: "linkFee", Type: uint192Type},
+		{Name: "expiresAt", Type: uint32Type},
+		{Name: "benchmarkPrice", Type: int192Type},
+		{Name: "bid", Type: int192Type},
+		{Name: "ask", Type: int192Type},
+	}
+
+	reportV4Args = abi.Arguments{
+		{Name: "feedId", Type: bytes32Type},
+		{Name: "validFromTimestamp", Type: uint32Type},
+		{Name: "observationsTimestamp", Type: uint32Type},
+		{Name: "nativeFee", Type: uint192Type},
+		{Name: "linkFee", Type: uint192Type},
+		{Name: "expiresAt", Type: uint32Type},
+		{Name: "price", Type: int192Type},
+		{Name: "marketStatus", Type: uint32Type},
+	}
+)
+
+// mustType builds an ABI type, panicking on an invalid type string
+func mustType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
+
+// DecodeFullReport decodes a full report payload
+func DecodeFullReport(payload []byte) (*FullReport, error) {
+	values, err := fullReportArgs.Unpack(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode full report: %v", err)
+	}
+
+	return &FullReport{
+		ReportContext: values[0].([3][32]byte),
+		ReportBlob:    values[1].([]byte),
+		RawRs:         values[2].([][32]byte),
+		RawSs:         values[3].([][32]byte),
+		RawVs:         values[4].([32]byte),
+	}, nil
+}
+
+// DecodeFullReportHex decodes a 0x-prefixed hex full report, as returned by the Data Streams API
+func DecodeFullReportHex(payload string) (*FullReport, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(payload, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid full report hex: %v", err)
+	}
+	return DecodeFullReport(raw)
+}
+
+// Encode ABI-encodes the full report
+func (r *FullReport) Encode() ([]byte, error) {
+	return fullReportArgs.Pack(r.ReportContext, r.ReportBlob, r.RawRs, r.RawSs, r.RawVs)
+}
+
+// SchemaVersion returns the report schema encoded in a feed ID
+func SchemaVersion(feedID [32]byte) uint16 {
+	return binary.BigEndian.Uint16(feedID[:2])
+}
+
+// FeedIDHex formats a feed ID as 0x-prefixed hex
+func FeedIDHex(feedID [32]byte) string {
+	return "0x" + hex.EncodeToString(feedID[:])
+}
+
+// DecodeReport decodes a report blob according to the schema encoded in its feed ID
+func DecodeReport(reportBlob []byte) (Report, error) {
+	if len(reportBlob) < 32 {
+		return nil, fmt.Errorf("report blob too short: %d bytes", len(reportBlob))
+	}
+
+	var feedID [32]byte
+	copy(feedID[:], reportBlob[:32])
+
+	switch version := SchemaVersion(feedID); version {
+	case SchemaV3:
+		return DecodeReportV3(reportBlob)
+	case SchemaV4:
+		return DecodeReportV4(reportBlob)
+	default:
+		return nil, fmt.Errorf("unsupported report schema v%d for feed %s", version, FeedIDHex(feedID))
+	}
+}
+
+// DecodeReportV3 decodes a v3 (crypto) report blob
+func DecodeReportV3(reportBlob []byte) (*ReportV3, error) {
+	values, err := reportV3Args.Unpack(reportBlob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode v3 report: %v", err)
+	}
+
+	return &ReportV3{
+		FeedID:                values[0].([32]byte),
+		ValidFromTimestamp:    values[1].(uint32),
+		ObservationsTimestamp: values[2].(uint32),
+		NativeFee:             values[3].(*big.Int),
+		LinkFee:               values[4].(*big.Int),
+		ExpiresAt:             values[5].(uint32),
+		BenchmarkPrice:        values[6].(*big.Int),
+		Bid:                   values[7].(*big.Int),
+		Ask:                   values[8].(*big.Int),
+	}, nil
+}
+
+// DecodeReportV4 decodes a v4 (real world asset) report blob
+func DecodeReportV4(reportBlob []byte) (*ReportV4, error) {
+	values, err := reportV4Args.Unpack(reportBlob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode v4 report: %v", err)
+	}
+
+	return &ReportV4{
+		FeedID:                values[0].([32]byte),
+		ValidFromTimestamp:    values[1].(uint32),
+		ObservationsTimestamp: values[2].(uint32),
+		NativeFee:             values[3].(*big.Int),
+		LinkFee:               values[4].(*big.Int),
+		ExpiresAt:             values[5].(uint32),
+		Price:                 values[6].(*big.Int),
+		MarketStatus:          values[7].(uint32),
+	}, nil
+}
+
+// EncodeReportV3 ABI-encodes a v3 report blob
+func EncodeReportV3(r *ReportV3) ([]byte, error) {
+	return reportV3Args.Pack(r.FeedID, r.ValidFromTimestamp, r.ObservationsTimestamp, r.NativeFee, r.LinkFee,
+		r.ExpiresAt, r.BenchmarkPrice, r.Bid, r.Ask)
+}
+
+// EncodeReportV4 ABI-encodes a v4 report blob
+func EncodeReportV4(r *ReportV4) ([]byte, error) {
+	return reportV4Args.Pack(r.FeedID, r.ValidFromTimestamp, r.ObservationsTimestamp, r.NativeFee, r.LinkFee,
+		r.ExpiresAt, r.Price, r.MarketStatus)
+}
@@ -0,0 +1,195 @@
+package streams
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// ETH/USD v3 and a v4 RWA feed ID; the schema is in the first two bytes
+var (
+	feedIDV3 = [32]byte(common.FromHex("0x000359843a543ee2fe414dc14c7e7920ef10f4372990b79d6361cdc0dd1ba782"))
+	feedIDV4 = [32]byte(common.FromHex("0x0004b9905d8337c34e00f8dbe31619428bac5c3937e73e6af75c71780f1770ce"))
+)
+
+func newSigners(t *testing.T, n int) ([]*ecdsa.PrivateKey, []common.Address) {
+	t.Helper()
+	var keys []*ecdsa.PrivateKey
+	var addresses []common.Address
+	for i := 0; i < n; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key)
+		addresses = append(addresses, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	return keys, addresses
+}
+
+func signedV3Report(t *testing.T, keys []*ecdsa.PrivateKey) (*FullReport, [32]byte) {
+	t.Helper()
+	blob, err := EncodeReportV3(&ReportV3{
+		FeedID:                feedIDV3,
+		ValidFromTimestamp:    1_700_000_000,
+		ObservationsTimestamp: 1_700_000_001,
+		NativeFee:             big.NewInt(1000),
+		LinkFee:               big.NewInt(2000),
+		ExpiresAt:             1_700_086_401,
+		BenchmarkPrice:        new(big.Int).Mul(big.NewInt(2500), big.NewInt(1e18)),
+		Bid:                   new(big.Int).Mul(big.NewInt(2499), big.NewInt(1e18)),
+		Ask:                   new(big.Int).Mul(big.NewInt(2501), big.NewInt(1e18)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := [32]byte{0x00, 0x06, 0xaa}
+	report, err := SignReport([3][32]byte{digest, {0x01}, {0x02}}, blob, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report, digest
+}
+
+func TestParseReportV3(t *testing.T) {
+	keys, signers := newSigners(t, 4)
+	report, digest := signedV3Report(t, keys[:2])
+
+	payload, err := report.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := ParseReport(payload, &SignerSet{ConfigDigest: digest, Signers: signers, F: 1})
+	if err != nil {
+		t.Fatalf("Expected report to verify, got %v", err)
+	}
+	if decoded.Schema() != SchemaV3 {
+		t.Fatalf("Expected schema v3, got v%d", decoded.Schema())
+	}
+
+	var price types.PriceInfo = decoded.ToPrice(0, DefaultExponent)
+	streamsPrice := price.(*types.StreamsPrice)
+	if streamsPrice.FeedID != FeedIDHex(feedIDV3) {
+		t.Errorf("Expected feed ID %s, got %s", FeedIDHex(feedIDV3), streamsPrice.FeedID)
+	}
+	if streamsPrice.Bid.Cmp(new(big.Int).Mul(big.NewInt(2499), big.NewInt(1e18))) != 0 {
+		t.Errorf("Unexpected bid %s", streamsPrice.Bid)
+	}
+	if got := price.GetTimestamp().Unix(); got != 1_700_000_001 {
+		t.Errorf("Expected observations timestamp 1700000001, got %d", got)
+	}
+	if price.GetSource() != types.SourceChainlinkStreams {
+		t.Errorf("Expected source %s, got %s", types.SourceChainlinkStreams, price.GetSource())
+	}
+}
+
+func TestDecodeReportV4(t *testing.T) {
+	blob, err := EncodeReportV4(&ReportV4{
+		FeedID:                feedIDV4,
+		ValidFromTimestamp:    1_700_000_000,
+		ObservationsTimestamp: 1_700_000_001,
+		NativeFee:             big.NewInt(0),
+		LinkFee:               big.NewInt(0),
+		ExpiresAt:             1_700_086_401,
+		Price:                 new(big.Int).Mul(big.NewInt(230), big.NewInt(1e18)),
+		MarketStatus:          MarketStatusClosed,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := DecodeReport(blob)
+	if err != nil {
+		t.Fatalf("Failed to decode v4 report: %v", err)
+	}
+	price := decoded.ToPrice(0, DefaultExponent)
+	if price.Schema != SchemaV4 || price.MarketStatus != MarketStatusClosed || price.Bid != nil {
+		t.Errorf("Unexpected v4 price: %+v", price)
+	}
+
+	// Unknown schemas are rejected
+	unknown := append([]byte{}, blob...)
+	unknown[1] = 0x09
+	if _, err := DecodeReport(unknown); err == nil {
+		t.Error("Expected an error for an unsupported schema")
+	}
+}
+
+func TestVerifyRejectsBadSignatures(t *testing.T) {
+	keys, signers := newSigners(t, 4)
+	outsider, _ := newSigners(t, 1)
+
+	tests := []struct {
+		name    string
+		keys    []*ecdsa.PrivateKey
+		tamper  func(*FullReport)
+		set     func(digest [32]byte) *SignerSet
+		wantErr error
+	}{
+		{
+			name:    "insufficient signatures",
+			keys:    keys[:1],
+			set:     func(digest [32]byte) *SignerSet { return &SignerSet{ConfigDigest: digest, Signers: signers, F: 1} },
+			wantErr: ErrInsufficientSignatures,
+		},
+		{
+			name:    "unauthorized signer",
+			keys:    []*ecdsa.PrivateKey{keys[0], outsider[0]},
+			set:     func(digest [32]byte) *SignerSet { return &SignerSet{ConfigDigest: digest, Signers: signers, F: 1} },
+			wantErr: ErrUnauthorizedSigner,
+		},
+		{
+			name:    "duplicate signer",
+			keys:    []*ecdsa.PrivateKey{keys[0], keys[0]},
+			set:     func(digest [32]byte) *SignerSet { return &SignerSet{ConfigDigest: digest, Signers: signers, F: 1} },
+			wantErr: ErrDuplicateSigner,
+		},
+		{
+			name:    "config digest mismatch",
+			keys:    keys[:2],
+			set:     func([32]byte) *SignerSet { return &SignerSet{ConfigDigest: [32]byte{0xff}, Signers: signers, F: 1} },
+			wantErr: ErrConfigDigestMismatch,
+		},
+		{
+			name:    "tampered report",
+			keys:    keys[:2],
+			tamper:  func(r *FullReport) { r.ReportBlob[len(r.ReportBlob)-1] ^= 0x01 },
+			set:     func(digest [32]byte) *SignerSet { return &SignerSet{ConfigDigest: digest, Signers: signers, F: 1} },
+			wantErr: ErrUnauthorizedSigner,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, digest := signedV3Report(t, tt.keys)
+			if tt.tamper != nil {
+				tt.tamper(report)
+			}
+			if err := Verify(report, tt.set(digest)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewSignerSet(t *testing.T) {
+	_, signers := newSigners(t, 2)
+	set, err := NewSignerSet("", []string{signers[0].Hex(), signers[1].Hex()}, 1)
+	if err != nil || len(set.Signers) != 2 {
+		t.Fatalf("Expected a signer set of 2, got %+v (%v)", set, err)
+	}
+	if _, err := NewSignerSet("", []string{signers[0].Hex()}, 1); err == nil {
+		t.Error("Expected an error when F+1 exceeds the number of signers")
+	}
+	if _, err := NewSignerSet("0x1234", []string{signers[0].Hex()}, 0); err == nil {
+		t.Error("Expected an error for a short config digest")
+	}
+}
@@ -0,0 +1,142 @@
+package streams
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrConfigDigestMismatch means the report was signed by a different DON configuration
+	ErrConfigDigestMismatch = errors.New("report config digest does not match signer set")
+	// ErrInsufficientSignatures means the report carries fewer than F+1 signatures
+	ErrInsufficientSignatures = errors.New("insufficient report signatures")
+	// ErrUnauthorizedSigner means a signature was made by a key outside the signer set
+	ErrUnauthorizedSigner = errors.New("report signed by unauthorized signer")
+	// ErrDuplicateSigner means the same signer signed the report more than once
+	ErrDuplicateSigner = errors.New("report signed twice by the same signer")
+)
+
+// SignerSet is the DON configuration reports are verified against, as configured on the
+// Data Streams verifier contract
+type SignerSet struct {
+	ConfigDigest [32]byte         // Digest of the configuration; zero accepts any digest
+	Signers      []common.Address // Oracle signing addresses
+	F            int              // Maximum number of faulty oracles; F+1 signatures are required
+}
+
+// ReportHash returns the hash the oracles sign: keccak256(keccak256(reportBlob) || reportContext)
+func ReportHash(reportBlob []byte, reportContext [3][32]byte) common.Hash {
+	blobHash := crypto.Keccak256(reportBlob)
+	return crypto.Keccak256Hash(blobHash, reportContext[0][:], reportContext[1][:], reportContext[2][:])
+}
+
+// Verify checks that a full report carries at least F+1 valid signatures from distinct
+// members of the signer set
+func Verify(report *FullReport, signers *SignerSet) error {
+	if signers == nil {
+		return fmt.Errorf("signer set cannot be nil")
+	}
+	if signers.ConfigDigest != ([32]byte{}) && report.ConfigDigest() != signers.ConfigDigest {
+		return fmt.Errorf("%w: got %x", ErrConfigDigestMismatch, report.ConfigDigest())
+	}
+	if len(report.RawRs) != len(report.RawSs) || len(report.RawRs) > len(report.RawVs) {
+		return fmt.Errorf("malformed signatures: %d r values, %d s values", len(report.RawRs), len(report.RawSs))
+	}
+	if len(report.RawRs) < signers.F+1 {
+		return fmt.Errorf("%w: got %d, need %d", ErrInsufficientSignatures, len(report.RawRs), signers.F+1)
+	}
+
+	authorized := make(map[common.Address]bool, len(signers.Signers))
+	for _, signer := range signers.Signers {
+		authorized[signer] = true
+	}
+
+	hash := ReportHash(report.ReportBlob, report.ReportContext)
+	seen := make(map[common.Address]bool, len(report.RawRs))
+	for i := range report.RawRs {
+		signature := make([]byte, 0, crypto.SignatureLength)
+		signature = append(signature, report.RawRs[i][:]...)
+		signature = append(signature, report.RawSs[i][:]...)
+		signature = append(signature, report.RawVs[i])
+
+		publicKey, err := crypto.SigToPub(hash.Bytes(), signature)
+		if err != nil {
+			return fmt.Errorf("invalid signature %d: %v", i, err)
+		}
+		signer := crypto.PubkeyToAddress(*publicKey)
+
+		if !authorized[signer] {
+			return fmt.Errorf("%w: %s", ErrUnauthorizedSigner, signer.Hex())
+		}
+		if seen[signer] {
+			return fmt.Errorf("%w: %s", ErrDuplicateSigner, signer.Hex())
+		}
+		seen[signer] = true
+	}
+
+	return nil
+}
+
+// ParseReport decodes a full report payload, verifies its signatures and decodes the report blob
+func ParseReport(payload []byte, signers *SignerSet) (Report, error) {
+	fullReport, err := DecodeFullReport(payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := Verify(fullReport, signers); err != nil {
+		return nil, err
+	}
+	return DecodeReport(fullReport.ReportBlob)
+}
+
+// SignReport signs a report blob with the given oracle keys, producing a full report that
+// Verify accepts for a signer set of those keys. Used to generate reports for local testing.
+func SignReport(reportContext [3][32]byte, reportBlob []byte, keys []*ecdsa.PrivateKey) (*FullReport, error) {
+	if len(keys) > 32 {
+		return nil, fmt.Errorf("too many signers: %d", len(keys))
+	}
+
+	report := &FullReport{ReportContext: reportContext, ReportBlob: reportBlob}
+	hash := ReportHash(reportBlob, reportContext)
+	for i, key := range keys {
+		signature, err := crypto.Sign(hash.Bytes(), key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign report: %v", err)
+		}
+
+		var r, s [32]byte
+		copy(r[:], signature[:32])
+		copy(s[:], signature[32:64])
+		report.RawRs = append(report.RawRs, r)
+		report.RawSs = append(report.RawSs, s)
+		report.RawVs[i] = signature[64]
+	}
+	return report, nil
+}
+
+// NewSignerSet builds a signer set from hex configuration values. An empty configDigest
+// accepts reports of any configuration.
+func NewSignerSet(configDigest string, signers []string, f int) (*SignerSet, error) {
+	set := &SignerSet{F: f}
+	if configDigest != "" {
+		digest := common.FromHex(configDigest)
+		if len(digest) != 32 {
+			return nil, fmt.Errorf("invalid config digest %q", configDigest)
+		}
+		copy(set.ConfigDigest[:], digest)
+	}
+	for _, signer := range signers {
+		if !common.IsHexAddress(signer) {
+			return nil, fmt.Errorf("invalid signer address %q", signer)
+		}
+		set.Signers = append(set.Signers, common.HexToAddress(signer))
+	}
+	if f < 0 || len(set.Signers) < f+1 {
+		return nil, fmt.Errorf("signer set of %d signers cannot tolerate f=%d", len(set.Signers), f)
+	}
+	return set, nil
+}
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+// Subset of the Chainlink Feed Registry interface used to resolve feeds by (base, quote)
+interface FeedRegistryInterface {
+  function decimals(address base, address quote) external view returns (uint8);
+
+  function description(address base, address quote) external view returns (string memory);
+
+  function getFeed(address base, address quote) external view returns (address aggregator);
+
+  function isFeedEnabled(address aggregator) external view returns (bool);
+
+  function latestRoundData(address base, address quote)
+    external
+    view
+    returns (
+      uint80 roundId,
+      int256 answer,
+      uint256 startedAt,
+      uint256 updatedAt,
+      uint80 answeredInRound
+    );
+}
@@ -0,0 +1,365 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package feed_registry_interface
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// FeedRegistryInterfaceMetaData contains all meta data concerning the FeedRegistryInterface contract.
+var FeedRegistryInterfaceMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"base\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"quote\",\"type\":\"address\"}],\"name\":\"decimals\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"base\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"quote\",\"type\":\"address\"}],\"name\":\"description\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"base\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"quote\",\"type\":\"address\"}],\"name\":\"getFeed\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"aggregator\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"aggregator\",\"type\":\"address\"}],\"name\":\"isFeedEnabled\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"base\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"quote\",\"type\":\"address\"}],\"name\":\"latestRoundData\",\"outputs\":[{\"internalType\":\"uint80\",\"name\":\"roundId\",\"type\":\"uint80\"},{\"internalType\":\"int256\",\"name\":\"answer\",\"type\":\"int256\"},{\"internalType\":\"uint256\",\"name\":\"startedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"updatedAt\",\"type\":\"uint256\"},{\"internalType\":\"uint80\",\"name\":\"answeredInRound\",\"type\":\"uint80\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// FeedRegistryInterfaceABI is the input ABI used to generate the binding from.
+// Deprecated: Use FeedRegistryInterfaceMetaData.ABI instead.
+var FeedRegistryInterfaceABI = FeedRegistryInterfaceMetaData.ABI
+
+// FeedRegistryInterface is an auto generated Go binding around an Ethereum contract.
+type FeedRegistryInterface struct {
+	FeedRegistryInterfaceCaller     // Read-only binding to the contract
+	FeedRegistryInterfaceTransactor // Write-only binding to the contract
+	FeedRegistryInterfaceFilterer   // Log filterer for contract events
+}
+
+// FeedRegistryInterfaceCaller is an auto generated read-only Go binding around an Ethereum contract.
+type FeedRegistryInterfaceCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FeedRegistryInterfaceTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type FeedRegistryInterfaceTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FeedRegistryInterfaceFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type FeedRegistryInterfaceFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// FeedRegistryInterfaceSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type FeedRegistryInterfaceSession struct {
+	Contract     *FeedRegistryInterface // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts          // Call options to use throughout this session
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// FeedRegistryInterfaceCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type FeedRegistryInterfaceCallerSession struct {
+	Contract *FeedRegistryInterfaceCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                // Call options to use throughout this session
+}
+
+// FeedRegistryInterfaceTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type FeedRegistryInterfaceTransactorSession struct {
+	Contract     *FeedRegistryInterfaceTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                // Transaction auth options to use throughout this session
+}
+
+// FeedRegistryInterfaceRaw is an auto generated low-level Go binding around an Ethereum contract.
+type FeedRegistryInterfaceRaw struct {
+	Contract *FeedRegistryInterface // Generic contract binding to access the raw methods on
+}
+
+// FeedRegistryInterfaceCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type FeedRegistryInterfaceCallerRaw struct {
+	Contract *FeedRegistryInterfaceCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// FeedRegistryInterfaceTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type FeedRegistryInterfaceTransactorRaw struct {
+	Contract *FeedRegistryInterfaceTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewFeedRegistryInterface creates a new instance of FeedRegistryInterface, bound to a specific deployed contract.
+func NewFeedRegistryInterface(address common.Address, backend bind.ContractBackend) (*FeedRegistryInterface, error) {
+	contract, err := bindFeedRegistryInterface(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &FeedRegistryInterface{FeedRegistryInterfaceCaller: FeedRegistryInterfaceCaller{contract: contract}, FeedRegistryInterfaceTransactor: FeedRegistryInterfaceTransactor{contract: contract}, FeedRegistryInterfaceFilterer: FeedRegistryInterfaceFilterer{contract: contract}}, nil
+}
+
+// NewFeedRegistryInterfaceCaller creates a new read-only instance of FeedRegistryInterface, bound to a specific deployed contract.
+func NewFeedRegistryInterfaceCaller(address common.Address, caller bind.ContractCaller) (*FeedRegistryInterfaceCaller, error) {
+	contract, err := bindFeedRegistryInterface(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FeedRegistryInterfaceCaller{contract: contract}, nil
+}
+
+// NewFeedRegistryInterfaceTransactor creates a new write-only instance of FeedRegistryInterface, bound to a specific deployed contract.
+func NewFeedRegistryInterfaceTransactor(address common.Address, transactor bind.ContractTransactor) (*FeedRegistryInterfaceTransactor, error) {
+	contract, err := bindFeedRegistryInterface(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &FeedRegistryInterfaceTransactor{contract: contract}, nil
+}
+
+// NewFeedRegistryInterfaceFilterer creates a new log filterer instance of FeedRegistryInterface, bound to a specific deployed contract.
+func NewFeedRegistryInterfaceFilterer(address common.Address, filterer bind.ContractFilterer) (*FeedRegistryInterfaceFilterer, error) {
+	contract, err := bindFeedRegistryInterface(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &FeedRegistryInterfaceFilterer{contract: contract}, nil
+}
+
+// bindFeedRegistryInterface binds a generic wrapper to an already deployed contract.
+func bindFeedRegistryInterface(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := FeedRegistryInterfaceMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FeedRegistryInterface *FeedRegistryInterfaceRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _FeedRegistryInterface.Contract.FeedRegistryInterfaceCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FeedRegistryInterface *FeedRegistryInterfaceRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FeedRegistryInterface.Contract.FeedRegistryInterfaceTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FeedRegistryInterface *FeedRegistryInterfaceRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FeedRegistryInterface.Contract.FeedRegistryInterfaceTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_FeedRegistryInterface *FeedRegistryInterfaceCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _FeedRegistryInterface.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_FeedRegistryInterface *FeedRegistryInterfaceTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _FeedRegistryInterface.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_FeedRegistryInterface *FeedRegistryInterfaceTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _FeedRegistryInterface.Contract.contract.Transact(opts, method, params...)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x58e2d3a8.
+//
+// Solidity: function decimals(address base, address quote) view returns(uint8)
+func (_FeedRegistryInterface *FeedRegistryInterfaceCaller) Decimals(opts *bind.CallOpts, base common.Address, quote common.Address) (uint8, error) {
+	var out []interface{}
+	err := _FeedRegistryInterface.contract.Call(opts, &out, "decimals", base, quote)
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x58e2d3a8.
+//
+// Solidity: function decimals(address base, address quote) view returns(uint8)
+func (_FeedRegistryInterface *FeedRegistryInterfaceSession) Decimals(base common.Address, quote common.Address) (uint8, error) {
+	return _FeedRegistryInterface.Contract.Decimals(&_FeedRegistryInterface.CallOpts, base, quote)
+}
+
+// Decimals is a free data retrieval call binding the contract method 0x58e2d3a8.
+//
+// Solidity: function decimals(address base, address quote) view returns(uint8)
+func (_FeedRegistryInterface *FeedRegistryInterfaceCallerSession) Decimals(base common.Address, quote common.Address) (uint8, error) {
+	return _FeedRegistryInterface.Contract.Decimals(&_FeedRegistryInterface.CallOpts, base, quote)
+}
+
+// Description is a free data retrieval call binding the contract method 0xfa820de9.
+//
+// Solidity: function description(address base, address quote) view returns(string)
+func (_FeedRegistryInterface *FeedRegistryInterfaceCaller) Description(opts *bind.CallOpts, base common.Address, quote common.Address) (string, error) {
+	var out []interface{}
+	err := _FeedRegistryInterface.contract.Call(opts, &out, "description", base, quote)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Description is a free data retrieval call binding the contract method 0xfa820de9.
+//
+// Solidity: function description(address base, address quote) view returns(string)
+func (_FeedRegistryInterface *FeedRegistryInterfaceSession) Description(base common.Address, quote common.Address) (string, error) {
+	return _FeedRegistryInterface.Contract.Description(&_FeedRegistryInterface.CallOpts, base, quote)
+}
+
+// Description is a free data retrieval call binding the contract method 0xfa820de9.
+//
+// Solidity: function description(address base, address quote) view returns(string)
+func (_FeedRegistryInterface *FeedRegistryInterfaceCallerSession) Description(base common.Address, quote common.Address) (string, error) {
+	return _FeedRegistryInterface.Contract.Description(&_FeedRegistryInterface.CallOpts, base, quote)
+}
+
+// GetFeed is a free data retrieval call binding the contract method 0xd2edb6dd.
+//
+// Solidity: function getFeed(address base, address quote) view returns(address aggregator)
+func (_FeedRegistryInterface *FeedRegistryInterfaceCaller) GetFeed(opts *bind.CallOpts, base common.Address, quote common.Address) (common.Address, error) {
+	var out []interface{}
+	err := _FeedRegistryInterface.contract.Call(opts, &out, "getFeed", base, quote)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GetFeed is a free data retrieval call binding the contract method 0xd2edb6dd.
+//
+// Solidity: function getFeed(address base, address quote) view returns(address aggregator)
+func (_FeedRegistryInterface *FeedRegistryInterfaceSession) GetFeed(base common.Address, quote common.Address) (common.Address, error) {
+	return _FeedRegistryInterface.Contract.GetFeed(&_FeedRegistryInterface.CallOpts, base, quote)
+}
+
+// GetFeed is a free data retrieval call binding the contract method 0xd2edb6dd.
+//
+// Solidity: function getFeed(address base, address quote) view returns(address aggregator)
+func (_FeedRegistryInterface *FeedRegistryInterfaceCallerSession) GetFeed(base common.Address, quote common.Address) (common.Address, error) {
+	return _FeedRegistryInterface.Contract.GetFeed(&_FeedRegistryInterface.CallOpts, base, quote)
+}
+
+// IsFeedEnabled is a free data retrieval call binding the contract method 0xb099d43b.
+//
+// Solidity: function isFeedEnabled(address aggregator) view returns(bool)
+func (_FeedRegistryInterface *FeedRegistryInterfaceCaller) IsFeedEnabled(opts *bind.CallOpts, aggregator common.Address) (bool, error) {
+	var out []interface{}
+	err := _FeedRegistryInterface.contract.Call(opts, &out, "isFeedEnabled", aggregator)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsFeedEnabled is a free data retrieval call binding the contract method 0xb099d43b.
+//
+// Solidity: function isFeedEnabled(address aggregator) view returns(bool)
+func (_FeedRegistryInterface *FeedRegistryInterfaceSession) IsFeedEnabled(aggregator common.Address) (bool, error) {
+	return _FeedRegistryInterface.Contract.IsFeedEnabled(&_FeedRegistryInterface.CallOpts, aggregator)
+}
+
+// IsFeedEnabled is a free data retrieval call binding the contract method 0xb099d43b.
+//
+// Solidity: function isFeedEnabled(address aggregator) view returns(bool)
+func (_FeedRegistryInterface *FeedRegistryInterfaceCallerSession) IsFeedEnabled(aggregator common.Address) (bool, error) {
+	return _FeedRegistryInterface.Contract.IsFeedEnabled(&_FeedRegistryInterface.CallOpts, aggregator)
+}
+
+// LatestRoundData is a free data retrieval call binding the contract method 0xbcfd032d.
+//
+// Solidity: function latestRoundData(address base, address quote) view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_FeedRegistryInterface *FeedRegistryInterfaceCaller) LatestRoundData(opts *bind.CallOpts, base common.Address, quote common.Address) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	var out []interface{}
+	err := _FeedRegistryInterface.contract.Call(opts, &out, "latestRoundData", base, quote)
+
+	outstruct := new(struct {
+		RoundId         *big.Int
+		Answer          *big.Int
+		StartedAt       *big.Int
+		UpdatedAt       *big.Int
+		AnsweredInRound *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.RoundId = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Answer = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.StartedAt = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.UpdatedAt = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.AnsweredInRound = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// LatestRoundData is a free data retrieval call binding the contract method 0xbcfd032d.
+//
+// Solidity: function latestRoundData(address base, address quote) view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_FeedRegistryInterface *FeedRegistryInterfaceSession) LatestRoundData(base common.Address, quote common.Address) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	return _FeedRegistryInterface.Contract.LatestRoundData(&_FeedRegistryInterface.CallOpts, base, quote)
+}
+
+// LatestRoundData is a free data retrieval call binding the contract method 0xbcfd032d.
+//
+// Solidity: function latestRoundData(address base, address quote) view returns(uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+func (_FeedRegistryInterface *FeedRegistryInterfaceCallerSession) LatestRoundData(base common.Address, quote common.Address) (struct {
+	RoundId         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}, error) {
+	return _FeedRegistryInterface.Contract.LatestRoundData(&_FeedRegistryInterface.CallOpts, base, quote)
+}
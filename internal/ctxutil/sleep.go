@@ -0,0 +1,21 @@
+// Package ctxutil holds small context helpers shared by the chainlink and pyth clients.
+package ctxutil
+
+import (
+	"context"
+	"time"
+)
+
+// Sleep waits for d or until ctx is done, whichever comes first. It returns ctx.Err() when the
+// context ended the wait.
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,31 @@
+package ctxutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSleepWaits(t *testing.T) {
+	start := time.Now()
+	if err := Sleep(context.Background(), 10*time.Millisecond); err != nil {
+		t.Fatalf("Sleep failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("Expected Sleep to wait 10ms, returned after %v", elapsed)
+	}
+}
+
+func TestSleepCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	if err := Sleep(ctx, time.Minute); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected Sleep to return on cancellation, took %v", elapsed)
+	}
+}
@@ -0,0 +1,83 @@
+// Package evmtest runs a funded account on go-ethereum's simulated backend. It is the chain the
+// chainlinktest and pythtest mock contracts are deployed on.
+package evmtest
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+)
+
+// SimulatedChainID is the chain ID of go-ethereum's simulated backend
+const SimulatedChainID = 1337
+
+// deployerBalance funds the account that deploys and updates the mock contracts
+var deployerBalance = new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+
+// Chain is a simulated chain with a funded account that deploys and updates mock contracts.
+// Close it when done.
+type Chain struct {
+	Backend *simulated.Backend
+	// Client reads the chain. It satisfies bind.ContractBackend, so it can be passed to
+	// chainlink.FetchPriceData and the price monitors' AddClient.
+	Client simulated.Client
+
+	auth *bind.TransactOpts
+}
+
+// NewChain starts a simulated chain
+func NewChain() (*Chain, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate deployer key: %v", err)
+	}
+	auth, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(SimulatedChainID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transactor: %v", err)
+	}
+
+	backend := simulated.NewBackend(gethtypes.GenesisAlloc{auth.From: {Balance: deployerBalance}})
+	return &Chain{Backend: backend, Client: backend.Client(), auth: auth}, nil
+}
+
+// TransactOpts returns a copy of the transactor of the funded deployer account, e.g. to send
+// transactions to the mock contracts from code under test
+func (c *Chain) TransactOpts() *bind.TransactOpts {
+	auth := *c.auth
+	return &auth
+}
+
+// Close stops the simulated chain
+func (c *Chain) Close() error {
+	return c.Backend.Close()
+}
+
+// Commit mines a block
+func (c *Chain) Commit() {
+	c.Backend.Commit()
+}
+
+// AdvanceTime moves the timestamp of the next block forward and mines it
+func (c *Chain) AdvanceTime(d time.Duration) error {
+	return c.Backend.AdjustTime(d)
+}
+
+// Mine commits a block and checks that the transaction succeeded
+func (c *Chain) Mine(tx *gethtypes.Transaction) (*gethtypes.Receipt, error) {
+	c.Backend.Commit()
+
+	receipt, err := c.Client.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get receipt of %s: %v", tx.Hash().Hex(), err)
+	}
+	if receipt.Status != gethtypes.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %s reverted", tx.Hash().Hex())
+	}
+	return receipt, nil
+}
@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+pragma solidity ^0.8.0;
+
+// Subset of the Pyth EVM contract interface (@pythnetwork/pyth-sdk-solidity) used to push
+// Hermes updates on-chain and read the stored prices
+library PythStructs {
+    struct Price {
+        int64 price;
+        uint64 conf;
+        int32 expo;
+        uint publishTime;
+    }
+}
+
+interface IPyth {
+    event PriceFeedUpdate(bytes32 indexed id, uint64 publishTime, int64 price, uint64 conf);
+
+    error InsufficientFee();
+    error NoFreshUpdate();
+    error PriceFeedNotFound();
+    error StalePrice();
+
+    function getValidTimePeriod() external view returns (uint validTimePeriod);
+
+    function getPriceUnsafe(bytes32 id) external view returns (PythStructs.Price memory price);
+
+    function getPriceNoOlderThan(bytes32 id, uint age) external view returns (PythStructs.Price memory price);
+
+    function getEmaPriceUnsafe(bytes32 id) external view returns (PythStructs.Price memory price);
+
+    function getEmaPriceNoOlderThan(bytes32 id, uint age) external view returns (PythStructs.Price memory price);
+
+    function updatePriceFeeds(bytes[] calldata updateData) external payable;
+
+    function updatePriceFeedsIfNecessary(
+        bytes[] calldata updateData,
+        bytes32[] calldata priceIds,
+        uint64[] calldata publishTimes
+    ) external payable;
+
+    function getUpdateFee(bytes[] calldata updateData) external view returns (uint feeAmount);
+}
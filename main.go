@@ -9,32 +9,98 @@ import (
 	"math/big"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/morpheum-labs/pricefeeding/chainlink"
 	"github.com/morpheum-labs/pricefeeding/pricefeed"
+	"github.com/morpheum-labs/pricefeeding/pyth"
 	"github.com/morpheum-labs/pricefeeding/rpcscan"
 	"github.com/morpheum-labs/pricefeeding/types"
-	"gopkg.in/yaml.v2"
 )
 
 func main() {
 	// Parse command line arguments
 	var (
-		chainlink = flag.Bool("chainlink", false, "Start Chainlink price feed monitor")
-		pyth      = flag.Bool("pyth", false, "Start Pyth price feed client")
+		chainlink      = flag.Bool("chainlink", false, "Start Chainlink price feed monitor")
+		pyth           = flag.Bool("pyth", false, "Start Pyth price feed client")
+		strictFeeds    = flag.Bool("strict-feeds", false, "Refuse to start the Chainlink monitor if any feed fails metadata validation")
+		snapshot       = flag.Bool("snapshot", false, "Read all Chainlink feeds of a network at one block per update cycle")
+		sequencerGrace = flag.Duration("sequencer-grace", time.Hour, "How long L2 prices stay untrusted after the sequencer comes back up")
+		adaptive       = flag.Bool("adaptive", false, "Poll each Chainlink feed on its own heartbeat/deviation schedule")
+		registryDiff   = flag.Bool("registry-diff", false, "Log how configured Chainlink proxies differ from the Feed Registry at startup")
+		heartbeatGrace = flag.Duration("heartbeat-grace", pricefeed.DefaultHeartbeatGrace, "How long past its heartbeat a Chainlink feed may go without a new round before an alarm")
+		pythStream     = flag.Bool("pyth-stream", false, "Stream Pyth prices over the Hermes WebSocket, polling over HTTP while it is down")
+		pythOnChain    = flag.Bool("pyth-onchain", false, "Also read Pyth prices from the Pyth contract of each network and compare them with Hermes")
+		ocrReport      = flag.String("ocr-report", "", "Print OCR transmission analytics for a Chainlink feed address and exit")
+		ocrRPC         = flag.String("ocr-rpc", "", "RPC endpoint used by --ocr-report")
+		ocrNetwork     = flag.Uint64("ocr-network", 42161, "Network ID of the feed passed to --ocr-report")
+		ocrBlocks      = flag.Uint64("ocr-blocks", 10000, "Number of recent blocks analyzed by --ocr-report (at most 100000)")
+		pythTWAP       = flag.String("pyth-twap", "", "With --pyth, also cache Pyth TWAPs over these comma-separated windows, e.g. 1m,5m")
+		pythHistory    = flag.Duration("pyth-history", 0, "With --pyth, seed the cache history with the prices of this past period at one-minute steps")
+		backfill       = flag.Bool("pyth-backfill", false, "Export historical Pyth prices of the configured tickers to CSV and exit")
+		backfillFrom   = flag.String("pyth-backfill-from", "", "Start of --pyth-backfill, RFC 3339 (default one hour before its end)")
+		backfillTo     = flag.String("pyth-backfill-to", "", "End of --pyth-backfill, RFC 3339 (default now)")
+		backfillStep   = flag.Duration("pyth-backfill-step", time.Minute, "Distance between the timestamps fetched by --pyth-backfill")
+		backfillOut    = flag.String("pyth-backfill-out", "pyth_backfill.csv", "CSV file written by --pyth-backfill; its progress is kept next to it to resume")
+		catalog        = flag.Bool("pyth-catalog", false, "Validate the Pyth tickers file against the Hermes feed catalog and exit")
+		catalogFile    = flag.String("pyth-catalog-file", "conf/pyth_tickers.yaml", "Tickers file validated or generated by --pyth-catalog")
+		catalogQuery   = flag.String("pyth-catalog-query", "", "With --pyth-catalog, add the Hermes feeds matching this query")
+		catalogAsset   = flag.String("pyth-catalog-asset-type", "", "With --pyth-catalog, add the Hermes feeds of this asset type, e.g. equity")
+		catalogPrune   = flag.Bool("pyth-catalog-prune", false, "With --pyth-catalog, remove tickers Hermes no longer lists")
+		catalogWrite   = flag.Bool("pyth-catalog-write", false, "With --pyth-catalog, write the updated tickers file instead of only reporting")
 	)
 	flag.Parse()
 
+	// The OCR report is a one-shot mode
+	if *ocrReport != "" {
+		ocr_report(*ocrRPC, *ocrNetwork, *ocrReport, *ocrBlocks)
+		return
+	}
+
+	// The Pyth backfill and catalog sync are one-shot modes too
+	if *backfill {
+		pyth_backfill(*backfillFrom, *backfillTo, *backfillStep, *backfillOut)
+		return
+	}
+
+	if *catalog {
+		pyth_catalog(*catalogFile, *catalogQuery, *catalogAsset, *catalogPrune, *catalogWrite)
+		return
+	}
+
 	// Check if any mode is specified
 	if !*chainlink && !*pyth {
 		fmt.Println("Usage:")
 		fmt.Println("  --chainlink    Start Chainlink price feed monitor")
 		fmt.Println("  --pyth         Start Pyth price feed client")
+		fmt.Println("  --strict-feeds Refuse to start Chainlink monitoring on feed metadata mismatch")
+		fmt.Println("  --sequencer-grace <duration> Grace period after an L2 sequencer restart (default 1h)")
+		fmt.Println("  --snapshot     Read all Chainlink feeds of a network at the same block")
+		fmt.Println("  --adaptive     Poll each Chainlink feed from its heartbeat and deviation threshold")
+		fmt.Println("  --registry-diff Compare configured Chainlink proxies with the Feed Registry")
+		fmt.Println("  --heartbeat-grace <duration> Alarm when a feed has no new round for heartbeat + grace (default 1m)")
+		fmt.Println("  --pyth-stream  Stream Pyth prices over the Hermes WebSocket with HTTP polling as fallback")
+		fmt.Println("  --pyth-onchain With --chainlink, read Pyth contract prices and log how far they lag Hermes")
+		fmt.Println("  --ocr-report <feed> --ocr-rpc <url> [--ocr-network <id>] [--ocr-blocks <n>]")
+		fmt.Println("                 Print oracle observation spread, deviation and participation for a feed")
+		fmt.Println("  --pyth-twap <windows>     Also cache Pyth TWAPs over comma-separated windows of up to 10m")
+		fmt.Println("  --pyth-history <duration> Seed the Pyth cache history with past prices before monitoring")
+		fmt.Println("  --pyth-backfill [--pyth-backfill-from <time>] [--pyth-backfill-to <time>] [--pyth-backfill-step <duration>]")
+		fmt.Println("                 [--pyth-backfill-out <file>]")
+		fmt.Println("                 Export historical Pyth prices to CSV, resuming an interrupted export")
+		fmt.Println("  --pyth-catalog [--pyth-catalog-file <path>] [--pyth-catalog-query <q>] [--pyth-catalog-asset-type <type>]")
+		fmt.Println("                 [--pyth-catalog-prune] [--pyth-catalog-write]")
+		fmt.Println("                 Validate Pyth tickers against Hermes, add matching feeds and drop removed ones")
 		fmt.Println("")
 		fmt.Println("Example:")
 		fmt.Println("  go run . --chainlink")
 		fmt.Println("  go run . --pyth")
+		fmt.Println("  go run . --pyth-catalog --pyth-catalog-asset-type equity --pyth-catalog-write")
+		fmt.Println("  go run . --ocr-report 0x639Fe6ab55C921f74e7fac1ee960C0B6293ba612 --ocr-rpc https://arb1.arbitrum.io/rpc")
 		os.Exit(1)
 	}
 
@@ -46,10 +112,10 @@ func main() {
 	// Start the appropriate service
 	if *chainlink {
 		log.Println("Starting Chainlink price feed monitor...")
-		chainlink_start()
+		chainlink_start(*strictFeeds, *sequencerGrace, *snapshot, *adaptive, *registryDiff, *heartbeatGrace, *pythOnChain)
 	} else if *pyth {
 		log.Println("Starting Pyth price feed client...")
-		pyth_start()
+		pyth_start(*pythStream, *pythTWAP, *pythHistory)
 	}
 }
 
@@ -62,22 +128,28 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
-func chainlink_start() {
+func chainlink_start(strictFeeds bool, sequencerGrace time.Duration, snapshot bool, adaptive bool, registryDiff bool, heartbeatGrace time.Duration, pythOnChain bool) {
 	log.Println("Starting Chainlink Price Feed Monitor with Switchable RPC Clients...")
 
-	// Create price feed manager for Arbitrum network (Chain ID: 42161)
+	// Create context for graceful shutdown; cancelling it aborts in-flight RPC calls
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Create price feed manager; crytos.yaml and stocks.yaml are Arbitrum feeds (Chain ID: 42161)
 	priceFeedManager := rpcscan.NewPriceFeedManager(42161)
 
-	// Load price feed configurations from YAML files
+	// Load price feed configurations from YAML files, including per-network files in conf/networks
 	if err := priceFeedManager.LoadConfig("conf"); err != nil {
 		log.Fatalf("Failed to load price feed configurations: %v", err)
 	}
 
 	// Log loaded feeds for debugging
-	allFeeds := priceFeedManager.GetAllFeeds()
-	log.Printf("Loaded %d price feeds from configuration files", len(allFeeds))
-	for _, feed := range allFeeds {
-		log.Printf("  - %s (%s): %s", feed.Name, feed.Symbol, feed.Address)
+	for _, networkID := range priceFeedManager.GetNetworkIDs() {
+		networkFeeds := priceFeedManager.GetFeedsForNetwork(networkID)
+		log.Printf("Loaded %d price feeds for network %d from configuration files", len(networkFeeds), networkID)
+		for _, feed := range networkFeeds {
+			log.Printf("  - %s (%s): %s", feed.Name, feed.Symbol, feed.Address)
+		}
 	}
 
 	// Create network configuration from price feed configs
@@ -132,27 +204,62 @@ func chainlink_start() {
 		log.Printf("Found %d feeds for network %d", len(feeds), networkID)
 		for _, feed := range feeds {
 			if feed.Address != "" && feed.Address != "0x" {
-				// Use the enhanced method with symbol
-				priceMonitor.AddPriceFeedWithSymbol(networkID, feed.Address, feed.Symbol)
+				// Use the enhanced method with symbol and decimals so the feed is verified on-chain
+				priceMonitor.AddPriceFeedWithDecimals(networkID, feed.Address, feed.Symbol, feed.Decimals)
+				priceMonitor.SetFeedSchedule(networkID, feed.Address, time.Duration(feed.Heartbeat)*time.Second, feed.Threshold)
 				priceCacheManager.AddFeed(networkID, feed.Address, types.SourceChainlink)
 				log.Printf("Added price feed %s (%s) for network %d - %s", feed.Name, feed.Address, networkID, feed.Symbol)
+			} else if feed.Base != "" && feed.Quote != "" {
+				// No proxy configured: resolve the aggregator through the Feed Registry
+				if err := priceMonitor.AddRegistryFeed(networkID, feed.Base, feed.Quote, feed.Symbol, feed.Decimals, pricefeed.FeedSchedule{
+					Heartbeat:        time.Duration(feed.Heartbeat) * time.Second,
+					ThresholdPercent: feed.Threshold,
+				}); err != nil {
+					log.Printf("Skipping registry feed %s (%s/%s): %v", feed.Name, feed.Base, feed.Quote, err)
+				}
 			} else {
 				log.Printf("Skipping invalid feed %s with address: %s", feed.Name, feed.Address)
 			}
 		}
+
+		if registryDiff {
+			logRegistryDiff(ctx, priceMonitor, networkID, feeds)
+		}
 	}
 
+	// Verify feed metadata (description, decimals, version) against the configuration
+	priceMonitor.SetRefuseStartOnMismatch(strictFeeds)
+	if validationErrors := priceMonitor.ValidateFeeds(ctx); len(validationErrors) > 0 {
+		if strictFeeds {
+			log.Fatalf("%d Chainlink feed validation error(s), refusing to start", len(validationErrors))
+		}
+		log.Printf("Warning: %d Chainlink feed validation error(s), continuing", len(validationErrors))
+	}
+
+	// Prices on L2 networks are marked untrusted while the sequencer is down and during this grace period
+	priceMonitor.SetSequencerGracePeriod(sequencerGrace)
+
+	// Pin every cycle to one block per network so prices of different feeds are consistent
+	priceMonitor.SetSnapshotMode(snapshot)
+
+	// Poll each feed just after its expected heartbeat and as often as its deviation threshold needs
+	priceMonitor.SetAdaptiveScheduling(adaptive)
+
+	// Feeds with a configured heartbeat raise an alarm when no new round lands within heartbeat + grace
+	priceMonitor.SetHeartbeatGrace(heartbeatGrace)
+
 	// Start price monitoring
-	go priceMonitor.Start()
+	go priceMonitor.Start(ctx)
+
+	// Read the prices pushed to each network's Pyth contract as a separate source
+	if pythOnChain {
+		startPythOnChain(ctx, priceFeedManager, networkConfig, priceCacheManager)
+	}
 
 	// Set up signal handling for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	// Create context for graceful shutdown
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
 	// Start price cache updater goroutine
 	go func() {
 		ticker := time.NewTicker(15 * time.Second)
@@ -216,11 +323,10 @@ func chainlink_start() {
 					if len(prices) > 0 {
 						log.Printf("📊 CURRENT CHAINLINK PRICES - Network %d:", networkID)
 
-						// Get all feeds to match addresses with names
-						allFeeds := priceFeedManager.GetAllFeeds()
+						// Get the feeds configured for this network to match addresses with names
 						feedMap := make(map[string]rpcscan.PriceFeedInfo)
-						for _, feed := range allFeeds {
-							feedMap[feed.Address] = feed
+						for _, feed := range priceFeedManager.GetFeedsForNetwork(networkID) {
+							feedMap[strings.ToLower(feed.Address)] = feed
 						}
 
 						for feedAddress, priceInfo := range prices {
@@ -231,7 +337,7 @@ func chainlink_start() {
 							}
 
 							// Find feed info
-							feedInfo, exists := feedMap[feedAddress]
+							feedInfo, exists := feedMap[strings.ToLower(feedAddress)]
 							var feedName, symbol string
 							if exists {
 								feedName = feedInfo.Name
@@ -309,6 +415,62 @@ func chainlink_start() {
 	log.Println("Shutdown complete")
 }
 
+// startPythOnChain reads the Pyth tickers from the Pyth contract of every network that declares
+// one and periodically logs how far the on-chain prices lag behind Hermes
+func startPythOnChain(ctx context.Context, priceFeedManager *rpcscan.PriceFeedManager, networkConfig *rpcscan.NetworkConfiguration, priceCacheManager *pricefeed.PriceCacheManager) {
+	priceFeeds, err := loadPythTickers("conf/pyth_tickers.yaml")
+	if err != nil {
+		log.Printf("Not reading on-chain Pyth prices: %v", err)
+		return
+	}
+
+	monitor := pricefeed.NewPythOnChainMonitor(priceCacheManager, 30*time.Second)
+	monitor.SetNetworkConfig(networkConfig)
+	clients := networkConfig.GetAllClients()
+	for networkID, contract := range priceFeedManager.GetPythContracts() {
+		client, exists := clients[networkID]
+		if !exists {
+			continue
+		}
+		if err := monitor.SetContract(networkID, contract); err != nil {
+			log.Printf("Skipping on-chain Pyth prices on network %d: %v", networkID, err)
+			continue
+		}
+		monitor.AddClient(networkID, client.GetClient())
+		for priceID, symbol := range priceFeeds {
+			if err := monitor.AddPriceFeed(networkID, priceID, symbol); err != nil {
+				log.Printf("Skipping Pyth ticker %s: %v", symbol, err)
+			}
+		}
+		log.Printf("Reading %d Pyth prices from %s on network %d", len(priceFeeds), contract, networkID)
+	}
+	go monitor.Start(ctx)
+
+	go func() {
+		hermes := pyth.NewHermesClient("https://hermes.pyth.network", nil)
+		ticker := time.NewTicker(60 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				lags, err := monitor.CompareWithHermes(ctx, hermes)
+				if err != nil {
+					log.Printf("Failed to compare on-chain Pyth prices with Hermes: %v", err)
+					continue
+				}
+				for _, lag := range lags {
+					if lag.Stale {
+						log.Printf("⚠️  Pyth contract on network %d is %v behind Hermes for %s", lag.NetworkID, lag.Lag, lag.Symbol)
+					}
+				}
+			}
+		}
+	}()
+}
+
 // Helper function to get asset name from price ID
 func getAssetName(priceId string, priceIdToAsset map[string]string) string {
 	if assetName, exists := priceIdToAsset[priceId]; exists {
@@ -317,18 +479,6 @@ func getAssetName(priceId string, priceIdToAsset map[string]string) string {
 	return "Unknown"
 }
 
-// PythTicker represents a single Pyth price feed configuration
-type PythTicker struct {
-	Symbol      string `yaml:"symbol"`
-	PriceID     string `yaml:"priceId"`
-	Decimals    int    `yaml:"decimals"`
-	Description string `yaml:"description"`
-	Category    string `yaml:"category"`
-}
-
-// PythTickersConfig represents the entire Pyth tickers configuration
-type PythTickersConfig map[string]PythTicker
-
 // loadPythTickers loads Pyth tickers from the YAML configuration file
 func loadPythTickers(configPath string) (map[string]string, error) {
 	// Read the YAML file
@@ -338,14 +488,14 @@ func loadPythTickers(configPath string) (map[string]string, error) {
 	}
 
 	// Parse the YAML
-	var config PythTickersConfig
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	tickers, err := pyth.ParseTickers(data)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse Pyth tickers YAML: %v", err)
 	}
 
 	// Convert to priceID -> symbol mapping
 	priceFeeds := make(map[string]string)
-	for _, ticker := range config {
+	for _, ticker := range tickers {
 		if ticker.PriceID != "" && ticker.Symbol != "" {
 			priceFeeds[ticker.PriceID] = ticker.Symbol
 			log.Printf("Loaded Pyth ticker: %s (%s)", ticker.Symbol, ticker.PriceID)
@@ -356,7 +506,7 @@ func loadPythTickers(configPath string) (map[string]string, error) {
 	return priceFeeds, nil
 }
 
-func pyth_start() {
+func pyth_start(stream bool, twapWindows string, history time.Duration) {
 	log.Println("Starting Pyth Price Feed Monitor...")
 
 	// Default configuration
@@ -397,12 +547,47 @@ func pyth_start() {
 		monitor.AddPriceFeed(priceID, symbol)
 	}
 
+	// Subscribe all feeds over the WebSocket; the interval then only applies while it is down
+	if stream {
+		monitor.EnableStreaming(nil)
+	}
+
+	// TWAPs are cached per window next to the spot and EMA prices
+	if twapWindows != "" {
+		var windows []time.Duration
+		for _, value := range strings.Split(twapWindows, ",") {
+			window, err := time.ParseDuration(strings.TrimSpace(value))
+			if err != nil {
+				log.Fatalf("Invalid --pyth-twap window %q: %v", value, err)
+			}
+			windows = append(windows, window)
+		}
+		if err := monitor.SetTWAPWindows(windows...); err != nil {
+			log.Fatalf("Invalid --pyth-twap: %v", err)
+		}
+	}
+
 	// Set up signal handling for graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
+	// Create context for graceful shutdown; cancelling it aborts in-flight Hermes requests
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Seed the cache history before the live updates extend it
+	if history > 0 {
+		now := time.Now()
+		report, err := monitor.Backfill(ctx, now.Add(-history), now, time.Minute)
+		if err != nil {
+			log.Printf("Failed to seed Pyth price history: %v", err)
+		} else {
+			log.Printf("Seeded Pyth price history: %d prices at %d timestamps, %d failed", report.Prices, report.Fetched, len(report.Failed))
+		}
+	}
+
 	// Start the monitor in a goroutine
-	go monitor.Start()
+	go monitor.Start(ctx)
 
 	// Start a status display goroutine
 	go func() {
@@ -450,7 +635,11 @@ func pyth_start() {
 		log.Printf("  - %s (%s)", symbol, priceID)
 	}
 	log.Println("Features:")
-	log.Printf("- Polling every %v", interval)
+	if stream {
+		log.Printf("- Streaming over %s, polling every %v while the WebSocket is down", pricefeed.DefaultPythWebSocketURL, interval)
+	} else {
+		log.Printf("- Polling every %v", interval)
+	}
 	log.Println("- Immediate price printing when updates are received")
 	log.Println("- Local price cache with persistence tracking")
 	log.Println("- Thread-safe operations")
@@ -460,6 +649,202 @@ func pyth_start() {
 	// Wait for shutdown signal
 	<-sigChan
 	log.Println("Received shutdown signal, stopping Pyth price monitor...")
+	cancel()
 	monitor.Stop()
 	log.Println("Pyth price monitor stopped.")
 }
+
+// logRegistryDiff logs whether the configured proxies of a network point to the aggregators
+// the Feed Registry resolves for their (base, quote) pairs
+func logRegistryDiff(ctx context.Context, priceMonitor *pricefeed.CLPriceMonitor, networkID uint64, feeds []rpcscan.PriceFeedInfo) {
+	if _, exists := chainlink.FeedRegistryAddresses[networkID]; !exists {
+		return
+	}
+
+	var refs []chainlink.RegistryFeedRef
+	for _, feed := range feeds {
+		if feed.Base != "" && feed.Quote != "" {
+			refs = append(refs, chainlink.RegistryFeedRef{Name: feed.Name, Proxy: feed.Address, Base: feed.Base, Quote: feed.Quote})
+		}
+	}
+
+	diffs, err := priceMonitor.RegistryDiffReport(ctx, networkID, refs)
+	if err != nil {
+		log.Printf("Feed Registry diff for network %d failed: %v", networkID, err)
+		return
+	}
+
+	log.Printf("Feed Registry diff for network %d (%d feeds):", networkID, len(diffs))
+	for _, diff := range diffs {
+		switch {
+		case diff.Err != nil:
+			log.Printf("  ? %s: %v", diff.Name, diff.Err)
+		case diff.Proxy == "":
+			log.Printf("  + %s: registry only, aggregator %s", diff.Name, diff.RegistryAggregator)
+		case diff.Match:
+			log.Printf("  = %s: proxy %s -> aggregator %s", diff.Name, diff.Proxy, diff.RegistryAggregator)
+		default:
+			log.Printf("  ! %s: proxy %s -> aggregator %s, registry -> %s", diff.Name, diff.Proxy, diff.ProxyAggregator, diff.RegistryAggregator)
+		}
+	}
+}
+
+// ocr_report prints OCR transmission analytics for one feed: observation spread per round and
+// each oracle's deviation from the median and participation
+func ocr_report(rpcURL string, networkID uint64, feedAddress string, blocks uint64) {
+	if rpcURL == "" {
+		log.Fatal("--ocr-report needs an RPC endpoint (--ocr-rpc)")
+	}
+
+	client, err := ethclient.Dial(rpcURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to %s: %v", rpcURL, err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	priceMonitor := pricefeed.NewCLPriceMonitor(pricefeed.NewPriceCacheManager(), 30*time.Second, false)
+	priceMonitor.AddClient(networkID, client)
+
+	report, err := priceMonitor.TransmissionReport(ctx, networkID, feedAddress, blocks)
+	if err != nil {
+		log.Fatalf("OCR report for %s failed: %v", feedAddress, err)
+	}
+
+	fmt.Printf("OCR transmissions of feed %s (aggregator %s) on network %d, blocks %d-%d\n",
+		feedAddress, report.Aggregator, networkID, report.FromBlock, report.ToBlock)
+	fmt.Printf("Rounds: %d, mean spread %.2f bps, max spread %.2f bps\n", len(report.Rounds), report.MeanSpreadBps, report.MaxSpreadBps)
+
+	fmt.Println("\nRound       Block       Answer                Observers  Spread (bps)")
+	for _, round := range report.Rounds {
+		fmt.Printf("%-11d %-11d %-21s %-10d %.2f\n", round.AggregatorRoundID, round.BlockNumber, round.Answer, len(round.Deviations), round.SpreadBps)
+	}
+
+	fmt.Println("\nOracle  Transmitter                                 Participation  Transmitted  Mean |dev| (bps)  Max |dev| (bps)")
+	for _, oracle := range report.Oracles {
+		transmitter := "unknown"
+		if oracle.Transmitter != (common.Address{}) {
+			transmitter = oracle.Transmitter.Hex()
+		}
+		fmt.Printf("%-7d %-43s %12.1f%%  %-11d  %-16.2f  %.2f\n", oracle.Oracle, transmitter, oracle.Participation*100,
+			oracle.Transmissions, oracle.MeanAbsDeviationBps, oracle.MaxAbsDeviationBps)
+	}
+}
+
+// pyth_catalog validates a Pyth tickers file against the Hermes feed catalog, and with write set
+// saves it with the feeds selected by query and assetType added and, with prune, removed feeds dropped
+func pyth_catalog(path, query, assetType string, prune, write bool) {
+	data, err := os.ReadFile(path)
+	if err != nil && !(os.IsNotExist(err) && write) {
+		log.Fatalf("Failed to read %s: %v", path, err)
+	}
+
+	options := &pyth.CatalogSyncOptions{Prune: prune}
+	if query != "" {
+		options.Query = &query
+	}
+	if assetType != "" {
+		selected := pyth.AssetType(assetType)
+		options.AssetType = &selected
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client := pyth.NewHermesClient("https://hermes.pyth.network", nil)
+	report, err := client.SyncTickers(ctx, data, options)
+	if err != nil {
+		log.Fatalf("Pyth catalog sync failed: %v", err)
+	}
+
+	fmt.Printf("%d issue(s) in %s\n", len(report.Issues), path)
+	for _, issue := range report.Issues {
+		fmt.Printf("  %s\n", issue)
+	}
+	for _, ticker := range report.Removed {
+		fmt.Printf("- %s (%s) %s\n", ticker.Key, ticker.Symbol, ticker.PriceID)
+	}
+	for _, ticker := range report.Added {
+		fmt.Printf("+ %s (%s) %s\n", ticker.Key, ticker.Symbol, ticker.PriceID)
+	}
+
+	if !write {
+		if len(report.Added) > 0 || len(report.Removed) > 0 {
+			fmt.Println("Run with --pyth-catalog-write to apply these changes")
+		}
+		if len(report.Issues) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+	if err := os.WriteFile(path, report.Output, 0o644); err != nil {
+		log.Fatalf("Failed to write %s: %v", path, err)
+	}
+	fmt.Printf("Wrote %s (%d added, %d removed)\n", path, len(report.Added), len(report.Removed))
+}
+
+// pyth_backfill exports the historical prices of the configured Pyth tickers to a CSV file. The
+// progress is kept in <out>.progress, so rerunning the command with the same explicit range
+// resumes an interrupted or partly failed export.
+func pyth_backfill(from, to string, step time.Duration, out string) {
+	priceFeeds, err := loadPythTickers("conf/pyth_tickers.yaml")
+	if err != nil {
+		log.Fatalf("Failed to load Pyth tickers: %v", err)
+	}
+
+	end := time.Now()
+	if to != "" {
+		if end, err = time.Parse(time.RFC3339, to); err != nil {
+			log.Fatalf("Invalid --pyth-backfill-to: %v", err)
+		}
+	}
+	start := end.Add(-time.Hour)
+	if from != "" {
+		if start, err = time.Parse(time.RFC3339, from); err != nil {
+			log.Fatalf("Invalid --pyth-backfill-from: %v", err)
+		}
+	}
+
+	config := pricefeed.PythBackfillConfig{
+		Symbols:      priceFeeds,
+		Start:        start,
+		End:          end,
+		Step:         step,
+		ProgressFile: out + ".progress",
+	}
+	for priceID := range priceFeeds {
+		config.PriceIDs = append(config.PriceIDs, priceID)
+	}
+
+	// A resumed export appends to the rows of the earlier run
+	_, statErr := os.Stat(config.ProgressFile)
+	resuming := statErr == nil
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resuming {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	file, err := os.OpenFile(out, flags, 0o644)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", out, err)
+	}
+	defer file.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	client := pyth.NewHermesClient("https://hermes.pyth.network", nil)
+	report, err := pricefeed.BackfillPyth(ctx, client, config, pricefeed.NewPythCSVSink(file, !resuming))
+	if report != nil {
+		fmt.Printf("%d timestamp(s): %d fetched, %d resumed, %d failed; %d price(s) written to %s\n",
+			report.Timestamps, report.Fetched, report.Resumed, len(report.Failed), report.Prices, out)
+	}
+	if err != nil {
+		log.Fatalf("Pyth backfill failed: %v", err)
+	}
+	if len(report.Failed) > 0 {
+		fmt.Println("Run the same command again to retry the failed timestamps")
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,24 @@
+package pricefeed
+
+import "time"
+
+// SetHeartbeatGrace sets how long past its heartbeat a feed may go without a new round before
+// HeartbeatMissed is raised (default 1m)
+func (pm *CLPriceMonitor) SetHeartbeatGrace(grace time.Duration) {
+	pm.heartbeatWatcher.SetGrace(grace)
+}
+
+// OnHeartbeatEvent registers a handler called when a feed misses its heartbeat and when it recovers
+func (pm *CLPriceMonitor) OnHeartbeatEvent(handler HeartbeatHandler) {
+	pm.heartbeatWatcher.OnEvent(handler)
+}
+
+// GetMissedHeartbeats returns the feeds currently past their heartbeat plus grace
+func (pm *CLPriceMonitor) GetMissedHeartbeats() []HeartbeatEvent {
+	return pm.heartbeatWatcher.Missed()
+}
+
+// GetHeartbeatWatcher returns the heartbeat watcher (e.g. to check feeds at a given time)
+func (pm *CLPriceMonitor) GetHeartbeatWatcher() *HeartbeatWatcher {
+	return pm.heartbeatWatcher
+}
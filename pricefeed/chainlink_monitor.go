@@ -1,6 +1,7 @@
 package pricefeed
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math/big"
@@ -8,7 +9,7 @@ import (
 	"sync"
 	"time"
 
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 
 	"github.com/morpheum-labs/pricefeeding/chainlink"
 	"github.com/morpheum-labs/pricefeeding/rpcscan"
@@ -18,13 +19,56 @@ import (
 // CLPriceMonitor handles monitoring of Chainlink price feeds
 type CLPriceMonitor struct {
 	cacheManager  *PriceCacheManager
-	clients       map[uint64]*ethclient.Client
+	clients       *evmClients // Client per network, switched through the network configuration
 	mu            sync.RWMutex
 	stopChan      chan struct{}
 	interval      time.Duration
-	networkConfig *rpcscan.NetworkConfiguration // Network configuration for RPC switching
-	feedSymbols   map[uint64]map[string]string  // networkID -> feedAddress -> symbol mapping
-	immediateMode bool                          // If true, prints prices immediately when received
+	feedSymbols   map[uint64]map[string]string // networkID -> feedAddress -> symbol mapping
+	immediateMode bool                         // If true, prints prices immediately when received
+
+	feedRegistrations     map[uint64]map[string]*feedRegistration // networkID -> feedAddress -> metadata verification state
+	refuseStartOnMismatch bool                                    // If true, Start refuses to poll when any feed fails validation
+	metadataCache         *chainlink.MetadataCache                // Cached decimals/description and bindings per feed
+
+	sequencerFeeds       map[uint64]string                     // networkID -> L2 sequencer uptime feed address
+	sequencerGracePeriod time.Duration                         // How long prices stay untrusted after a sequencer restart
+	sequencerStatus      map[uint64]*chainlink.SequencerStatus // networkID -> latest sequencer status
+
+	snapshotMode   bool                           // If true, each cycle reads all feeds of a network at one block
+	snapshotBlocks map[uint64]*chainlink.BlockRef // networkID -> block of the latest snapshot
+
+	roundStats   map[uint64]map[string]*RoundValidationStats // networkID -> feedAddress -> round validation counters
+	maxClockSkew time.Duration                               // Allowed clock skew for future updatedAt checks
+
+	cycleTimeout time.Duration // Time budget of one update cycle, 0 uses the interval
+
+	retryPolicy *chainlink.RetryPolicy // Per error category switch/backoff/fail-fast policy, nil uses the default
+
+	adaptiveScheduling bool           // If true, each feed is polled on its own heartbeat/deviation schedule
+	scheduler          *PollScheduler // Per-feed next poll times used in adaptive mode
+
+	registryFeeds           map[uint64]map[string]*registryFeed // networkID -> "base/quote" -> feed resolved through the Feed Registry
+	registryResolveInterval time.Duration                       // How often registry feeds are re-resolved
+	lastRegistryResolve     time.Time                           // When registry feeds were last re-resolved
+
+	phaseHistory             map[uint64]map[string][]chainlink.PhaseInfo // networkID -> proxy address -> observed phases, oldest first
+	phaseCheckInterval       time.Duration                               // How often every proxy's aggregator and phase are re-read
+	phaseCheckFailures       map[uint64]map[string]*phaseCheckFailure    // networkID -> proxy address -> last failed phase read
+	lastPhaseCheck           time.Time                                   // When proxies were last re-read
+	aggregatorChangeHandlers []AggregatorChangeHandler                   // Called when a proxy's aggregator changes
+
+	heartbeatWatcher *HeartbeatWatcher // Raises missed/recovered events from each feed's configured heartbeat
+}
+
+// registrationVerifyTimeout bounds the metadata reads done when a feed is registered
+const registrationVerifyTimeout = 15 * time.Second
+
+// feedRegistration tracks the configured metadata of a feed and the result of verifying it on-chain
+type feedRegistration struct {
+	expected chainlink.ExpectedFeedMetadata
+	metadata *chainlink.FeedMetadata
+	errors   []*chainlink.FeedValidationError
+	verified bool
 }
 
 // NewCLPriceMonitor creates a new Chainlink price monitor
@@ -34,27 +78,46 @@ type CLPriceMonitor struct {
 func NewCLPriceMonitor(cacheManager *PriceCacheManager, interval time.Duration, immediateMode bool) *CLPriceMonitor {
 	return &CLPriceMonitor{
 		cacheManager:  cacheManager,
-		clients:       make(map[uint64]*ethclient.Client),
+		clients:       newEVMClients(),
 		stopChan:      make(chan struct{}),
 		interval:      interval,
 		feedSymbols:   make(map[uint64]map[string]string),
 		immediateMode: immediateMode,
+
+		feedRegistrations: make(map[uint64]map[string]*feedRegistration),
+		metadataCache:     chainlink.NewMetadataCache(chainlink.DefaultMetadataTTL),
+
+		sequencerFeeds:       defaultSequencerFeeds(),
+		sequencerGracePeriod: chainlink.DefaultSequencerGracePeriod,
+		sequencerStatus:      make(map[uint64]*chainlink.SequencerStatus),
+
+		snapshotBlocks: make(map[uint64]*chainlink.BlockRef),
+
+		roundStats:   make(map[uint64]map[string]*RoundValidationStats),
+		maxClockSkew: chainlink.DefaultMaxClockSkew,
+
+		scheduler:        NewPollScheduler(DefaultSchedulerConfig()),
+		heartbeatWatcher: NewHeartbeatWatcher(DefaultHeartbeatGrace),
+
+		registryFeeds:           make(map[uint64]map[string]*registryFeed),
+		registryResolveInterval: defaultRegistryResolveInterval,
+
+		phaseHistory:       make(map[uint64]map[string][]chainlink.PhaseInfo),
+		phaseCheckInterval: defaultPhaseCheckInterval,
+		phaseCheckFailures: make(map[uint64]map[string]*phaseCheckFailure),
 	}
 }
 
-// AddClient adds an Ethereum client for a specific network
-func (pm *CLPriceMonitor) AddClient(networkID uint64, client *ethclient.Client) {
-	pm.mu.Lock()
-	defer pm.mu.Unlock()
-	pm.clients[networkID] = client
+// AddClient adds an Ethereum client for a specific network. Any bind.ContractBackend works,
+// e.g. an *ethclient.Client or a simulated backend client in tests.
+func (pm *CLPriceMonitor) AddClient(networkID uint64, client bind.ContractBackend) {
+	pm.clients.set(networkID, client)
 	log.Printf("Added client for network %d", networkID)
 }
 
 // UpdateClient updates an Ethereum client for a specific network (used after RPC switching)
-func (pm *CLPriceMonitor) UpdateClient(networkID uint64, client *ethclient.Client) {
-	pm.mu.Lock()
-	defer pm.mu.Unlock()
-	pm.clients[networkID] = client
+func (pm *CLPriceMonitor) UpdateClient(networkID uint64, client bind.ContractBackend) {
+	pm.clients.set(networkID, client)
 	log.Printf("Updated client for network %d after RPC switch", networkID)
 }
 
@@ -63,18 +126,132 @@ func (pm *CLPriceMonitor) AddPriceFeed(networkID uint64, feedAddress string) {
 	pm.cacheManager.AddFeed(networkID, feedAddress, types.SourceChainlink)
 }
 
-// AddPriceFeedWithSymbol adds a price feed to monitor with a symbol for better display
+// AddPriceFeedWithSymbol adds a price feed to monitor with a symbol for better display.
+// The feed's description() is verified against the symbol (see AddPriceFeedWithDecimals).
 func (pm *CLPriceMonitor) AddPriceFeedWithSymbol(networkID uint64, feedAddress string, symbol string) {
+	pm.AddPriceFeedWithDecimals(networkID, feedAddress, symbol, 0)
+}
+
+// AddPriceFeedWithDecimals adds a price feed to monitor with its configured symbol and decimals.
+// If a client is already available for the network, the feed's description(), decimals() and
+// version() are read immediately and compared with the configuration; otherwise the check is
+// deferred until ValidateFeeds or Start. A decimals value of 0 skips the decimals check.
+func (pm *CLPriceMonitor) AddPriceFeedWithDecimals(networkID uint64, feedAddress string, symbol string, decimals int) {
 	pm.cacheManager.AddFeed(networkID, feedAddress, types.SourceChainlink)
 
 	pm.mu.Lock()
-	defer pm.mu.Unlock()
-
 	if pm.feedSymbols[networkID] == nil {
 		pm.feedSymbols[networkID] = make(map[string]string)
 	}
 	pm.feedSymbols[networkID][feedAddress] = symbol
+
+	if pm.feedRegistrations[networkID] == nil {
+		pm.feedRegistrations[networkID] = make(map[string]*feedRegistration)
+	}
+	pm.feedRegistrations[networkID][feedAddress] = &feedRegistration{
+		expected: chainlink.ExpectedFeedMetadata{Symbol: symbol, Decimals: decimals},
+	}
+	pm.mu.Unlock()
+	_, hasClient := pm.clients.get(networkID)
+
 	log.Printf("Added Chainlink price feed: %s (%s) for network %d", symbol, feedAddress, networkID)
+
+	if hasClient {
+		ctx, cancel := context.WithTimeout(context.Background(), registrationVerifyTimeout)
+		defer cancel()
+		pm.verifyFeed(ctx, networkID, feedAddress)
+	}
+}
+
+// verifyFeed reads the on-chain metadata of a registered feed and records any validation errors
+func (pm *CLPriceMonitor) verifyFeed(ctx context.Context, networkID uint64, feedAddress string) []*chainlink.FeedValidationError {
+	pm.mu.RLock()
+	registration, registered := pm.feedRegistrations[networkID][feedAddress]
+	pm.mu.RUnlock()
+
+	if !registered {
+		return nil
+	}
+	opts, err := pm.fetchOptions(networkID, feedAddress)
+	if err != nil {
+		return nil
+	}
+
+	// Failing endpoints are switched away from by the same policy as price reads
+	metadata, validationErrors := chainlink.VerifyFeedWithRetry(ctx, opts, registration.expected)
+
+	pm.mu.Lock()
+	registration.metadata = metadata
+	registration.errors = validationErrors
+	registration.verified = true
+	pm.mu.Unlock()
+
+	// Seed the metadata cache so polling does not read decimals again
+	if metadata != nil {
+		pm.metadataCache.Set(networkID, feedAddress, metadata)
+	}
+
+	for _, validationErr := range validationErrors {
+		log.Printf("Chainlink feed validation failed: %v", validationErr)
+	}
+
+	return validationErrors
+}
+
+// ValidateFeeds verifies every registered feed that has not been verified yet and
+// returns all validation errors known for the monitored feeds
+func (pm *CLPriceMonitor) ValidateFeeds(ctx context.Context) []*chainlink.FeedValidationError {
+	pm.mu.RLock()
+	pending := make(map[uint64][]string)
+	for networkID, registrations := range pm.feedRegistrations {
+		for feedAddress, registration := range registrations {
+			if !registration.verified {
+				pending[networkID] = append(pending[networkID], feedAddress)
+			}
+		}
+	}
+	pm.mu.RUnlock()
+
+	for networkID, feedAddresses := range pending {
+		for _, feedAddress := range feedAddresses {
+			pm.verifyFeed(ctx, networkID, feedAddress)
+		}
+	}
+
+	return pm.GetFeedValidationErrors()
+}
+
+// GetFeedValidationErrors returns the validation errors recorded for all monitored feeds
+func (pm *CLPriceMonitor) GetFeedValidationErrors() []*chainlink.FeedValidationError {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	var validationErrors []*chainlink.FeedValidationError
+	for _, registrations := range pm.feedRegistrations {
+		for _, registration := range registrations {
+			validationErrors = append(validationErrors, registration.errors...)
+		}
+	}
+	return validationErrors
+}
+
+// GetFeedMetadata returns the on-chain metadata read when the feed was verified
+func (pm *CLPriceMonitor) GetFeedMetadata(networkID uint64, feedAddress string) (*chainlink.FeedMetadata, error) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	registration, exists := pm.feedRegistrations[networkID][feedAddress]
+	if !exists || registration.metadata == nil {
+		return nil, fmt.Errorf("no metadata for feed %s on network %d", feedAddress, networkID)
+	}
+	return registration.metadata, nil
+}
+
+// SetRefuseStartOnMismatch sets whether Start refuses to poll when any feed fails validation
+func (pm *CLPriceMonitor) SetRefuseStartOnMismatch(refuse bool) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.refuseStartOnMismatch = refuse
 }
 
 // GetPrice retrieves the latest price for a specific feed
@@ -101,49 +278,92 @@ func (pm *CLPriceMonitor) GetAllPrices(networkID uint64) map[string]*types.Chain
 	return result
 }
 
-// fetchPriceData fetches price data from a specific feed
-func (pm *CLPriceMonitor) fetchPriceData(networkID uint64, feedAddress string) (*types.ChainlinkPrice, error) {
+// GetPricesBySymbol retrieves the latest price of a symbol (e.g. "ETH/USD") on every network
+// that monitors it, by network ID
+func (pm *CLPriceMonitor) GetPricesBySymbol(symbol string) map[uint64]*types.ChainlinkPrice {
+	pm.mu.RLock()
+	feeds := make(map[uint64]string)
+	for networkID, networkSymbols := range pm.feedSymbols {
+		for feedAddress, feedSymbol := range networkSymbols {
+			if strings.EqualFold(feedSymbol, symbol) {
+				feeds[networkID] = feedAddress
+				break
+			}
+		}
+	}
+	pm.mu.RUnlock()
+
+	result := make(map[uint64]*types.ChainlinkPrice)
+	for networkID, feedAddress := range feeds {
+		if price, err := pm.GetPrice(networkID, feedAddress); err == nil {
+			result[networkID] = price
+		}
+	}
+	return result
+}
+
+// fetchPriceData fetches price data from a specific feed, at the given block or the latest one when block is nil
+func (pm *CLPriceMonitor) fetchPriceData(ctx context.Context, networkID uint64, feedAddress string, block *chainlink.BlockRef) (*types.ChainlinkPrice, error) {
+	opts, err := pm.fetchOptions(networkID, feedAddress)
+	if err != nil {
+		return nil, err
+	}
+	opts.MetadataCache = pm.metadataCache
+	opts.Block = block
+
+	// Use the chainlink package to fetch price data
+	return chainlink.FetchPriceData(ctx, opts)
+}
+
+// fetchOptions returns the options for reading a feed with the network's client, retry policy and
+// RPC switching through the network configuration
+func (pm *CLPriceMonitor) fetchOptions(networkID uint64, feedAddress string) (chainlink.FetchPriceDataOptions, error) {
 	pm.mu.RLock()
-	client, exists := pm.clients[networkID]
-	networkConfig := pm.networkConfig
+	retryPolicy := pm.retryPolicy
 	pm.mu.RUnlock()
 
+	client, exists := pm.clients.get(networkID)
 	if !exists {
-		return nil, fmt.Errorf("no client available for network %d", networkID)
+		return chainlink.FetchPriceDataOptions{}, fmt.Errorf("no client available for network %d", networkID)
 	}
 
-	// Create RPC switcher adapter if network config is available
+	// Switch RPCs through the network config if it is available
 	var rpcSwitcher chainlink.RPCSwitcher
-	if networkConfig != nil {
-		rpcSwitcher = &rpcSwitcherAdapter{
-			networkConfig: networkConfig,
-			priceMonitor:  pm,
-			networkID:     networkID,
-		}
+	if switcher := pm.clients.switcher(); switcher != nil {
+		rpcSwitcher = switcher
 	}
 
-	// Use the chainlink package to fetch price data
-	opts := chainlink.FetchPriceDataOptions{
+	return chainlink.FetchPriceDataOptions{
 		NetworkID:   networkID,
 		FeedAddress: feedAddress,
 		Client:      client,
 		RPCSwitcher: rpcSwitcher,
 		MaxRetries:  1,
 		RetryDelay:  2 * time.Second,
-	}
-
-	return chainlink.FetchPriceData(opts)
+		RetryPolicy: retryPolicy,
+	}, nil
 }
 
-// updateAllPrices updates all monitored price feeds efficiently
-func (pm *CLPriceMonitor) updateAllPrices() {
+// updateAllPrices updates all monitored price feeds efficiently. Reads still running when
+// the cycle's time budget runs out or ctx is cancelled are aborted.
+func (pm *CLPriceMonitor) updateAllPrices(ctx context.Context) {
 	pm.mu.RLock()
-	clients := make(map[uint64]*ethclient.Client)
-	for networkID, client := range pm.clients {
-		clients[networkID] = client
+	cycleTimeout := pm.cycleTimeout
+	if cycleTimeout == 0 {
+		cycleTimeout = pm.interval
 	}
+	clients := pm.clients.all()
+	snapshotMode := pm.snapshotMode
+	adaptive := pm.adaptiveScheduling
 	pm.mu.RUnlock()
 
+	ctx, cancel := context.WithTimeout(ctx, cycleTimeout)
+	defer cancel()
+
+	// Follow aggregator changes of feeds registered through the Feed Registry and behind proxies
+	pm.resolveRegistryFeedsIfDue(ctx)
+	pm.checkPhasesIfDue(ctx)
+
 	cache := pm.cacheManager.GetCache()
 	cache.mu.RLock()
 	feeds := make(map[uint64][]string)
@@ -153,6 +373,21 @@ func (pm *CLPriceMonitor) updateAllPrices() {
 	}
 	cache.mu.RUnlock()
 
+	// Check L2 sequencers on every tick, also on networks none of whose feeds are due, so that
+	// prices already cached for a network with an unhealthy sequencer are untrusted right away
+	trust := make(map[uint64]networkTrust)
+	for networkID, client := range clients {
+		trust[networkID] = pm.checkSequencer(ctx, networkID, client)
+		if trust[networkID].untrusted {
+			pm.markNetworkUntrusted(networkID, trust[networkID].reason)
+		}
+	}
+
+	// In adaptive mode only feeds whose next poll time has come are read
+	if adaptive {
+		feeds = pm.dueFeeds(feeds, time.Now())
+	}
+
 	var wg sync.WaitGroup
 	semaphore := make(chan struct{}, 10) // Limit concurrent requests
 
@@ -161,24 +396,55 @@ func (pm *CLPriceMonitor) updateAllPrices() {
 			continue // Skip if no client available
 		}
 
+		// In snapshot mode every feed of the network is read at the same block
+		var block *chainlink.BlockRef
+		if snapshotMode {
+			var err error
+			block, err = pm.pinSnapshotBlock(ctx, networkID, clients[networkID])
+			if err != nil {
+				log.Printf("Skipping snapshot for network %d: %v", networkID, err)
+				continue
+			}
+		}
+
 		for _, prefixedFeed := range feedList {
 			wg.Add(1)
-			go func(netID uint64, prefixed string) {
+			go func(netID uint64, prefixed string, block *chainlink.BlockRef) {
 				defer wg.Done()
 
-				// Acquire semaphore
-				semaphore <- struct{}{}
-				defer func() { <-semaphore }()
+				// Acquire semaphore, giving up when the cycle is over
+				select {
+				case semaphore <- struct{}{}:
+					defer func() { <-semaphore }()
+				case <-ctx.Done():
+					return
+				}
 
 				// Extract feed address from prefixed identifier (e.g., "chainlink:0xaddr" -> "0xaddr")
 				feedAddress := strings.TrimPrefix(prefixed, string(types.SourceChainlink)+":")
 
-				priceData, err := pm.fetchPriceData(netID, feedAddress)
+				priceData, err := pm.fetchPriceData(ctx, netID, feedAddress, block)
 				if err != nil {
 					log.Printf("Failed to fetch price data for feed %s on network %d: %v", feedAddress, netID, err)
+					pm.scheduler.ObserveError(netID, feedAddress, time.Now())
+					return
+				}
+				pm.scheduler.Observe(netID, feedAddress, priceData, time.Now())
+
+				// Reject incomplete, carried-over, non-positive and future-dated rounds
+				if !pm.validateRound(netID, feedAddress, priceData) {
 					return
 				}
 
+				// A round from a new phase means the proxy points to a new aggregator
+				pm.observeRoundPhase(ctx, netID, feedAddress, priceData.RoundID)
+
+				// A new round clears a missed heartbeat and moves the feed's deadline
+				pm.heartbeatWatcher.Observe(netID, feedAddress, priceData, time.Now())
+
+				priceData.Untrusted = trust[netID].untrusted
+				priceData.UntrustedReason = trust[netID].reason
+
 				pm.cacheManager.UpdatePrice(netID, feedAddress, types.SourceChainlink, priceData)
 
 				// Print immediately if in immediate mode
@@ -187,7 +453,7 @@ func (pm *CLPriceMonitor) updateAllPrices() {
 				} else {
 					log.Printf("Updated price for feed %s on network %d: %s", feedAddress, netID, priceData.Answer.String())
 				}
-			}(networkID, prefixedFeed)
+			}(networkID, prefixedFeed, block)
 		}
 	}
 
@@ -228,31 +494,41 @@ func (pm *CLPriceMonitor) printPriceUpdate(networkID uint64, feedAddress string,
 	fmt.Printf("   Updated At: %s\n", time.Unix(priceData.UpdatedAt.Int64(), 0).Format("15:04:05"))
 	fmt.Printf("   Answered In Round: %s\n", priceData.AnsweredInRound.String())
 	fmt.Printf("   Timestamp: %s\n", priceData.Timestamp.Format("15:04:05"))
+	if priceData.BlockNumber != nil {
+		fmt.Printf("   Block: %s (%s)\n", priceData.BlockNumber.String(), priceData.BlockHash)
+	}
+	if priceData.Untrusted {
+		fmt.Printf("   ⚠️  Untrusted: %s\n", priceData.UntrustedReason)
+	}
 	fmt.Println("   " + strings.Repeat("-", 50))
 }
 
-// Start begins monitoring price feeds
-func (pm *CLPriceMonitor) Start() {
-	log.Printf("Starting Chainlink price monitor with %v interval (immediate mode: %v)", pm.interval, pm.immediateMode)
+// Start begins monitoring price feeds until ctx is cancelled or Stop is called.
+// Cancelling ctx or calling Stop also aborts any RPC call in flight.
+func (pm *CLPriceMonitor) Start(ctx context.Context) {
+	ctx, cancel := stopContext(ctx, pm.stopChan)
+	defer cancel()
 
-	ticker := time.NewTicker(pm.interval)
-	defer ticker.Stop()
+	log.Printf("Starting Chainlink price monitor with %v interval (immediate mode: %v)", pm.interval, pm.immediateMode)
 
-	// Initial update
-	pm.updateAllPrices()
+	// Heartbeats are watched on their own timer, independently of the poll interval
+	go pm.heartbeatWatcher.Start(ctx)
 
-	for {
-		select {
-		case <-pm.stopChan:
-			log.Println("Stopping Chainlink price monitor")
-			return
-		case <-ticker.C:
-			pm.updateAllPrices()
-		}
+	// Verify feeds registered before their network had a client
+	validationErrors := pm.ValidateFeeds(ctx)
+	pm.mu.RLock()
+	refuse := pm.refuseStartOnMismatch
+	pm.mu.RUnlock()
+	if refuse && len(validationErrors) > 0 {
+		log.Printf("Refusing to start Chainlink price monitor: %d feed validation error(s)", len(validationErrors))
+		return
 	}
+
+	pollEvery(ctx, pm.tickInterval(), pm.updateAllPrices)
+	log.Println("Stopping Chainlink price monitor")
 }
 
-// Stop stops the price monitor
+// Stop stops the price monitor and cancels any RPC call in flight
 func (pm *CLPriceMonitor) Stop() {
 	close(pm.stopChan)
 }
@@ -267,11 +543,29 @@ func (pm *CLPriceMonitor) GetCache() *PriceCache {
 	return pm.cacheManager.GetCache()
 }
 
+// GetMetadataCache returns the aggregator metadata cache (e.g. to invalidate a feed after an upgrade)
+func (pm *CLPriceMonitor) GetMetadataCache() *chainlink.MetadataCache {
+	return pm.metadataCache
+}
+
 // SetNetworkConfig sets the network configuration for RPC switching
 func (pm *CLPriceMonitor) SetNetworkConfig(networkConfig *rpcscan.NetworkConfiguration) {
+	pm.clients.setNetworkConfig(networkConfig)
+}
+
+// SetCycleTimeout sets the time budget of one update cycle; reads still running when it
+// expires are cancelled. 0 uses the update interval.
+func (pm *CLPriceMonitor) SetCycleTimeout(timeout time.Duration) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.cycleTimeout = timeout
+}
+
+// SetRetryPolicy sets which error categories switch RPCs, back off or fail fast
+func (pm *CLPriceMonitor) SetRetryPolicy(policy chainlink.RetryPolicy) {
 	pm.mu.Lock()
 	defer pm.mu.Unlock()
-	pm.networkConfig = networkConfig
+	pm.retryPolicy = &policy
 }
 
 // SetImmediateMode sets whether to print prices immediately
@@ -284,9 +578,7 @@ func (pm *CLPriceMonitor) SetImmediateMode(immediate bool) {
 
 // PrintStatus prints the current cache status and monitored feeds
 func (pm *CLPriceMonitor) PrintStatus() {
-	pm.mu.RLock()
-	clientCount := len(pm.clients)
-	pm.mu.RUnlock()
+	clientCount := len(pm.clients.all())
 
 	cache := pm.cacheManager.GetCache()
 	cache.mu.RLock()
@@ -306,6 +598,23 @@ func (pm *CLPriceMonitor) PrintStatus() {
 	fmt.Printf("   Monitored Feeds: %d\n", feedCount)
 	fmt.Printf("   Immediate Mode: %v\n", pm.immediateMode)
 	fmt.Printf("   Update Interval: %v\n", pm.interval)
+	fmt.Printf("   Snapshot Mode: %v\n", pm.snapshotMode)
+	fmt.Printf("   Adaptive Scheduling: %v\n", pm.adaptiveScheduling)
+
+	// Show L2 sequencer status by network
+	for networkID, status := range pm.GetSequencerStatuses() {
+		if reason := status.UntrustedReason(time.Now()); reason != "" {
+			fmt.Printf("   Sequencer (network %d): UNTRUSTED - %s\n", networkID, reason)
+		} else {
+			fmt.Printf("   Sequencer (network %d): up since %s\n", networkID, status.StatusSince.Format(time.RFC3339))
+		}
+	}
+
+	// Show feeds past their heartbeat
+	for _, missed := range pm.GetMissedHeartbeats() {
+		fmt.Printf("   Heartbeat missed: %s (%s) on network %d, no new round since %s\n",
+			missed.Symbol, missed.FeedAddress, missed.NetworkID, missed.UpdatedAt.Format(time.RFC3339))
+	}
 
 	// Show feeds by network
 	for networkID, feeds := range feedsCopy {
@@ -347,31 +656,3 @@ func (pm *CLPriceMonitor) GetFeedSymbol(networkID uint64, feedAddress string) st
 	}
 	return "Unknown"
 }
-
-// rpcSwitcherAdapter adapts NetworkConfiguration to chainlink.RPCSwitcher interface
-type rpcSwitcherAdapter struct {
-	networkConfig *rpcscan.NetworkConfiguration
-	priceMonitor  *CLPriceMonitor
-	networkID     uint64
-}
-
-// SwitchRPCEndpointImmediately switches to a different RPC endpoint
-func (r *rpcSwitcherAdapter) SwitchRPCEndpointImmediately(networkID uint64) error {
-	return r.networkConfig.SwitchRPCEndpointImmediately(networkID)
-}
-
-// GetBestClient returns the best available client for the network
-func (r *rpcSwitcherAdapter) GetBestClient(networkID uint64) (*ethclient.Client, error) {
-	ethClient, err := r.networkConfig.GetBestClient(networkID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Get the underlying ethclient.Client
-	newClient := ethClient.GetClient()
-
-	// Update the price monitor's client map
-	r.priceMonitor.UpdateClient(networkID, newClient)
-
-	return newClient, nil
-}
@@ -1,8 +1,17 @@
 package pricefeed
 
 import (
+	"context"
+	"math/big"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/morpheum-labs/pricefeeding/chainlink"
+	"github.com/morpheum-labs/pricefeeding/chainlink/chainlinktest"
 )
 
 func TestCLPriceMonitorCreation(t *testing.T) {
@@ -99,3 +108,282 @@ func TestPrintStatus(t *testing.T) {
 	// This should not panic
 	monitor.PrintStatus()
 }
+
+func TestAddRegistryFeedRequiresRegistry(t *testing.T) {
+	monitor := NewCLPriceMonitor(NewPriceCacheManager(), 30*time.Second, false)
+
+	if err := monitor.AddRegistryFeed(42161, "ETH", "USD", "ETH/USD", 8, FeedSchedule{}); err == nil {
+		t.Error("Expected an error for a network without a feed registry")
+	}
+	if err := monitor.AddRegistryFeed(1, "DOGE", "USD", "DOGE/USD", 8, FeedSchedule{}); err == nil {
+		t.Error("Expected an error for an unknown base asset")
+	}
+
+	// Without a client the feed is accepted and resolved on the first cycle
+	if err := monitor.AddRegistryFeed(1, "ETH", "USD", "ETH/USD", 8, FeedSchedule{}); err != nil {
+		t.Fatalf("Expected registry feed to be added, got %v", err)
+	}
+	if _, err := monitor.GetRegistryFeedAddress(1, "ETH", "USD"); err == nil {
+		t.Error("Expected registry feed to be unresolved without a client")
+	}
+	if err := monitor.AddRegistryFeed(1, "eth", "usd", "ETH/USD", 8, FeedSchedule{}); err == nil {
+		t.Error("Expected an error for a duplicate registry feed")
+	}
+}
+
+func TestRemovePriceFeed(t *testing.T) {
+	monitor := NewCLPriceMonitor(NewPriceCacheManager(), 30*time.Second, false)
+	feedAddress := "0x639Fe6ab55C921f74e7fac1ee960C0B6293ba612"
+	monitor.AddPriceFeedWithSymbol(42161, feedAddress, "ETH/USD")
+	monitor.SetFeedSchedule(42161, feedAddress, time.Hour, 0.5)
+	if _, scheduled := monitor.GetNextPoll(42161, feedAddress); !scheduled {
+		t.Fatal("Expected the feed to be scheduled")
+	}
+
+	monitor.removePriceFeed(42161, feedAddress)
+
+	if symbol := monitor.GetFeedSymbol(42161, feedAddress); symbol != "Unknown" {
+		t.Errorf("Expected feed symbol to be removed, got %s", symbol)
+	}
+	if feeds := monitor.getFeedAddresses(42161); len(feeds) != 0 {
+		t.Errorf("Expected no monitored feeds, got %v", feeds)
+	}
+	if _, scheduled := monitor.GetNextPoll(42161, feedAddress); scheduled {
+		t.Error("Expected the feed's schedule to be removed")
+	}
+	if events := monitor.GetHeartbeatWatcher().Check(time.Now().Add(2 * time.Hour)); len(events) != 0 {
+		t.Errorf("Expected the feed's heartbeat watch to be removed, got %v", events)
+	}
+}
+
+func TestAggregatorForRoundUsesPhaseHistory(t *testing.T) {
+	monitor := NewCLPriceMonitor(NewPriceCacheManager(), 30*time.Second, false)
+	feedAddress := "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419"
+	monitor.phaseHistory[1] = map[string][]chainlink.PhaseInfo{
+		feedAddress: {
+			{NetworkID: 1, FeedAddress: feedAddress, PhaseID: 5, Aggregator: "0x37bC7498f4FF12C19678ee8fE19d713b87F6a9e6"},
+			{NetworkID: 1, FeedAddress: feedAddress, PhaseID: 6, Aggregator: "0xE62B71cf983019BFf55bC83B48601ce8419650CC"},
+		},
+	}
+
+	phase, err := monitor.GetFeedPhase(1, feedAddress)
+	if err != nil || phase.PhaseID != 6 {
+		t.Fatalf("Expected current phase 6, got %+v (%v)", phase, err)
+	}
+
+	aggregator, err := monitor.AggregatorForRound(context.Background(), 1, feedAddress, chainlink.ProxyRoundID(5, 100))
+	if err != nil || aggregator != "0x37bC7498f4FF12C19678ee8fE19d713b87F6a9e6" {
+		t.Errorf("Expected phase 5 aggregator, got %s (%v)", aggregator, err)
+	}
+
+	// Unknown phases are read from the proxy, which needs a client
+	if _, err := monitor.AggregatorForRound(context.Background(), 1, feedAddress, chainlink.ProxyRoundID(4, 1)); err == nil {
+		t.Error("Expected an error for an unknown phase without a client")
+	}
+
+	history := monitor.GetPhaseHistory(1, feedAddress)
+	history[0].Aggregator = "modified"
+	if monitor.GetPhaseHistory(1, feedAddress)[0].Aggregator == "modified" {
+		t.Error("Expected GetPhaseHistory to return a copy")
+	}
+}
+
+// countingBackend counts the contract calls made through a backend
+type countingBackend struct {
+	bind.ContractBackend
+	calls atomic.Int64
+}
+
+func (b *countingBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	b.calls.Add(1)
+	return b.ContractBackend.CallContract(ctx, call, blockNumber)
+}
+
+func TestObserveRoundPhaseBacksOffWhenNotProxy(t *testing.T) {
+	chain, err := chainlinktest.NewChain()
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer chain.Close()
+
+	monitor := NewCLPriceMonitor(NewPriceCacheManager(), 30*time.Second, false)
+	networkID := uint64(chainlinktest.SimulatedChainID)
+	backend := &countingBackend{ContractBackend: chain.Client}
+	monitor.AddClient(networkID, backend)
+
+	// An address without code reverts phaseId(), so it is not a proxy
+	feedAddress := "0x000000000000000000000000000000000000dEaD"
+	ctx := context.Background()
+	monitor.observeRoundPhase(ctx, networkID, feedAddress, chainlink.ProxyRoundID(1, 1))
+	calls := backend.calls.Load()
+	if calls == 0 {
+		t.Fatal("Expected the first poll to read the proxy phase")
+	}
+
+	failure := monitor.phaseCheckFailures[networkID][feedAddress]
+	if failure == nil || !failure.notProxy {
+		t.Fatalf("Expected the feed to be remembered as not a proxy, got %+v", failure)
+	}
+
+	// Further polls do not re-read the phase until the retry time
+	for i := 0; i < 3; i++ {
+		monitor.observeRoundPhase(ctx, networkID, feedAddress, chainlink.ProxyRoundID(1, uint64(i+2)))
+	}
+	if got := backend.calls.Load(); got != calls {
+		t.Errorf("Expected no phase reads while backing off, got %d more calls", got-calls)
+	}
+
+	failure.retryAt = time.Now().Add(-time.Second)
+	monitor.observeRoundPhase(ctx, networkID, feedAddress, chainlink.ProxyRoundID(1, 5))
+	if backend.calls.Load() == calls {
+		t.Error("Expected the phase to be re-read once the retry time passed")
+	}
+}
+
+func TestMonitorReadsSimulatedFeed(t *testing.T) {
+	chain, err := chainlinktest.NewChain()
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer chain.Close()
+
+	mock, err := chain.DeployAggregator(8, "ETH / USD")
+	if err != nil {
+		t.Fatalf("Failed to deploy mock aggregator: %v", err)
+	}
+	if _, err := mock.PushRound(big.NewInt(250000000000), time.Now()); err != nil {
+		t.Fatalf("PushRound failed: %v", err)
+	}
+
+	monitor := NewCLPriceMonitor(NewPriceCacheManager(), 30*time.Second, false)
+	networkID := uint64(chainlinktest.SimulatedChainID)
+	feedAddress := mock.Address.Hex()
+	monitor.AddClient(networkID, chain.Client)
+	monitor.AddPriceFeedWithDecimals(networkID, feedAddress, "ETH/USD", 8)
+
+	if errs := monitor.GetFeedValidationErrors(); len(errs) != 0 {
+		t.Fatalf("Expected the mock feed to verify, got %v", errs)
+	}
+
+	monitor.updateAllPrices(context.Background())
+	price, err := monitor.GetPrice(networkID, feedAddress)
+	if err != nil {
+		t.Fatalf("Expected a price after the first cycle: %v", err)
+	}
+	if price.Answer.Int64() != 250000000000 || price.Exponent != -8 {
+		t.Errorf("Unexpected price %+v", price)
+	}
+
+	roundID, err := mock.PushRound(big.NewInt(251000000000), time.Now())
+	if err != nil {
+		t.Fatalf("PushRound failed: %v", err)
+	}
+	monitor.updateAllPrices(context.Background())
+	price, err = monitor.GetPrice(networkID, feedAddress)
+	if err != nil || price.RoundID.Cmp(roundID) != 0 || price.Answer.Int64() != 251000000000 {
+		t.Errorf("Expected the pushed round %s, got %+v (%v)", roundID, price, err)
+	}
+
+	phase, err := monitor.GetFeedPhase(networkID, feedAddress)
+	if err != nil || phase.PhaseID != chainlinktest.MockPhaseID {
+		t.Errorf("Expected phase %d to be recorded, got %+v (%v)", chainlinktest.MockPhaseID, phase, err)
+	}
+}
+
+func TestAdaptiveMonitorChecksSequencerWhileFeedsBackOff(t *testing.T) {
+	chain, err := chainlinktest.NewChain()
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer chain.Close()
+
+	mock, err := chain.DeployAggregator(8, "ETH / USD")
+	if err != nil {
+		t.Fatalf("Failed to deploy mock aggregator: %v", err)
+	}
+	if _, err := mock.PushRound(big.NewInt(250000000000), time.Now()); err != nil {
+		t.Fatalf("PushRound failed: %v", err)
+	}
+
+	// The uptime feed answers 0 while the sequencer is up, here since well past the grace period
+	uptimeFeed, err := chain.DeployAggregator(0, "L2 Sequencer Uptime Status Feed")
+	if err != nil {
+		t.Fatalf("Failed to deploy uptime feed: %v", err)
+	}
+	if _, err := uptimeFeed.PushRound(big.NewInt(0), time.Now().Add(-2*time.Hour)); err != nil {
+		t.Fatalf("PushRound failed: %v", err)
+	}
+
+	monitor := NewCLPriceMonitor(NewPriceCacheManager(), 30*time.Second, false)
+	networkID := uint64(chainlinktest.SimulatedChainID)
+	feedAddress := mock.Address.Hex()
+	monitor.AddClient(networkID, chain.Client)
+	monitor.AddPriceFeedWithDecimals(networkID, feedAddress, "ETH/USD", 8)
+	monitor.SetSequencerUptimeFeed(networkID, uptimeFeed.Address.Hex())
+	monitor.SetAdaptiveScheduling(true)
+	monitor.SetFeedSchedule(networkID, feedAddress, time.Hour, 1)
+
+	monitor.updateAllPrices(context.Background())
+	price, err := monitor.GetPrice(networkID, feedAddress)
+	if err != nil || price.Untrusted {
+		t.Fatalf("Expected a trusted price after the first cycle, got %+v (%v)", price, err)
+	}
+
+	// Back the feed off as if its round had not changed for many polls
+	monitor.scheduler.mu.Lock()
+	monitor.scheduler.feeds[feedScheduleKey{networkID, feedAddress}].nextPoll = time.Now().Add(30 * time.Minute)
+	monitor.scheduler.mu.Unlock()
+	if len(monitor.dueFeeds(map[uint64][]string{networkID: {"chainlink:" + feedAddress}}, time.Now())) != 0 {
+		t.Fatal("Expected no feed to be due")
+	}
+
+	// The sequencer goes down while no feed is due
+	if _, err := uptimeFeed.PushRound(big.NewInt(1), time.Now()); err != nil {
+		t.Fatalf("PushRound failed: %v", err)
+	}
+	monitor.updateAllPrices(context.Background())
+
+	if monitor.IsNetworkTrusted(networkID) {
+		t.Error("Expected the network to be untrusted while its sequencer is down")
+	}
+	price, err = monitor.GetPrice(networkID, feedAddress)
+	if err != nil || !price.Untrusted || price.UntrustedReason == "" {
+		t.Errorf("Expected the cached price to be marked untrusted, got %+v (%v)", price, err)
+	}
+}
+
+func TestTransmissionReportSimulated(t *testing.T) {
+	chain, err := chainlinktest.NewChain()
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer chain.Close()
+
+	mock, err := chain.DeployAggregator(8, "ETH / USD")
+	if err != nil {
+		t.Fatalf("Failed to deploy mock aggregator: %v", err)
+	}
+
+	monitor := NewCLPriceMonitor(NewPriceCacheManager(), 30*time.Second, false)
+	networkID := uint64(chainlinktest.SimulatedChainID)
+	if _, err := monitor.TransmissionReport(context.Background(), networkID, mock.Address.Hex(), 100); err == nil {
+		t.Error("Expected an error without a client")
+	}
+
+	monitor.AddClient(networkID, chain.Client)
+	// Scanning from genesis or past the cap is rejected before any RPC call
+	for _, blocks := range []uint64{0, MaxTransmissionReportBlocks + 1} {
+		if _, err := monitor.TransmissionReport(context.Background(), networkID, mock.Address.Hex(), blocks); err == nil {
+			t.Errorf("Expected an error for a range of %d blocks", blocks)
+		}
+	}
+
+	report, err := monitor.TransmissionReport(context.Background(), networkID, mock.Address.Hex(), 100)
+	if err != nil {
+		t.Fatalf("TransmissionReport failed: %v", err)
+	}
+	// The mock proxy points at itself and emits no transmissions
+	if report.Aggregator != mock.Address.Hex() || len(report.Rounds) != 0 || report.FromBlock != 0 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+}
@@ -0,0 +1,76 @@
+package pricefeed
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/morpheum-labs/pricefeeding/chainlink"
+)
+
+// MaxTransmissionReportBlocks caps the block range of a TransmissionReport, 50 eth_getLogs chunks
+const MaxTransmissionReportBlocks = 100000
+
+// TransmissionReport analyzes the OCR NewTransmission events of a feed's current aggregator over
+// the last blocks (1 to MaxTransmissionReportBlocks): per-round observation spread, per-oracle deviation
+// from the median and participation
+func (pm *CLPriceMonitor) TransmissionReport(ctx context.Context, networkID uint64, feedAddress string, blocks uint64) (*chainlink.TransmissionReport, error) {
+	if blocks == 0 || blocks > MaxTransmissionReportBlocks {
+		return nil, fmt.Errorf("block range must be between 1 and %d, got %d", MaxTransmissionReportBlocks, blocks)
+	}
+
+	client, exists := pm.clients.get(networkID)
+	if !exists {
+		return nil, fmt.Errorf("no client available for network %d", networkID)
+	}
+
+	aggregator, err := pm.currentAggregator(ctx, networkID, feedAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := chainlink.FetchBlockRef(ctx, client, nil)
+	if err != nil {
+		return nil, err
+	}
+	toBlock := head.Number.Uint64()
+	fromBlock := uint64(0)
+	if toBlock >= blocks {
+		fromBlock = toBlock - blocks + 1
+	}
+
+	transmissions, err := chainlink.FetchTransmissions(ctx, client, aggregator, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without the transmitter list oracles are still reported by index
+	transmitters, err := chainlink.FetchTransmitters(ctx, client, aggregator)
+	if err != nil {
+		log.Printf("Transmitters of aggregator %s on network %d unavailable: %v", aggregator, networkID, err)
+	}
+
+	return chainlink.AnalyzeTransmissions(aggregator, fromBlock, toBlock, transmissions, transmitters), nil
+}
+
+// currentAggregator returns the aggregator behind a monitored feed: registry feeds are aggregators
+// already, proxies are resolved from the known phase or read from the proxy
+func (pm *CLPriceMonitor) currentAggregator(ctx context.Context, networkID uint64, feedAddress string) (string, error) {
+	if pm.isRegistryAggregator(networkID, feedAddress) {
+		return feedAddress, nil
+	}
+	if phase, err := pm.GetFeedPhase(networkID, feedAddress); err == nil {
+		return phase.Aggregator, nil
+	}
+
+	client, exists := pm.clients.get(networkID)
+	if !exists {
+		return "", fmt.Errorf("no client available for network %d", networkID)
+	}
+
+	aggregator, err := chainlink.FetchProxyAggregator(ctx, client, feedAddress)
+	if err != nil {
+		return "", err
+	}
+	return aggregator.Hex(), nil
+}
@@ -0,0 +1,246 @@
+package pricefeed
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/morpheum-labs/pricefeeding/chainlink"
+)
+
+// defaultPhaseCheckInterval is how often every proxy's aggregator() and phaseId() are re-read
+const defaultPhaseCheckInterval = time.Hour
+
+// phaseRetryBackoff is how long polls wait before re-reading a phase after the first failed read;
+// it doubles with every further failure, up to the phase check interval
+const phaseRetryBackoff = time.Minute
+
+// phaseCheckFailure remembers a failed phase read so polls do not re-read the proxy every cycle
+type phaseCheckFailure struct {
+	notProxy bool      // phaseId() or aggregator() reverted: the feed address is not a proxy
+	failures int       // Consecutive failed reads
+	retryAt  time.Time // Polls do not re-read the phase before this
+}
+
+// AggregatorChangeEvent is emitted when a feed proxy is pointed at a new aggregator
+type AggregatorChangeEvent struct {
+	NetworkID   uint64
+	FeedAddress string
+	Previous    chainlink.PhaseInfo
+	Current     chainlink.PhaseInfo
+}
+
+// AggregatorChangeHandler is called for every AggregatorChangeEvent
+type AggregatorChangeHandler func(event AggregatorChangeEvent)
+
+// OnAggregatorChange registers a handler called when a proxy's aggregator or phase changes
+func (pm *CLPriceMonitor) OnAggregatorChange(handler AggregatorChangeHandler) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.aggregatorChangeHandlers = append(pm.aggregatorChangeHandlers, handler)
+}
+
+// SetPhaseCheckInterval sets how often every proxy's aggregator and phase are re-read (default 1h).
+// Phase changes are also detected from the round IDs returned by each poll.
+func (pm *CLPriceMonitor) SetPhaseCheckInterval(interval time.Duration) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.phaseCheckInterval = interval
+}
+
+// GetFeedPhase returns the current phase and aggregator of a feed proxy
+func (pm *CLPriceMonitor) GetFeedPhase(networkID uint64, feedAddress string) (*chainlink.PhaseInfo, error) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	history := pm.phaseHistory[networkID][feedAddress]
+	if len(history) == 0 {
+		return nil, fmt.Errorf("phase of feed %s on network %d not known yet", feedAddress, networkID)
+	}
+	phase := history[len(history)-1]
+	return &phase, nil
+}
+
+// GetPhaseHistory returns every phase observed for a feed proxy, oldest first
+func (pm *CLPriceMonitor) GetPhaseHistory(networkID uint64, feedAddress string) []chainlink.PhaseInfo {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	history := pm.phaseHistory[networkID][feedAddress]
+	result := make([]chainlink.PhaseInfo, len(history))
+	copy(result, history)
+	return result
+}
+
+// AggregatorForRound returns the aggregator that produced a proxy round, so backfills and event
+// subscriptions read the right contract. Phases not seen by the monitor are read from the proxy.
+func (pm *CLPriceMonitor) AggregatorForRound(ctx context.Context, networkID uint64, feedAddress string, roundID *big.Int) (string, error) {
+	phaseID := chainlink.PhaseIDFromRoundID(roundID)
+
+	pm.mu.RLock()
+	for _, phase := range pm.phaseHistory[networkID][feedAddress] {
+		if phase.PhaseID == phaseID {
+			pm.mu.RUnlock()
+			return phase.Aggregator, nil
+		}
+	}
+	pm.mu.RUnlock()
+	client, exists := pm.clients.get(networkID)
+	if !exists {
+		return "", fmt.Errorf("no client available for network %d", networkID)
+	}
+
+	return chainlink.FetchPhaseAggregator(ctx, client, feedAddress, phaseID)
+}
+
+// CheckFeedPhases re-reads the aggregator and phase of every monitored proxy
+func (pm *CLPriceMonitor) CheckFeedPhases(ctx context.Context) {
+	pm.mu.Lock()
+	pm.lastPhaseCheck = time.Now()
+	pm.mu.Unlock()
+	clients := pm.clients.all()
+
+	for networkID, client := range clients {
+		for _, feedAddress := range pm.getFeedAddresses(networkID) {
+			if err := pm.checkFeedPhase(ctx, networkID, client, feedAddress); err != nil {
+				log.Printf("Failed to check phase of feed %s on network %d: %v", feedAddress, networkID, err)
+			}
+		}
+	}
+}
+
+// checkPhasesIfDue runs CheckFeedPhases once the phase check interval has passed
+func (pm *CLPriceMonitor) checkPhasesIfDue(ctx context.Context) {
+	pm.mu.RLock()
+	due := !pm.lastPhaseCheck.IsZero() && time.Since(pm.lastPhaseCheck) >= pm.phaseCheckInterval
+	pm.mu.RUnlock()
+
+	if due {
+		pm.CheckFeedPhases(ctx)
+	}
+}
+
+// observeRoundPhase compares the phase encoded in a polled round ID with the known phase of the
+// proxy, and re-reads the proxy when they differ or the phase is not known yet
+func (pm *CLPriceMonitor) observeRoundPhase(ctx context.Context, networkID uint64, feedAddress string, roundID *big.Int) {
+	pm.mu.Lock()
+	if pm.lastPhaseCheck.IsZero() {
+		pm.lastPhaseCheck = time.Now()
+	}
+	history := pm.phaseHistory[networkID][feedAddress]
+	failure := pm.phaseCheckFailures[networkID][feedAddress]
+	client, hasClient := pm.clients.get(networkID)
+	pm.mu.Unlock()
+
+	if len(history) > 0 && history[len(history)-1].PhaseID == chainlink.PhaseIDFromRoundID(roundID) {
+		return
+	}
+	if !hasClient || pm.isRegistryAggregator(networkID, feedAddress) {
+		return
+	}
+	if failure != nil && time.Now().Before(failure.retryAt) {
+		return // Backing off after a failed read; CheckFeedPhases still retries on its interval
+	}
+
+	if err := pm.checkFeedPhase(ctx, networkID, client, feedAddress); err != nil {
+		log.Printf("Failed to check phase of feed %s on network %d: %v", feedAddress, networkID, err)
+	}
+}
+
+// checkFeedPhase reads the aggregator and phase of a proxy and records them. On a change the
+// cached metadata of the feed is invalidated, the feed re-verified and an event emitted.
+func (pm *CLPriceMonitor) checkFeedPhase(ctx context.Context, networkID uint64, client bind.ContractBackend, feedAddress string) error {
+	if pm.isRegistryAggregator(networkID, feedAddress) {
+		return nil // Registry feeds are read from the aggregator directly and followed by re-resolution
+	}
+
+	current, err := chainlink.FetchProxyPhase(ctx, client, networkID, feedAddress)
+	if err != nil {
+		pm.recordPhaseCheckFailure(networkID, feedAddress, err)
+		return err
+	}
+
+	pm.mu.Lock()
+	delete(pm.phaseCheckFailures[networkID], feedAddress)
+	if pm.phaseHistory[networkID] == nil {
+		pm.phaseHistory[networkID] = make(map[string][]chainlink.PhaseInfo)
+	}
+	history := pm.phaseHistory[networkID][feedAddress]
+	if len(history) > 0 {
+		previous := history[len(history)-1]
+		if previous.PhaseID == current.PhaseID && previous.Aggregator == current.Aggregator {
+			pm.mu.Unlock()
+			return nil
+		}
+	}
+	pm.phaseHistory[networkID][feedAddress] = append(history, *current)
+	handlers := make([]AggregatorChangeHandler, len(pm.aggregatorChangeHandlers))
+	copy(handlers, pm.aggregatorChangeHandlers)
+	pm.mu.Unlock()
+
+	if len(history) == 0 {
+		log.Printf("Feed %s on network %d is in phase %d (aggregator %s)", feedAddress, networkID, current.PhaseID, current.Aggregator)
+		return nil
+	}
+
+	previous := history[len(history)-1]
+	log.Printf("Aggregator of feed %s on network %d changed: phase %d (%s) -> phase %d (%s)",
+		feedAddress, networkID, previous.PhaseID, previous.Aggregator, current.PhaseID, current.Aggregator)
+
+	// A new aggregator may report different decimals or description
+	pm.metadataCache.Invalidate(networkID, feedAddress)
+	pm.verifyFeed(ctx, networkID, feedAddress)
+
+	event := AggregatorChangeEvent{NetworkID: networkID, FeedAddress: feedAddress, Previous: previous, Current: *current}
+	for _, handler := range handlers {
+		handler(event)
+	}
+	return nil
+}
+
+// recordPhaseCheckFailure backs off re-reading the phase of a feed after a failed read. An address
+// that reverts is not a proxy and is only re-read on the phase check interval; other failures
+// back off exponentially from phaseRetryBackoff.
+func (pm *CLPriceMonitor) recordPhaseCheckFailure(networkID uint64, feedAddress string, err error) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.phaseCheckFailures[networkID] == nil {
+		pm.phaseCheckFailures[networkID] = make(map[string]*phaseCheckFailure)
+	}
+	failure, exists := pm.phaseCheckFailures[networkID][feedAddress]
+	if !exists {
+		failure = &phaseCheckFailure{}
+		pm.phaseCheckFailures[networkID][feedAddress] = failure
+	}
+	failure.failures++
+	failure.notProxy = chainlink.ClassifyError(err) == chainlink.ErrorRevert
+
+	backoff := pm.phaseCheckInterval
+	if !failure.notProxy && failure.failures < 32 {
+		if exponential := phaseRetryBackoff << (failure.failures - 1); exponential < backoff {
+			backoff = exponential
+		}
+	}
+	if failure.notProxy && failure.failures == 1 {
+		log.Printf("Feed %s on network %d is not a proxy, re-reading its phase every %v", feedAddress, networkID, pm.phaseCheckInterval)
+	}
+	failure.retryAt = time.Now().Add(backoff)
+}
+
+// isRegistryAggregator reports whether a monitored feed is an aggregator resolved through the Feed Registry
+func (pm *CLPriceMonitor) isRegistryAggregator(networkID uint64, feedAddress string) bool {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	for _, feed := range pm.registryFeeds[networkID] {
+		if feed.aggregator == feedAddress {
+			return true
+		}
+	}
+	return false
+}
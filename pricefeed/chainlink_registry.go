@@ -0,0 +1,204 @@
+package pricefeed
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/morpheum-labs/pricefeeding/chainlink"
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// defaultRegistryResolveInterval is how often feeds registered by (base, quote) are re-resolved
+const defaultRegistryResolveInterval = time.Hour
+
+// registryFeed is a feed registered by (base, quote) and resolved through the Feed Registry
+type registryFeed struct {
+	base       common.Address
+	quote      common.Address
+	symbol     string
+	decimals   int
+	schedule   FeedSchedule // Applied to every aggregator the feed resolves to
+	aggregator string       // Currently resolved aggregator, "" until first resolved
+}
+
+// AddRegistryFeed registers a feed by its Feed Registry (base, quote) pair instead of a proxy
+// address. base and quote are denomination names (e.g. "ETH", "USD") or token addresses.
+// The feed is read from the aggregator the registry resolves, and re-resolved periodically.
+// schedule is set with SetFeedSchedule on every aggregator the feed resolves to.
+func (pm *CLPriceMonitor) AddRegistryFeed(networkID uint64, base, quote, symbol string, decimals int, schedule FeedSchedule) error {
+	if _, exists := chainlink.FeedRegistryAddresses[networkID]; !exists {
+		return fmt.Errorf("no feed registry on network %d", networkID)
+	}
+	baseAddress, err := chainlink.ParseRegistryAsset(base)
+	if err != nil {
+		return err
+	}
+	quoteAddress, err := chainlink.ParseRegistryAsset(quote)
+	if err != nil {
+		return err
+	}
+
+	feed := &registryFeed{base: baseAddress, quote: quoteAddress, symbol: symbol, decimals: decimals, schedule: schedule}
+	key := registryFeedKey(baseAddress, quoteAddress)
+
+	pm.mu.Lock()
+	if pm.registryFeeds[networkID] == nil {
+		pm.registryFeeds[networkID] = make(map[string]*registryFeed)
+	}
+	if _, exists := pm.registryFeeds[networkID][key]; exists {
+		pm.mu.Unlock()
+		return fmt.Errorf("registry feed %s already registered on network %d", symbol, networkID)
+	}
+	pm.registryFeeds[networkID][key] = feed
+	client, hasClient := pm.clients.get(networkID)
+	pm.mu.Unlock()
+
+	log.Printf("Added Chainlink registry feed: %s (%s/%s) for network %d", symbol, base, quote, networkID)
+
+	// Without a client the feed is resolved on the first update cycle
+	if hasClient {
+		ctx, cancel := context.WithTimeout(context.Background(), registrationVerifyTimeout)
+		defer cancel()
+		return pm.resolveRegistryFeed(ctx, networkID, client, feed)
+	}
+	return nil
+}
+
+// SetRegistryResolveInterval sets how often feeds registered by (base, quote) are re-resolved (default 1h)
+func (pm *CLPriceMonitor) SetRegistryResolveInterval(interval time.Duration) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.registryResolveInterval = interval
+}
+
+// GetRegistryFeedAddress returns the aggregator a registry feed currently resolves to
+func (pm *CLPriceMonitor) GetRegistryFeedAddress(networkID uint64, base, quote string) (string, error) {
+	baseAddress, err := chainlink.ParseRegistryAsset(base)
+	if err != nil {
+		return "", err
+	}
+	quoteAddress, err := chainlink.ParseRegistryAsset(quote)
+	if err != nil {
+		return "", err
+	}
+
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	feed, exists := pm.registryFeeds[networkID][registryFeedKey(baseAddress, quoteAddress)]
+	if !exists || feed.aggregator == "" {
+		return "", fmt.Errorf("registry feed %s/%s not resolved on network %d", base, quote, networkID)
+	}
+	return feed.aggregator, nil
+}
+
+// ResolveRegistryFeeds re-resolves every registry feed and moves feeds whose aggregator changed.
+// It returns the feeds that could not be resolved.
+func (pm *CLPriceMonitor) ResolveRegistryFeeds(ctx context.Context) []error {
+	type pending struct {
+		networkID uint64
+		client    bind.ContractBackend
+		feed      *registryFeed
+	}
+
+	pm.mu.Lock()
+	var feeds []pending
+	for networkID, networkFeeds := range pm.registryFeeds {
+		client, exists := pm.clients.get(networkID)
+		if !exists {
+			continue
+		}
+		for _, feed := range networkFeeds {
+			feeds = append(feeds, pending{networkID: networkID, client: client, feed: feed})
+		}
+	}
+	pm.lastRegistryResolve = time.Now()
+	pm.mu.Unlock()
+
+	var errs []error
+	for _, p := range feeds {
+		if err := pm.resolveRegistryFeed(ctx, p.networkID, p.client, p.feed); err != nil {
+			log.Printf("Failed to resolve registry feed %s on network %d: %v", p.feed.symbol, p.networkID, err)
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// RegistryDiffReport compares the proxies of configured feeds with the aggregators the Feed Registry
+// resolves for their (base, quote) pairs
+func (pm *CLPriceMonitor) RegistryDiffReport(ctx context.Context, networkID uint64, feeds []chainlink.RegistryFeedRef) ([]chainlink.RegistryDiff, error) {
+	registryAddress, exists := chainlink.FeedRegistryAddresses[networkID]
+	if !exists {
+		return nil, fmt.Errorf("no feed registry on network %d", networkID)
+	}
+
+	client, exists := pm.clients.get(networkID)
+	if !exists {
+		return nil, fmt.Errorf("no client available for network %d", networkID)
+	}
+
+	return chainlink.DiffRegistryFeeds(ctx, client, registryAddress, feeds), nil
+}
+
+// resolveRegistryFeedsIfDue re-resolves registry feeds once the resolve interval has passed
+func (pm *CLPriceMonitor) resolveRegistryFeedsIfDue(ctx context.Context) {
+	pm.mu.RLock()
+	due := len(pm.registryFeeds) > 0 && time.Since(pm.lastRegistryResolve) >= pm.registryResolveInterval
+	pm.mu.RUnlock()
+
+	if due {
+		pm.ResolveRegistryFeeds(ctx)
+	}
+}
+
+// resolveRegistryFeed resolves a registry feed and, when its aggregator changed, moves the
+// monitored feed from the old aggregator to the new one
+func (pm *CLPriceMonitor) resolveRegistryFeed(ctx context.Context, networkID uint64, client bind.ContractBackend, feed *registryFeed) error {
+	aggregator, err := chainlink.ResolveRegistryFeed(ctx, client, chainlink.FeedRegistryAddresses[networkID], feed.base, feed.quote)
+	if err != nil {
+		return err
+	}
+
+	pm.mu.Lock()
+	previous := feed.aggregator
+	feed.aggregator = aggregator.Hex()
+	pm.mu.Unlock()
+
+	if previous == aggregator.Hex() {
+		return nil
+	}
+	if previous != "" {
+		log.Printf("Registry feed %s on network %d moved from aggregator %s to %s", feed.symbol, networkID, previous, aggregator.Hex())
+		pm.removePriceFeed(networkID, previous)
+	}
+	pm.AddPriceFeedWithDecimals(networkID, aggregator.Hex(), feed.symbol, feed.decimals)
+	pm.SetFeedSchedule(networkID, aggregator.Hex(), feed.schedule.Heartbeat, feed.schedule.ThresholdPercent)
+	return nil
+}
+
+// removePriceFeed stops monitoring a feed and drops its cached price, metadata and schedule
+func (pm *CLPriceMonitor) removePriceFeed(networkID uint64, feedAddress string) {
+	pm.cacheManager.RemoveFeed(networkID, feedAddress, types.SourceChainlink)
+	pm.metadataCache.Invalidate(networkID, feedAddress)
+	pm.heartbeatWatcher.Unwatch(networkID, feedAddress)
+	pm.scheduler.Remove(networkID, feedAddress)
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	delete(pm.feedSymbols[networkID], feedAddress)
+	delete(pm.feedRegistrations[networkID], feedAddress)
+	delete(pm.roundStats[networkID], feedAddress)
+	delete(pm.phaseHistory[networkID], feedAddress)
+	delete(pm.phaseCheckFailures[networkID], feedAddress)
+}
+
+// registryFeedKey identifies a registry feed by its (base, quote) pair
+func registryFeedKey(base, quote common.Address) string {
+	return base.Hex() + "/" + quote.Hex()
+}
@@ -0,0 +1,101 @@
+package pricefeed
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/morpheum-labs/pricefeeding/chainlink"
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// RoundValidationStats counts round sanity check results for a feed
+type RoundValidationStats struct {
+	Checked   uint64                                   // Rounds checked
+	Rejected  uint64                                   // Rounds rejected by at least one check
+	Failures  map[chainlink.RoundValidationKind]uint64 // Failures by kind (a round can fail several checks)
+	LastError *chainlink.RoundValidationError          // Most recent failure
+}
+
+// copy returns a snapshot of the stats that is safe to hand out
+func (s *RoundValidationStats) copy() *RoundValidationStats {
+	failures := make(map[chainlink.RoundValidationKind]uint64, len(s.Failures))
+	for kind, count := range s.Failures {
+		failures[kind] = count
+	}
+	return &RoundValidationStats{
+		Checked:   s.Checked,
+		Rejected:  s.Rejected,
+		Failures:  failures,
+		LastError: s.LastError,
+	}
+}
+
+// SetMaxClockSkew sets how far in the future a round's updatedAt may be before it is rejected
+func (pm *CLPriceMonitor) SetMaxClockSkew(maxClockSkew time.Duration) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.maxClockSkew = maxClockSkew
+}
+
+// GetRoundValidationStats returns the round validation counters of a feed
+func (pm *CLPriceMonitor) GetRoundValidationStats(networkID uint64, feedAddress string) (*RoundValidationStats, error) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	stats, exists := pm.roundStats[networkID][feedAddress]
+	if !exists {
+		return nil, fmt.Errorf("no round validation stats for feed %s on network %d", feedAddress, networkID)
+	}
+	return stats.copy(), nil
+}
+
+// GetAllRoundValidationStats returns the round validation counters of every checked feed
+func (pm *CLPriceMonitor) GetAllRoundValidationStats() map[uint64]map[string]*RoundValidationStats {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	result := make(map[uint64]map[string]*RoundValidationStats, len(pm.roundStats))
+	for networkID, feeds := range pm.roundStats {
+		result[networkID] = make(map[string]*RoundValidationStats, len(feeds))
+		for feedAddress, stats := range feeds {
+			result[networkID][feedAddress] = stats.copy()
+		}
+	}
+	return result
+}
+
+// validateRound runs the round sanity checks on a fetched price, records the result and
+// reports whether the price may be stored
+func (pm *CLPriceMonitor) validateRound(networkID uint64, feedAddress string, priceData *types.ChainlinkPrice) bool {
+	pm.mu.RLock()
+	maxClockSkew := pm.maxClockSkew
+	pm.mu.RUnlock()
+
+	validationErrors := chainlink.ValidateRound(priceData, time.Now(), maxClockSkew)
+
+	pm.mu.Lock()
+	if pm.roundStats[networkID] == nil {
+		pm.roundStats[networkID] = make(map[string]*RoundValidationStats)
+	}
+	stats, exists := pm.roundStats[networkID][feedAddress]
+	if !exists {
+		stats = &RoundValidationStats{Failures: make(map[chainlink.RoundValidationKind]uint64)}
+		pm.roundStats[networkID][feedAddress] = stats
+	}
+	stats.Checked++
+	if len(validationErrors) > 0 {
+		stats.Rejected++
+		for _, validationErr := range validationErrors {
+			stats.Failures[validationErr.Kind]++
+		}
+		stats.LastError = validationErrors[len(validationErrors)-1]
+	}
+	pm.mu.Unlock()
+
+	for _, validationErr := range validationErrors {
+		log.Printf("Rejected Chainlink round: %v", validationErr)
+	}
+
+	return len(validationErrors) == 0
+}
@@ -0,0 +1,61 @@
+package pricefeed
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// SetAdaptiveScheduling sets whether feeds are polled on their own heartbeat/deviation schedule
+// instead of all together on every interval tick. Must be called before Start.
+func (pm *CLPriceMonitor) SetAdaptiveScheduling(enabled bool) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.adaptiveScheduling = enabled
+	log.Printf("Chainlink price monitor adaptive scheduling set to: %v", enabled)
+}
+
+// SetFeedSchedule sets the heartbeat and deviation threshold (in percent) of a feed, as published
+// for the feed by Chainlink. Used to schedule its polls in adaptive mode and, when heartbeat is
+// set, to raise heartbeat-missed events.
+func (pm *CLPriceMonitor) SetFeedSchedule(networkID uint64, feedAddress string, heartbeat time.Duration, thresholdPercent float64) {
+	pm.scheduler.SetSchedule(networkID, feedAddress, FeedSchedule{
+		Heartbeat:        heartbeat,
+		ThresholdPercent: thresholdPercent,
+	})
+	pm.heartbeatWatcher.Watch(networkID, feedAddress, pm.GetFeedSymbol(networkID, feedAddress), heartbeat)
+}
+
+// GetNextPoll returns when a feed will be polled next in adaptive mode
+func (pm *CLPriceMonitor) GetNextPoll(networkID uint64, feedAddress string) (time.Time, bool) {
+	return pm.scheduler.NextPoll(networkID, feedAddress)
+}
+
+// tickInterval returns how often the monitor wakes up: the update interval, or the scheduler
+// resolution in adaptive mode
+func (pm *CLPriceMonitor) tickInterval() time.Duration {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	if pm.adaptiveScheduling && pm.scheduler.config.TickInterval > 0 && pm.scheduler.config.TickInterval < pm.interval {
+		return pm.scheduler.config.TickInterval
+	}
+	return pm.interval
+}
+
+// dueFeeds keeps only the feeds whose next poll time has come. Networks without due feeds are
+// left out, so no snapshot block is pinned for them; their sequencer is still checked.
+func (pm *CLPriceMonitor) dueFeeds(feeds map[uint64][]string, now time.Time) map[uint64][]string {
+	prefix := string(types.SourceChainlink) + ":"
+	due := make(map[uint64][]string)
+	for networkID, feedList := range feeds {
+		for _, prefixed := range feedList {
+			if pm.scheduler.Due(networkID, strings.TrimPrefix(prefixed, prefix), now) {
+				due[networkID] = append(due[networkID], prefixed)
+			}
+		}
+	}
+	return due
+}
@@ -0,0 +1,141 @@
+package pricefeed
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/morpheum-labs/pricefeeding/chainlink"
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// networkTrust is the outcome of a sequencer check for one update cycle
+type networkTrust struct {
+	untrusted bool
+	reason    string
+}
+
+// defaultSequencerFeeds copies chainlink.DefaultSequencerUptimeFeeds so per-monitor overrides
+// do not modify the package defaults
+func defaultSequencerFeeds() map[uint64]string {
+	feeds := make(map[uint64]string, len(chainlink.DefaultSequencerUptimeFeeds))
+	for networkID, feedAddress := range chainlink.DefaultSequencerUptimeFeeds {
+		feeds[networkID] = feedAddress
+	}
+	return feeds
+}
+
+// SetSequencerUptimeFeed sets the L2 sequencer uptime feed checked for a network.
+// An empty address disables the sequencer check for that network.
+func (pm *CLPriceMonitor) SetSequencerUptimeFeed(networkID uint64, feedAddress string) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if feedAddress == "" {
+		delete(pm.sequencerFeeds, networkID)
+		delete(pm.sequencerStatus, networkID)
+		log.Printf("Disabled L2 sequencer check for network %d", networkID)
+		return
+	}
+	pm.sequencerFeeds[networkID] = feedAddress
+	log.Printf("Set L2 sequencer uptime feed for network %d: %s", networkID, feedAddress)
+}
+
+// SetSequencerGracePeriod sets how long prices stay untrusted after a sequencer comes back up
+func (pm *CLPriceMonitor) SetSequencerGracePeriod(gracePeriod time.Duration) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.sequencerGracePeriod = gracePeriod
+}
+
+// GetSequencerStatus returns the latest L2 sequencer status read for a network
+func (pm *CLPriceMonitor) GetSequencerStatus(networkID uint64) (*chainlink.SequencerStatus, error) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	status, exists := pm.sequencerStatus[networkID]
+	if !exists {
+		return nil, fmt.Errorf("no sequencer status for network %d", networkID)
+	}
+	return status, nil
+}
+
+// GetSequencerStatuses returns the latest L2 sequencer status of every checked network
+func (pm *CLPriceMonitor) GetSequencerStatuses() map[uint64]*chainlink.SequencerStatus {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	result := make(map[uint64]*chainlink.SequencerStatus, len(pm.sequencerStatus))
+	for networkID, status := range pm.sequencerStatus {
+		result[networkID] = status
+	}
+	return result
+}
+
+// IsNetworkTrusted reports whether prices on a network can currently be trusted.
+// Networks without a sequencer uptime feed are always trusted.
+func (pm *CLPriceMonitor) IsNetworkTrusted(networkID uint64) bool {
+	pm.mu.RLock()
+	_, hasFeed := pm.sequencerFeeds[networkID]
+	status, hasStatus := pm.sequencerStatus[networkID]
+	pm.mu.RUnlock()
+
+	if !hasFeed {
+		return true
+	}
+	return hasStatus && status.Trusted(time.Now())
+}
+
+// checkSequencer reads the sequencer uptime feed of a network, if it has one. A feed that
+// cannot be read leaves the network untrusted, since a down sequencer cannot be ruled out.
+func (pm *CLPriceMonitor) checkSequencer(ctx context.Context, networkID uint64, client bind.ContractBackend) networkTrust {
+	pm.mu.RLock()
+	feedAddress, hasFeed := pm.sequencerFeeds[networkID]
+	gracePeriod := pm.sequencerGracePeriod
+	previous := pm.sequencerStatus[networkID]
+	pm.mu.RUnlock()
+
+	if !hasFeed {
+		return networkTrust{}
+	}
+
+	status, err := chainlink.FetchSequencerStatus(ctx, client, networkID, feedAddress, gracePeriod)
+	if err != nil {
+		log.Printf("Failed to check L2 sequencer on network %d: %v", networkID, err)
+		return networkTrust{untrusted: true, reason: fmt.Sprintf("L2 sequencer status unknown: %v", err)}
+	}
+
+	pm.mu.Lock()
+	pm.sequencerStatus[networkID] = status
+	pm.mu.Unlock()
+
+	if previous == nil || previous.Up != status.Up {
+		if status.Up {
+			log.Printf("L2 sequencer on network %d is up since %s", networkID, status.StatusSince.Format(time.RFC3339))
+		} else {
+			log.Printf("L2 sequencer on network %d is DOWN since %s", networkID, status.StatusSince.Format(time.RFC3339))
+		}
+	}
+
+	reason := status.UntrustedReason(time.Now())
+	return networkTrust{untrusted: reason != "", reason: reason}
+}
+
+// markNetworkUntrusted re-stores the cached Chainlink prices of a network flagged as untrusted
+func (pm *CLPriceMonitor) markNetworkUntrusted(networkID uint64, reason string) {
+	for feedAddress, priceInfo := range pm.cacheManager.GetAllPricesBySource(networkID, types.SourceChainlink) {
+		clPrice, ok := priceInfo.(*types.ChainlinkPrice)
+		if !ok || (clPrice.Untrusted && clPrice.UntrustedReason == reason) {
+			continue
+		}
+
+		// Copy so readers holding the previous value are not mutated
+		flagged := *clPrice
+		flagged.Untrusted = true
+		flagged.UntrustedReason = reason
+		pm.cacheManager.UpdatePrice(networkID, feedAddress, types.SourceChainlink, &flagged)
+	}
+}
@@ -0,0 +1,101 @@
+package pricefeed
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/morpheum-labs/pricefeeding/chainlink"
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// SetSnapshotMode sets whether each update cycle reads all feeds of a network at a single block.
+// Prices read in snapshot mode carry the block number and hash they were read at.
+func (pm *CLPriceMonitor) SetSnapshotMode(enabled bool) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.snapshotMode = enabled
+	log.Printf("Chainlink price monitor snapshot mode set to: %v", enabled)
+}
+
+// GetSnapshotBlock returns the block the latest snapshot of a network was read at
+func (pm *CLPriceMonitor) GetSnapshotBlock(networkID uint64) (*chainlink.BlockRef, error) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	block, exists := pm.snapshotBlocks[networkID]
+	if !exists {
+		return nil, fmt.Errorf("no snapshot block for network %d", networkID)
+	}
+	return block, nil
+}
+
+// pinSnapshotBlock resolves the latest block of a network and records it as the current snapshot block
+func (pm *CLPriceMonitor) pinSnapshotBlock(ctx context.Context, networkID uint64, client bind.ContractBackend) (*chainlink.BlockRef, error) {
+	block, err := chainlink.FetchBlockRef(ctx, client, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pm.mu.Lock()
+	pm.snapshotBlocks[networkID] = block
+	pm.mu.Unlock()
+
+	return block, nil
+}
+
+// SnapshotAt reads every monitored feed of a network at a historical block, e.g. to reconcile
+// against another system. The prices are returned by feed address and are not written to the cache.
+// Feeds that cannot be read are left out and reported in the returned error.
+func (pm *CLPriceMonitor) SnapshotAt(ctx context.Context, networkID uint64, blockNumber *big.Int) (map[string]*types.ChainlinkPrice, error) {
+	if blockNumber == nil {
+		return nil, fmt.Errorf("block number cannot be nil")
+	}
+
+	client, exists := pm.clients.get(networkID)
+	if !exists {
+		return nil, fmt.Errorf("no client available for network %d", networkID)
+	}
+
+	block, err := chainlink.FetchBlockRef(ctx, client, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*types.ChainlinkPrice)
+	var failed []string
+	for _, feedAddress := range pm.getFeedAddresses(networkID) {
+		priceData, err := pm.fetchPriceData(ctx, networkID, feedAddress, block)
+		if err != nil {
+			log.Printf("Failed to read feed %s on network %d at block %s: %v", feedAddress, networkID, blockNumber.String(), err)
+			failed = append(failed, feedAddress)
+			continue
+		}
+		result[feedAddress] = priceData
+	}
+
+	if len(failed) > 0 {
+		return result, fmt.Errorf("failed to read %d feed(s) at block %s: %s", len(failed), blockNumber.String(), strings.Join(failed, ", "))
+	}
+	return result, nil
+}
+
+// getFeedAddresses returns the Chainlink feed addresses monitored on a network
+func (pm *CLPriceMonitor) getFeedAddresses(networkID uint64) []string {
+	cache := pm.cacheManager.GetCache()
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+
+	prefix := string(types.SourceChainlink) + ":"
+	var feedAddresses []string
+	for _, prefixed := range cache.feeds[networkID] {
+		if strings.HasPrefix(prefixed, prefix) {
+			feedAddresses = append(feedAddresses, strings.TrimPrefix(prefixed, prefix))
+		}
+	}
+	return feedAddresses
+}
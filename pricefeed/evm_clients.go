@@ -0,0 +1,151 @@
+package pricefeed
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/morpheum-labs/pricefeeding/rpcscan"
+)
+
+// rpcSwitchTimeout bounds a failover to a network's best RPC endpoint. The failover gets its own
+// context, so it still runs when the reads that failed used up the cycle's time budget.
+const rpcSwitchTimeout = 15 * time.Second
+
+// evmClients holds the RPC client of every network an on-chain monitor reads, and moves a network
+// to its best endpoint through the rpcscan configuration when reads fail. CLPriceMonitor and
+// PythOnChainMonitor share it.
+type evmClients struct {
+	mu            sync.RWMutex
+	clients       map[uint64]bind.ContractBackend
+	networkConfig *rpcscan.NetworkConfiguration // Network configuration for RPC switching, nil disables it
+}
+
+// newEVMClients creates an empty client set
+func newEVMClients() *evmClients {
+	return &evmClients{clients: make(map[uint64]bind.ContractBackend)}
+}
+
+// set adds or replaces the client of a network
+func (c *evmClients) set(networkID uint64, client bind.ContractBackend) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clients[networkID] = client
+}
+
+// get returns the client of a network
+func (c *evmClients) get(networkID uint64) (bind.ContractBackend, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	client, exists := c.clients[networkID]
+	return client, exists
+}
+
+// all returns a copy of the clients by network
+func (c *evmClients) all() map[uint64]bind.ContractBackend {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	clients := make(map[uint64]bind.ContractBackend, len(c.clients))
+	for networkID, client := range c.clients {
+		clients[networkID] = client
+	}
+	return clients
+}
+
+// setNetworkConfig sets the network configuration used for RPC switching
+func (c *evmClients) setNetworkConfig(networkConfig *rpcscan.NetworkConfiguration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.networkConfig = networkConfig
+}
+
+// switcher returns the RPC switcher, or nil without a network configuration
+func (c *evmClients) switcher() *rpcSwitcherAdapter {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.networkConfig == nil {
+		return nil
+	}
+	return &rpcSwitcherAdapter{networkConfig: c.networkConfig, clients: c}
+}
+
+// switchRPC moves a network to its best RPC endpoint after failed reads. It runs on its own
+// timeout derived from ctx, which should outlive the reads' cycle, e.g. the monitor's context.
+func (c *evmClients) switchRPC(ctx context.Context, networkID uint64) error {
+	switcher := c.switcher()
+	if switcher == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, rpcSwitchTimeout)
+	defer cancel()
+	if err := switcher.SwitchRPCEndpointImmediately(ctx, networkID); err != nil {
+		return fmt.Errorf("failed to switch RPC endpoint for network %d: %v", networkID, err)
+	}
+	if _, err := switcher.GetBestClient(networkID); err != nil {
+		return fmt.Errorf("failed to get a client for network %d after RPC switch: %v", networkID, err)
+	}
+	return nil
+}
+
+// rpcSwitcherAdapter adapts NetworkConfiguration to chainlink.RPCSwitcher interface
+type rpcSwitcherAdapter struct {
+	networkConfig *rpcscan.NetworkConfiguration
+	clients       *evmClients
+}
+
+// SwitchRPCEndpointImmediately switches to a different RPC endpoint
+func (r *rpcSwitcherAdapter) SwitchRPCEndpointImmediately(ctx context.Context, networkID uint64) error {
+	return r.networkConfig.SwitchRPCEndpointImmediately(ctx, networkID)
+}
+
+// GetBestClient returns the best available client for the network
+func (r *rpcSwitcherAdapter) GetBestClient(networkID uint64) (bind.ContractCaller, error) {
+	ethClient, err := r.networkConfig.GetBestClient(networkID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the underlying ethclient.Client
+	newClient := ethClient.GetClient()
+
+	// Update the monitor's client map
+	r.clients.set(networkID, newClient)
+	log.Printf("Updated client for network %d after RPC switch", networkID)
+
+	return newClient, nil
+}
+
+// stopContext returns a context that is cancelled with ctx or when stop is closed, so that
+// stopping a monitor also aborts any RPC call in flight
+func stopContext(ctx context.Context, stop <-chan struct{}) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// pollEvery runs update right away and then every interval until ctx is done
+func pollEvery(ctx context.Context, interval time.Duration, update func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	update(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			update(ctx)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package pricefeed
+
+import (
+	"context"
+	"log"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// DefaultHeartbeatGrace is how long past its heartbeat a feed may go without a new round before it is reported
+const DefaultHeartbeatGrace = time.Minute
+
+// HeartbeatEventType is the kind of a HeartbeatEvent
+type HeartbeatEventType string
+
+const (
+	HeartbeatMissed    HeartbeatEventType = "heartbeat_missed"    // No new round within heartbeat + grace
+	HeartbeatRecovered HeartbeatEventType = "heartbeat_recovered" // A new round landed after a missed heartbeat
+)
+
+// HeartbeatEvent reports a feed missing its heartbeat or recovering from it
+type HeartbeatEvent struct {
+	Type        HeartbeatEventType
+	NetworkID   uint64
+	FeedAddress string
+	Symbol      string
+	Heartbeat   time.Duration
+	Grace       time.Duration
+	RoundID     *big.Int      // Latest round seen; the late round for HeartbeatRecovered, nil if none was seen yet
+	UpdatedAt   time.Time     // updatedAt of RoundID, or when watching started if no round was seen
+	Overdue     time.Duration // Missed: how far past heartbeat + grace the check ran; recovered: how late the round landed
+	DetectedAt  time.Time
+}
+
+// HeartbeatHandler is called for every HeartbeatEvent
+type HeartbeatHandler func(event HeartbeatEvent)
+
+// watchedFeed is the heartbeat state of a single feed
+type watchedFeed struct {
+	symbol    string
+	heartbeat time.Duration
+	roundID   *big.Int
+	updatedAt time.Time
+	missed    bool
+	missedAt  time.Time
+	missedDue time.Time // Deadline that was missed
+}
+
+// deadline returns when the feed misses its heartbeat
+func (f *watchedFeed) deadline(grace time.Duration) time.Time {
+	return f.updatedAt.Add(f.heartbeat + grace)
+}
+
+// HeartbeatWatcher raises an event the moment a feed goes longer than its heartbeat plus a grace
+// period without a new round, and again when the next round lands. It keeps its own timer on
+// the earliest deadline, so it fires even when polls succeed but keep returning the same round,
+// and does not depend on how often the feed is polled.
+type HeartbeatWatcher struct {
+	mu       sync.Mutex
+	grace    time.Duration
+	feeds    map[feedScheduleKey]*watchedFeed
+	handlers []HeartbeatHandler
+	wake     chan struct{} // Signalled when a deadline may have moved
+}
+
+// NewHeartbeatWatcher creates a heartbeat watcher. A grace of 0 uses DefaultHeartbeatGrace.
+func NewHeartbeatWatcher(grace time.Duration) *HeartbeatWatcher {
+	if grace == 0 {
+		grace = DefaultHeartbeatGrace
+	}
+	return &HeartbeatWatcher{
+		grace: grace,
+		feeds: make(map[feedScheduleKey]*watchedFeed),
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+// SetGrace sets how long past its heartbeat a feed may go without a new round
+func (w *HeartbeatWatcher) SetGrace(grace time.Duration) {
+	w.mu.Lock()
+	w.grace = grace
+	w.mu.Unlock()
+	w.signal()
+}
+
+// OnEvent registers a handler called for every missed and recovered heartbeat
+func (w *HeartbeatWatcher) OnEvent(handler HeartbeatHandler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers = append(w.handlers, handler)
+}
+
+// Watch starts watching a feed with the given heartbeat. Until its first round is observed, the
+// heartbeat is counted from now. A heartbeat of 0 stops watching the feed.
+func (w *HeartbeatWatcher) Watch(networkID uint64, feedAddress, symbol string, heartbeat time.Duration) {
+	if heartbeat <= 0 {
+		w.Unwatch(networkID, feedAddress)
+		return
+	}
+
+	w.mu.Lock()
+	key := feedScheduleKey{networkID, feedAddress}
+	if feed, exists := w.feeds[key]; exists {
+		feed.symbol = symbol
+		feed.heartbeat = heartbeat
+	} else {
+		w.feeds[key] = &watchedFeed{symbol: symbol, heartbeat: heartbeat, updatedAt: time.Now()}
+	}
+	w.mu.Unlock()
+	w.signal()
+}
+
+// Unwatch stops watching a feed
+func (w *HeartbeatWatcher) Unwatch(networkID uint64, feedAddress string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.feeds, feedScheduleKey{networkID, feedAddress})
+}
+
+// Observe records a round read from a feed and moves its deadline to the round's updatedAt plus
+// heartbeat and grace. After a missed heartbeat, a newer round that is itself within heartbeat
+// and grace of now emits HeartbeatRecovered; a newer but still stale round keeps the feed missed.
+func (w *HeartbeatWatcher) Observe(networkID uint64, feedAddress string, price *types.ChainlinkPrice, now time.Time) {
+	if price == nil || price.UpdatedAt == nil {
+		return
+	}
+	updatedAt := time.Unix(price.UpdatedAt.Int64(), 0)
+
+	w.mu.Lock()
+	feed, exists := w.feeds[feedScheduleKey{networkID, feedAddress}]
+	if !exists || (feed.roundID != nil && !updatedAt.After(feed.updatedAt)) {
+		w.mu.Unlock()
+		return
+	}
+
+	feed.roundID = price.RoundID
+	feed.updatedAt = updatedAt
+
+	var event *HeartbeatEvent
+	if feed.missed && now.Before(feed.deadline(w.grace)) {
+		feed.missed = false
+		recovered := w.event(HeartbeatRecovered, networkID, feedAddress, feed, now)
+		recovered.Overdue = updatedAt.Sub(feed.missedDue)
+		event = &recovered
+	}
+	handlers := w.copyHandlers()
+	w.mu.Unlock()
+	w.signal()
+
+	if event != nil {
+		w.emit(handlers, *event)
+	}
+}
+
+// Check emits HeartbeatMissed for every feed whose deadline passed since the last check and
+// returns the events. Start calls it when the earliest deadline is reached.
+func (w *HeartbeatWatcher) Check(now time.Time) []HeartbeatEvent {
+	w.mu.Lock()
+	var events []HeartbeatEvent
+	for key, feed := range w.feeds {
+		deadline := feed.deadline(w.grace)
+		if feed.missed || now.Before(deadline) {
+			continue
+		}
+		feed.missed = true
+		feed.missedAt = now
+		feed.missedDue = deadline
+		event := w.event(HeartbeatMissed, key.networkID, key.feedAddress, feed, now)
+		event.Overdue = now.Sub(deadline)
+		events = append(events, event)
+	}
+	handlers := w.copyHandlers()
+	w.mu.Unlock()
+
+	sort.Slice(events, func(i, j int) bool { return events[i].UpdatedAt.Before(events[j].UpdatedAt) })
+	for _, event := range events {
+		w.emit(handlers, event)
+	}
+	return events
+}
+
+// Missed returns the feeds currently past their heartbeat
+func (w *HeartbeatWatcher) Missed() []HeartbeatEvent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var missed []HeartbeatEvent
+	for key, feed := range w.feeds {
+		if feed.missed {
+			event := w.event(HeartbeatMissed, key.networkID, key.feedAddress, feed, feed.missedAt)
+			event.Overdue = time.Since(feed.deadline(w.grace))
+			missed = append(missed, event)
+		}
+	}
+	sort.Slice(missed, func(i, j int) bool { return missed[i].UpdatedAt.Before(missed[j].UpdatedAt) })
+	return missed
+}
+
+// Start checks every feed at its deadline until ctx is cancelled
+func (w *HeartbeatWatcher) Start(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			w.Check(time.Now())
+		case <-w.wake:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		}
+		timer.Reset(w.untilNextDeadline(time.Now()))
+	}
+}
+
+// untilNextDeadline returns how long until the earliest deadline of a feed not already missed.
+// Without such a feed the watcher sleeps until Watch or Observe wakes it.
+func (w *HeartbeatWatcher) untilNextDeadline(now time.Time) time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	next := time.Duration(-1)
+	for _, feed := range w.feeds {
+		if feed.missed {
+			continue
+		}
+		until := feed.deadline(w.grace).Sub(now)
+		if until < 0 {
+			until = 0
+		}
+		if next < 0 || until < next {
+			next = until
+		}
+	}
+	if next < 0 {
+		return time.Hour
+	}
+	return next
+}
+
+// event builds an event for a feed, called with w.mu held
+func (w *HeartbeatWatcher) event(eventType HeartbeatEventType, networkID uint64, feedAddress string, feed *watchedFeed, now time.Time) HeartbeatEvent {
+	return HeartbeatEvent{
+		Type:        eventType,
+		NetworkID:   networkID,
+		FeedAddress: feedAddress,
+		Symbol:      feed.symbol,
+		Heartbeat:   feed.heartbeat,
+		Grace:       w.grace,
+		RoundID:     feed.roundID,
+		UpdatedAt:   feed.updatedAt,
+		DetectedAt:  now,
+	}
+}
+
+// copyHandlers returns the registered handlers, called with w.mu held
+func (w *HeartbeatWatcher) copyHandlers() []HeartbeatHandler {
+	handlers := make([]HeartbeatHandler, len(w.handlers))
+	copy(handlers, w.handlers)
+	return handlers
+}
+
+// emit logs an event and passes it to the handlers
+func (w *HeartbeatWatcher) emit(handlers []HeartbeatHandler, event HeartbeatEvent) {
+	switch event.Type {
+	case HeartbeatMissed:
+		log.Printf("Heartbeat missed: feed %s (%s) on network %d has no new round since %s (heartbeat %v, grace %v)",
+			event.FeedAddress, event.Symbol, event.NetworkID, event.UpdatedAt.Format(time.RFC3339), event.Heartbeat, event.Grace)
+	case HeartbeatRecovered:
+		log.Printf("Heartbeat recovered: feed %s (%s) on network %d updated at %s",
+			event.FeedAddress, event.Symbol, event.NetworkID, event.UpdatedAt.Format(time.RFC3339))
+	}
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// signal wakes Start to recompute the next deadline
+func (w *HeartbeatWatcher) signal() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,91 @@
+package pricefeed
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestHeartbeatWatcherMissedAndRecovered(t *testing.T) {
+	w := NewHeartbeatWatcher(time.Minute)
+	var events []HeartbeatEvent
+	w.OnEvent(func(event HeartbeatEvent) { events = append(events, event) })
+
+	now := time.Unix(1_700_000_000, 0)
+	w.Watch(1, testFeed, "ETH/USD", time.Hour)
+	w.Observe(1, testFeed, roundAt(1, now.Add(-50*time.Minute)), now)
+
+	// 10m until the heartbeat, plus 1m grace
+	if missed := w.Check(now.Add(10 * time.Minute)); len(missed) != 0 {
+		t.Fatalf("Expected no event within the grace period, got %v", missed)
+	}
+	missed := w.Check(now.Add(11*time.Minute + time.Second))
+	if len(missed) != 1 || missed[0].Type != HeartbeatMissed || missed[0].Symbol != "ETH/USD" || missed[0].Overdue != time.Second {
+		t.Fatalf("Expected one missed heartbeat 1s overdue, got %+v", missed)
+	}
+
+	// A missed heartbeat is reported once, and polls returning the same round do not clear it
+	if again := w.Check(now.Add(20 * time.Minute)); len(again) != 0 {
+		t.Errorf("Expected the missed heartbeat to be reported once, got %v", again)
+	}
+	w.Observe(1, testFeed, roundAt(1, now.Add(-50*time.Minute)), now.Add(20*time.Minute))
+	if len(w.Missed()) != 1 {
+		t.Errorf("Expected the feed to stay missed on the same round, got %v", w.Missed())
+	}
+
+	// A newer round that is already past its own heartbeat does not recover the feed
+	w.Observe(1, testFeed, roundAt(2, now.Add(-45*time.Minute)), now.Add(20*time.Minute))
+	if len(events) != 1 || len(w.Missed()) != 1 {
+		t.Fatalf("Expected the feed to stay missed on a stale round, got %+v", events)
+	}
+
+	// A fresh round recovers the feed
+	w.Observe(1, testFeed, roundAt(3, now.Add(15*time.Minute)), now.Add(20*time.Minute))
+	if len(events) != 2 || events[1].Type != HeartbeatRecovered || events[1].RoundID.Int64() != 3 {
+		t.Fatalf("Expected a recovered event for round 3, got %+v", events)
+	}
+	if events[1].Overdue != 4*time.Minute {
+		t.Errorf("Expected the round to land 4m late, got %v", events[1].Overdue)
+	}
+	if len(w.Missed()) != 0 {
+		t.Errorf("Expected no missed feeds after recovery, got %v", w.Missed())
+	}
+}
+
+func TestHeartbeatWatcherIgnoresUnwatchedFeeds(t *testing.T) {
+	w := NewHeartbeatWatcher(0)
+	now := time.Now()
+
+	w.Observe(1, testFeed, roundAt(1, now.Add(-48*time.Hour)), now)
+	w.Watch(1, testFeed, "ETH/USD", 0) // No heartbeat configured
+	if missed := w.Check(now.Add(48 * time.Hour)); len(missed) != 0 {
+		t.Errorf("Expected feeds without a heartbeat to be ignored, got %v", missed)
+	}
+
+	w.Watch(1, testFeed, "ETH/USD", time.Hour)
+	w.Unwatch(1, testFeed)
+	if missed := w.Check(now.Add(48 * time.Hour)); len(missed) != 0 {
+		t.Errorf("Expected unwatched feeds to be ignored, got %v", missed)
+	}
+}
+
+func TestHeartbeatWatcherFiresOnItsOwnTimer(t *testing.T) {
+	w := NewHeartbeatWatcher(10 * time.Millisecond)
+	events := make(chan HeartbeatEvent, 1)
+	w.OnEvent(func(event HeartbeatEvent) { events <- event })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Start(ctx)
+
+	// Nothing polls the feed: the watcher alone raises the event at the deadline
+	w.Watch(1, testFeed, "ETH/USD", 50*time.Millisecond)
+	select {
+	case event := <-events:
+		if event.Type != HeartbeatMissed {
+			t.Errorf("Expected a missed heartbeat, got %+v", event)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the watcher to raise a missed heartbeat on its own")
+	}
+}
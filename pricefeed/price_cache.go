@@ -22,6 +22,9 @@ type PriceCache struct {
 	mu    sync.RWMutex
 	data  map[uint64]map[string]types.PriceInfo // networkID -> prefixedIdentifier -> PriceInfo
 	feeds map[uint64][]string                   // networkID -> list of prefixed identifiers (e.g., "chainlink:0xaddr", "pyth:id")
+
+	history      map[uint64]map[string][]types.PriceInfo // networkID -> prefixedIdentifier -> prices ordered by timestamp
+	historyLimit int                                     // Maximum number of prices kept per feed history
 }
 
 // NewPriceCache creates a new price cache
@@ -29,6 +32,9 @@ func NewPriceCache() *PriceCache {
 	return &PriceCache{
 		data:  make(map[uint64]map[string]types.PriceInfo),
 		feeds: make(map[uint64][]string),
+
+		history:      make(map[uint64]map[string][]types.PriceInfo),
+		historyLimit: DefaultHistoryLimit,
 	}
 }
 
@@ -60,6 +66,23 @@ func (pc *PriceCache) AddFeed(networkID uint64, identifier string, source types.
 	log.Printf("Added price feed %s for network %d (source: %s)", identifier, networkID, source)
 }
 
+// RemoveFeed stops tracking a price feed and drops its cached price and history
+func (pc *PriceCache) RemoveFeed(networkID uint64, identifier string, source types.PriceSource) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	prefixed := makePrefixedIdentifier(source, identifier)
+	for i, existing := range pc.feeds[networkID] {
+		if existing == prefixed {
+			pc.feeds[networkID] = append(pc.feeds[networkID][:i], pc.feeds[networkID][i+1:]...)
+			break
+		}
+	}
+	delete(pc.data[networkID], prefixed)
+	delete(pc.history[networkID], prefixed)
+	log.Printf("Removed price feed %s for network %d (source: %s)", identifier, networkID, source)
+}
+
 // GetPrice retrieves the latest price for a specific feed
 func (pc *PriceCache) GetPrice(networkID uint64, identifier string, source types.PriceSource) (types.PriceInfo, error) {
 	pc.mu.RLock()
@@ -144,6 +167,11 @@ func (pc *PriceCache) UpdatePrice(networkID uint64, identifier string, source ty
 		pc.feeds[networkID] = append(pc.feeds[networkID], prefixed)
 	}
 
+	// Feeds with a history (e.g. seeded by a backfill) keep extending it
+	if _, exists := pc.history[networkID][prefixed]; exists {
+		pc.addHistoryUnlocked(networkID, prefixed, priceInfo)
+	}
+
 	// Check cache size and prune if necessary (unlock first to avoid deadlock)
 	size := pc.estimateSizeUnlocked()
 	if size > MaxCacheSizeBytes {
@@ -398,6 +426,11 @@ func (pcm *PriceCacheManager) AddFeed(networkID uint64, identifier string, sourc
 	pcm.cache.AddFeed(networkID, identifier, source)
 }
 
+// RemoveFeed stops tracking a price feed
+func (pcm *PriceCacheManager) RemoveFeed(networkID uint64, identifier string, source types.PriceSource) {
+	pcm.cache.RemoveFeed(networkID, identifier, source)
+}
+
 // UpdateLastSaved updates the last saved timestamp
 func (pcm *PriceCacheManager) UpdateLastSaved() {
 	pcm.mu.Lock()
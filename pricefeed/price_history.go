@@ -0,0 +1,107 @@
+package pricefeed
+
+import (
+	"sort"
+	"time"
+
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// DefaultHistoryLimit is the default maximum number of prices kept per feed history
+const DefaultHistoryLimit = 10000
+
+// AddHistory adds a price to the history of a feed, ordered by when it was published. A price
+// published at the same time as one already in the history replaces it, and the oldest prices are dropped
+// beyond the history limit. Once a feed has a history, UpdatePrice also appends to it.
+// Histories are not counted against MaxCacheSizeBytes.
+func (pc *PriceCache) AddHistory(networkID uint64, identifier string, source types.PriceSource, priceInfo types.PriceInfo) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.addHistoryUnlocked(networkID, makePrefixedIdentifier(source, identifier), priceInfo)
+}
+
+// addHistoryUnlocked adds a price to a feed history (caller must hold the lock)
+func (pc *PriceCache) addHistoryUnlocked(networkID uint64, prefixed string, priceInfo types.PriceInfo) {
+	if pc.history[networkID] == nil {
+		pc.history[networkID] = make(map[string][]types.PriceInfo)
+	}
+	series := pc.history[networkID][prefixed]
+	timestamp := historyTimestamp(priceInfo)
+
+	i := sort.Search(len(series), func(i int) bool { return !historyTimestamp(series[i]).Before(timestamp) })
+	if i < len(series) && historyTimestamp(series[i]).Equal(timestamp) {
+		series[i] = priceInfo
+	} else {
+		series = append(series, nil)
+		copy(series[i+1:], series[i:])
+		series[i] = priceInfo
+	}
+
+	if pc.historyLimit > 0 && len(series) > pc.historyLimit {
+		series = append(series[:0:0], series[len(series)-pc.historyLimit:]...)
+	}
+	pc.history[networkID][prefixed] = series
+}
+
+// GetHistory returns the prices of a feed published in [from, to], oldest first.
+// A zero from or to leaves that end of the range open.
+func (pc *PriceCache) GetHistory(networkID uint64, identifier string, source types.PriceSource, from, to time.Time) []types.PriceInfo {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+
+	series := pc.history[networkID][makePrefixedIdentifier(source, identifier)]
+	result := make([]types.PriceInfo, 0, len(series))
+	for _, priceInfo := range series {
+		timestamp := historyTimestamp(priceInfo)
+		if (!from.IsZero() && timestamp.Before(from)) || (!to.IsZero() && timestamp.After(to)) {
+			continue
+		}
+		result = append(result, priceInfo)
+	}
+	return result
+}
+
+// SetHistoryLimit sets the maximum number of prices kept per feed history; 0 keeps every price
+func (pc *PriceCache) SetHistoryLimit(limit int) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.historyLimit = limit
+}
+
+// AddHistory adds a price to the history of a feed
+func (pcm *PriceCacheManager) AddHistory(networkID uint64, identifier string, source types.PriceSource, priceInfo types.PriceInfo) {
+	pcm.cache.AddHistory(networkID, identifier, source, priceInfo)
+}
+
+// GetHistory returns the prices of a feed published in [from, to], oldest first
+func (pcm *PriceCacheManager) GetHistory(networkID uint64, identifier string, source types.PriceSource, from, to time.Time) []types.PriceInfo {
+	return pcm.cache.GetHistory(networkID, identifier, source, from, to)
+}
+
+// historyTimestamp returns when a price was published, so that polling the same price twice
+// does not add it twice, falling back to its timestamp
+func historyTimestamp(priceInfo types.PriceInfo) time.Time {
+	switch price := priceInfo.(type) {
+	case *types.PythPrice:
+		if price.PublishTime > 0 {
+			return time.Unix(price.PublishTime, 0)
+		}
+	case *types.PythOnChainPrice:
+		if price.PublishTime > 0 {
+			return time.Unix(price.PublishTime, 0)
+		}
+	case *types.PythEMAPrice:
+		if price.PublishTime > 0 {
+			return time.Unix(price.PublishTime, 0)
+		}
+	case *types.PythTWAPPrice:
+		if price.EndTime > 0 {
+			return time.Unix(price.EndTime, 0)
+		}
+	case *types.ChainlinkPrice:
+		if price.UpdatedAt != nil && price.UpdatedAt.Sign() > 0 {
+			return time.Unix(price.UpdatedAt.Int64(), 0)
+		}
+	}
+	return priceInfo.GetTimestamp()
+}
@@ -152,6 +152,19 @@ func EstimatePriceInfoSize(priceInfo types.PriceInfo) int64 {
 			8 + // Slot
 			15 + // Timestamp
 			8 // NetworkID
+	case *types.PythEMAPrice:
+		// PythEMAPrice: ID, Symbol, Price, Confidence, Exponent, PublishTime, Timestamp, NetworkID
+		return int64(len(p.ID)) + 8 +
+			int64(len(p.Symbol)) + 8 +
+			2*32 + // Price and Confidence *big.Int
+			8 + 8 + 15 + 8
+	case *types.PythTWAPPrice:
+		// PythTWAPPrice: ID, Symbol, Window, Price, Confidence, Exponent, StartTime, EndTime, DownSlotsRatio, Timestamp, NetworkID
+		return int64(len(p.ID)) + 8 +
+			int64(len(p.Symbol)) + 8 +
+			8 + // Window
+			2*32 + // Price and Confidence *big.Int
+			8 + 8 + 8 + 8 + 15 + 8
 	default:
 		// Unknown type, return a conservative estimate
 		return 100
@@ -43,6 +43,27 @@ func (p *ExampleCustomPrice) GetIdentifier() string {
 	return p.ID
 }
 
+func (p *ExampleCustomPrice) GetPriceInSatoshi() (*big.Int, error) {
+	return exampleSatoshiPrice(p.Price, p.Exponent)
+}
+
+func (p *ExampleCustomPrice) GetUint64SatoshiPrice() uint64 {
+	price, _ := p.GetPriceInSatoshi()
+	return price.Uint64()
+}
+
+// exampleSatoshiPrice rescales a price with the given exponent to 8 decimals
+func exampleSatoshiPrice(price *big.Int, exponent int) (*big.Int, error) {
+	if price == nil {
+		return nil, fmt.Errorf("price is nil")
+	}
+	shift := exponent + 8
+	if shift >= 0 {
+		return new(big.Int).Mul(price, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(shift)), nil)), nil
+	}
+	return new(big.Int).Quo(price, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-shift)), nil)), nil
+}
+
 // ExampleRegisterSizeEstimator demonstrates how to register a custom size estimator
 // for a custom price type using generics.
 func Example_registerSizeEstimator() {
@@ -74,7 +95,8 @@ func Example_registerSizeEstimator() {
 
 // ExamplePriceCacheManagerWithCustomSize demonstrates how to use
 // PriceCacheManager with custom price types that have registered size estimators.
-func ExamplePriceCacheManagerWithCustomSize() {
+// PrintStatus reports timestamps, so the example is compiled but its output is not checked.
+func Example_priceCacheManagerWithCustomSize() {
 	// Step 1: Register the size estimator (typically done at package init)
 	pricefeed.RegisterSizeEstimator[*ExampleCustomPrice](func(p *ExampleCustomPrice) int64 {
 		size := int64(0)
@@ -142,16 +164,6 @@ func ExamplePriceCacheManagerWithCustomSize() {
 	// Step 7: The cache automatically prunes when size exceeds MaxCacheSizeBytes
 	// The pruning uses the registered size estimators to accurately calculate sizes
 	cacheManager.PrintStatus()
-
-	// Output:
-	// Cache size: <size> bytes (<size> KB)
-	// Retrieved price: CUSTOM/USD = 100000000
-	// 📊 CACHE STATUS
-	//    Last Saved: <timestamp>
-	//    Time Since Last Save: <duration>
-	//    Cache Size: <size> MB / 10.00 MB (<percentage>%)
-	//    Total Monitored Feeds: 2
-	//    --------------------------------------------------
 }
 
 // ExampleSizablePriceInfo demonstrates how to implement the SizablePriceInfo
@@ -186,6 +198,15 @@ func (p *ExampleSizablePrice) GetIdentifier() string {
 	return p.ID
 }
 
+func (p *ExampleSizablePrice) GetPriceInSatoshi() (*big.Int, error) {
+	return exampleSatoshiPrice(p.Price, p.Exponent)
+}
+
+func (p *ExampleSizablePrice) GetUint64SatoshiPrice() uint64 {
+	price, _ := p.GetPriceInSatoshi()
+	return price.Uint64()
+}
+
 // Implement SizablePriceInfo interface - no registration needed!
 func (p *ExampleSizablePrice) EstimateSize() int64 {
 	size := int64(0)
@@ -215,10 +236,22 @@ func Example_usingSizablePriceInfo() {
 
 	// Cache size calculation automatically uses EstimateSize() method
 	size := cacheManager.GetCacheSize()
-	fmt.Printf("Cache size with SizablePriceInfo: %d bytes\n", size)
+
+	// Replacing the price with a larger one grows the cache by the difference of their estimates
+	largerPrice := &ExampleSizablePrice{
+		ID:        "sizable-feed-1",
+		Price:     big.NewInt(51000000),
+		Exponent:  -8,
+		Timestamp: time.Now(),
+		NetworkID: 1,
+		Data:      []byte("some more data"),
+	}
+	cacheManager.UpdatePrice(1, "sizable-feed-1", types.PriceSource("sizable"), largerPrice)
+	grown := cacheManager.GetCacheSize() - size
+	fmt.Printf("Cache size follows EstimateSize: %t\n", grown == largerPrice.EstimateSize()-sizablePrice.EstimateSize())
 
 	// Output:
-	// Cache size with SizablePriceInfo: <size> bytes
+	// Cache size follows EstimateSize: true
 }
 
 // ExampleMixedPriceTypes demonstrates using multiple price types
@@ -271,8 +304,9 @@ func Example_mixedPriceTypes() {
 
 	// All price types use their respective size estimators automatically
 	totalSize := cacheManager.GetCacheSize()
-	fmt.Printf("Total cache size with mixed types: %d bytes\n", totalSize)
+	estimated := pricefeed.EstimateSize(chainlinkPrice) + pricefeed.EstimateSize(pythPrice) + pricefeed.EstimateSize(customPrice)
+	fmt.Printf("Total cache size covers every price estimate: %t\n", totalSize > estimated)
 
 	// Output:
-	// Total cache size with mixed types: <size> bytes
+	// Total cache size covers every price estimate: true
 }
@@ -0,0 +1,317 @@
+package pricefeed
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/morpheum-labs/pricefeeding/pyth"
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// DefaultPythBackfillConcurrency is how many timestamps a backfill fetches at once by default
+const DefaultPythBackfillConcurrency = 4
+
+// PythBackfillConfig describes a historical Pyth backfill
+type PythBackfillConfig struct {
+	PriceIDs    []string          // Price feed IDs, fetched together at every timestamp
+	Symbols     map[string]string // Optional price ID -> symbol, copied to the prices
+	Start       time.Time
+	End         time.Time
+	Step        time.Duration // Distance between fetched timestamps, at least one second
+	Concurrency int           // Timestamps fetched at once, 0 uses DefaultPythBackfillConcurrency
+
+	// ProgressFile, when set, records the completed timestamps so that a restarted backfill with
+	// the same IDs, range and step only fetches the timestamps left. It is saved after every
+	// timestamp the sink has written, so an export appended to on resume gets no duplicate rows.
+	ProgressFile string
+}
+
+// PythBackfillSink receives the prices of each backfilled timestamp. Timestamps are written as
+// they complete, not in order, and calls may be concurrent.
+type PythBackfillSink interface {
+	WritePrices(timestamp int64, prices []*types.PythPrice) error
+}
+
+// PythBackfillReport summarizes a backfill run
+type PythBackfillReport struct {
+	Timestamps int     // Timestamps in the range
+	Fetched    int     // Timestamps fetched by this run
+	Resumed    int     // Timestamps skipped because an earlier run completed them
+	Prices     int     // Prices written to the sink
+	Failed     []int64 // Timestamps that failed, retried by the next run with the same progress file
+}
+
+// pythBackfillProgress is the content of a progress file
+type pythBackfillProgress struct {
+	PriceIDs  []string `json:"priceIds"`
+	Start     int64    `json:"start"`
+	End       int64    `json:"end"`
+	Step      int64    `json:"step"`
+	Completed []int64  `json:"completed"`
+}
+
+// BackfillPyth fetches the Pyth prices at every step of [Start, End] from Hermes with
+// GetPriceUpdatesAtTimestamp and writes them to sink. A timestamp that fails is reported and
+// left for the next run; cancelling ctx stops the backfill after saving its progress.
+func BackfillPyth(ctx context.Context, client *pyth.HermesClient, config PythBackfillConfig, sink PythBackfillSink) (*PythBackfillReport, error) {
+	if len(config.PriceIDs) == 0 {
+		return nil, fmt.Errorf("backfill needs at least one price ID")
+	}
+	if config.Step < time.Second {
+		return nil, fmt.Errorf("backfill step must be at least 1s, got %v", config.Step)
+	}
+	if config.End.Before(config.Start) {
+		return nil, fmt.Errorf("backfill ends at %s before its start %s", config.End.Format(time.RFC3339), config.Start.Format(time.RFC3339))
+	}
+	concurrency := config.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultPythBackfillConcurrency
+	}
+
+	ids := make([]string, 0, len(config.PriceIDs))
+	for _, id := range config.PriceIDs {
+		ids = append(ids, normalizePythPriceID(id))
+	}
+	sort.Strings(ids)
+	symbols := make(map[string]string, len(config.Symbols))
+	for id, symbol := range config.Symbols {
+		symbols[normalizePythPriceID(id)] = symbol
+	}
+
+	progress := &pythBackfillProgress{PriceIDs: ids, Start: config.Start.Unix(), End: config.End.Unix(), Step: int64(config.Step / time.Second)}
+	completed, err := loadPythBackfillProgress(config.ProgressFile, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	var timestamps []int64
+	report := &PythBackfillReport{}
+	for timestamp := progress.Start; timestamp <= progress.End; timestamp += progress.Step {
+		report.Timestamps++
+		if completed[timestamp] {
+			report.Resumed++
+			continue
+		}
+		timestamps = append(timestamps, timestamp)
+	}
+
+	var mu sync.Mutex
+	jobs := make(chan int64)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for timestamp := range jobs {
+				count, err := backfillPythTimestamp(ctx, client, ids, symbols, timestamp, sink)
+
+				mu.Lock()
+				if err != nil {
+					if ctx.Err() == nil {
+						log.Printf("Pyth backfill of %s failed: %v", time.Unix(timestamp, 0).UTC().Format(time.RFC3339), err)
+						report.Failed = append(report.Failed, timestamp)
+					}
+				} else {
+					report.Fetched++
+					report.Prices += count
+					completed[timestamp] = true
+					if err := savePythBackfillProgress(config.ProgressFile, progress, completed); err != nil {
+						log.Printf("Failed to save Pyth backfill progress: %v", err)
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, timestamp := range timestamps {
+		select {
+		case jobs <- timestamp:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.Slice(report.Failed, func(i, j int) bool { return report.Failed[i] < report.Failed[j] })
+	if err := savePythBackfillProgress(config.ProgressFile, progress, completed); err != nil {
+		return report, err
+	}
+	return report, ctx.Err()
+}
+
+// backfillPythTimestamp fetches the prices at one timestamp and writes them to sink
+func backfillPythTimestamp(ctx context.Context, client *pyth.HermesClient, ids []string, symbols map[string]string, timestamp int64, sink PythBackfillSink) (int, error) {
+	hexIDs := make([]pyth.HexString, len(ids))
+	for i, id := range ids {
+		hexIDs[i] = pyth.HexString(id)
+	}
+	parsed, ignoreInvalid := true, true
+	update, err := client.GetPriceUpdatesAtTimestamp(ctx, pyth.UnixTimestamp(timestamp), hexIDs,
+		&pyth.GetPriceUpdatesAtTimestampOptions{Parsed: &parsed, IgnoreInvalidPriceIds: &ignoreInvalid})
+	if err != nil {
+		return 0, err
+	}
+
+	prices := make([]*types.PythPrice, 0, len(update.Parsed))
+	for _, feed := range update.Parsed {
+		price := pythFeedToPrice(feed, symbols[normalizePythPriceID(feed.ID)])
+		// A historical price was not observed live; date it by its publish time
+		price.Timestamp = time.Unix(feed.Price.PublishTime, 0)
+		prices = append(prices, price)
+	}
+	if err := sink.WritePrices(timestamp, prices); err != nil {
+		return 0, fmt.Errorf("failed to write prices: %v", err)
+	}
+	return len(prices), nil
+}
+
+// loadPythBackfillProgress returns the timestamps a progress file records as completed. The file
+// must describe the same backfill as progress; a missing file means nothing was completed.
+func loadPythBackfillProgress(path string, progress *pythBackfillProgress) (map[int64]bool, error) {
+	completed := make(map[int64]bool)
+	if path == "" {
+		return completed, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return completed, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backfill progress: %v", err)
+	}
+
+	var saved pythBackfillProgress
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to parse backfill progress %s: %v", path, err)
+	}
+	sameIDs := len(saved.PriceIDs) == len(progress.PriceIDs)
+	for i := 0; sameIDs && i < len(saved.PriceIDs); i++ {
+		sameIDs = saved.PriceIDs[i] == progress.PriceIDs[i]
+	}
+	if !sameIDs || saved.Start != progress.Start || saved.End != progress.End || saved.Step != progress.Step {
+		return nil, fmt.Errorf("backfill progress %s belongs to another backfill", path)
+	}
+	for _, timestamp := range saved.Completed {
+		completed[timestamp] = true
+	}
+	return completed, nil
+}
+
+// savePythBackfillProgress writes the completed timestamps to a progress file, replacing it
+// atomically so an interrupted write never loses earlier progress
+func savePythBackfillProgress(path string, progress *pythBackfillProgress, completed map[int64]bool) error {
+	if path == "" {
+		return nil
+	}
+	saved := *progress
+	saved.Completed = make([]int64, 0, len(completed))
+	for timestamp := range completed {
+		saved.Completed = append(saved.Completed, timestamp)
+	}
+	sort.Slice(saved.Completed, func(i, j int) bool { return saved.Completed[i] < saved.Completed[j] })
+
+	data, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return fmt.Errorf("failed to write backfill progress: %v", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write backfill progress: %v", err)
+	}
+	return nil
+}
+
+// pythHistorySink loads backfilled prices into the history of a price cache
+type pythHistorySink struct {
+	cacheManager *PriceCacheManager
+}
+
+// NewPythHistorySink returns a sink that adds backfilled prices to the cache history of their
+// feeds, under the Pyth network ID and source like live Hermes prices
+func NewPythHistorySink(cacheManager *PriceCacheManager) PythBackfillSink {
+	return &pythHistorySink{cacheManager: cacheManager}
+}
+
+// WritePrices adds the prices to the cache history
+func (s *pythHistorySink) WritePrices(timestamp int64, prices []*types.PythPrice) error {
+	for _, price := range prices {
+		s.cacheManager.AddHistory(uint64(types.OracleNetworkIDPyth), price.ID, types.SourcePyth, price)
+	}
+	return nil
+}
+
+// PythCSVSink exports backfilled prices as CSV rows of id, symbol, publish_time, price, conf,
+// expo, ema and ema_conf
+type PythCSVSink struct {
+	mu            sync.Mutex
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+// NewPythCSVSink creates a CSV sink writing to w; without header, e.g. when appending to an
+// export of an earlier run, the header row is left out
+func NewPythCSVSink(w io.Writer, header bool) *PythCSVSink {
+	return &PythCSVSink{writer: csv.NewWriter(w), headerWritten: !header}
+}
+
+// WritePrices writes one row per price, after the header on the first call if requested
+func (s *PythCSVSink) WritePrices(timestamp int64, prices []*types.PythPrice) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.headerWritten {
+		s.writer.Write([]string{"id", "symbol", "publish_time", "price", "conf", "expo", "ema", "ema_conf"})
+		s.headerWritten = true
+	}
+	for _, price := range prices {
+		s.writer.Write([]string{
+			price.ID,
+			price.Symbol,
+			strconv.FormatInt(price.PublishTime, 10),
+			bigIntString(price.Price),
+			bigIntString(price.Confidence),
+			strconv.Itoa(price.Exponent),
+			bigIntString(price.EMA),
+			bigIntString(price.EMAConfidence),
+		})
+	}
+	s.writer.Flush()
+	return s.writer.Error()
+}
+
+// bigIntString formats a big.Int, or returns "" for nil
+func bigIntString(value *big.Int) string {
+	if value == nil {
+		return ""
+	}
+	return value.String()
+}
+
+// Backfill loads the prices of the monitored feeds at every step of [start, end] into the cache
+// history, e.g. to seed indicators on startup. Live updates then extend the same histories.
+func (ppm *PythPriceMonitor) Backfill(ctx context.Context, start, end time.Time, step time.Duration) (*PythBackfillReport, error) {
+	ppm.mu.RLock()
+	config := PythBackfillConfig{Start: start, End: end, Step: step, Symbols: make(map[string]string, len(ppm.priceFeeds))}
+	for priceID, symbol := range ppm.priceFeeds {
+		config.PriceIDs = append(config.PriceIDs, priceID)
+		config.Symbols[priceID] = symbol
+	}
+	ppm.mu.RUnlock()
+
+	return BackfillPyth(ctx, ppm.client, config, NewPythHistorySink(ppm.cacheManager))
+}
@@ -0,0 +1,182 @@
+package pricefeed
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/morpheum-labs/pricefeeding/pyth"
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// newHistoricalHermes serves historical updates whose price and publish time are the requested
+// timestamp, failing the timestamps in fail
+func newHistoricalHermes(t *testing.T, fail map[int64]bool) (*pyth.HermesClient, func() []int64) {
+	t.Helper()
+	var mu sync.Mutex
+	var requested []int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timestamp, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/v2/updates/price/"), 10, 64)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		mu.Lock()
+		requested = append(requested, timestamp)
+		failed := fail[timestamp]
+		mu.Unlock()
+		if failed {
+			http.Error(w, "unavailable", http.StatusInternalServerError)
+			return
+		}
+
+		var feeds []string
+		for _, id := range r.URL.Query()["ids[]"] {
+			feeds = append(feeds, fmt.Sprintf(`{"id":"%s","price":{"price":"%d","conf":"1","expo":-2,"publish_time":%d},"ema_price":{"price":"%d","conf":"1","expo":-2,"publish_time":%d}}`,
+				id, timestamp, timestamp, timestamp-1, timestamp))
+		}
+		fmt.Fprintf(w, `{"parsed":[%s]}`, strings.Join(feeds, ","))
+	}))
+	t.Cleanup(server.Close)
+
+	retries := 0
+	client := pyth.NewHermesClient(server.URL, &pyth.HermesClientConfig{HTTPRetries: &retries})
+	return client, func() []int64 {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int64(nil), requested...)
+	}
+}
+
+func TestBackfillPythResumesIntoHistoryAndCSV(t *testing.T) {
+	client, requested := newHistoricalHermes(t, map[int64]bool{1120: true})
+	config := PythBackfillConfig{
+		PriceIDs:     []string{"0xABC1"},
+		Symbols:      map[string]string{"abc1": "ABC/USD"},
+		Start:        time.Unix(1000, 0),
+		End:          time.Unix(1540, 0),
+		Step:         time.Minute,
+		Concurrency:  3,
+		ProgressFile: filepath.Join(t.TempDir(), "progress.json"),
+	}
+
+	cacheManager := NewPriceCacheManager()
+	report, err := BackfillPyth(context.Background(), client, config, NewPythHistorySink(cacheManager))
+	if err != nil {
+		t.Fatalf("BackfillPyth failed: %v", err)
+	}
+	if report.Timestamps != 10 || report.Fetched != 9 || report.Prices != 9 || len(report.Failed) != 1 || report.Failed[0] != 1120 {
+		t.Fatalf("Unexpected report %+v", report)
+	}
+
+	networkID := uint64(types.OracleNetworkIDPyth)
+	history := cacheManager.GetHistory(networkID, "abc1", types.SourcePyth, time.Time{}, time.Time{})
+	if len(history) != 9 {
+		t.Fatalf("Expected 9 historical prices, got %d", len(history))
+	}
+	for i := 1; i < len(history); i++ {
+		if !history[i-1].GetTimestamp().Before(history[i].GetTimestamp()) {
+			t.Fatalf("Expected history ordered by publish time, got %v before %v", history[i-1].GetTimestamp(), history[i].GetTimestamp())
+		}
+	}
+	if first := history[0].(*types.PythPrice); first.Price.Int64() != 1000 || first.Symbol != "ABC/USD" || first.EMA.Int64() != 999 {
+		t.Errorf("Unexpected first price %+v", first)
+	}
+	if window := cacheManager.GetHistory(networkID, "abc1", types.SourcePyth, time.Unix(1060, 0), time.Unix(1180, 0)); len(window) != 2 {
+		t.Errorf("Expected 1060 and 1180 in the window, got %d prices", len(window))
+	}
+
+	// Live updates extend the seeded history, once per publish time
+	live := &types.PythPrice{ID: "abc1", Price: big.NewInt(1600), Exponent: -2, PublishTime: 1600, Timestamp: time.Unix(1601, 0)}
+	cacheManager.UpdatePrice(networkID, "abc1", types.SourcePyth, live)
+	cacheManager.UpdatePrice(networkID, "abc1", types.SourcePyth, live)
+	if history := cacheManager.GetHistory(networkID, "abc1", types.SourcePyth, time.Time{}, time.Time{}); len(history) != 10 {
+		t.Errorf("Expected the live price to be added once, got %d prices", len(history))
+	}
+
+	// The second run only fetches the failed timestamp
+	client, requested = newHistoricalHermes(t, nil)
+	var buf bytes.Buffer
+	report, err = BackfillPyth(context.Background(), client, config, NewPythCSVSink(&buf, true))
+	if err != nil {
+		t.Fatalf("Resumed BackfillPyth failed: %v", err)
+	}
+	if report.Resumed != 9 || report.Fetched != 1 || len(report.Failed) != 0 {
+		t.Errorf("Unexpected resumed report %+v", report)
+	}
+	if got := requested(); len(got) != 1 || got[0] != 1120 {
+		t.Errorf("Expected only 1120 to be requested, got %v", got)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(rows) != 2 || rows[1][0] != "abc1" || rows[1][2] != "1120" || rows[1][3] != "1120" {
+		t.Errorf("Unexpected CSV %v (%v)", rows, err)
+	}
+
+	// A progress file of another backfill is rejected
+	config.Step = 2 * time.Minute
+	if _, err := BackfillPyth(context.Background(), client, config, NewPythCSVSink(&buf, true)); err == nil {
+		t.Error("Expected a progress file of another backfill to be rejected")
+	}
+}
+
+// progressCheckingSink checks on every write that the progress file already records every
+// timestamp written before, as a run killed at that point would leave it
+type progressCheckingSink struct {
+	t        *testing.T
+	config   PythBackfillConfig
+	progress *pythBackfillProgress
+	written  []int64
+}
+
+func (s *progressCheckingSink) WritePrices(timestamp int64, prices []*types.PythPrice) error {
+	completed, err := loadPythBackfillProgress(s.config.ProgressFile, s.progress)
+	if err != nil {
+		s.t.Fatalf("Failed to load progress: %v", err)
+	}
+	for _, written := range s.written {
+		if !completed[written] {
+			s.t.Errorf("Timestamp %d was written before %d but is not in the progress file", written, timestamp)
+		}
+	}
+	s.written = append(s.written, timestamp)
+	return nil
+}
+
+func TestBackfillPythSavesProgressAfterEveryTimestamp(t *testing.T) {
+	client, _ := newHistoricalHermes(t, nil)
+	config := PythBackfillConfig{
+		PriceIDs:     []string{"abc1"},
+		Start:        time.Unix(1000, 0),
+		End:          time.Unix(1000+14*60, 0),
+		Step:         time.Minute,
+		Concurrency:  1,
+		ProgressFile: filepath.Join(t.TempDir(), "progress.json"),
+	}
+	sink := &progressCheckingSink{
+		t:        t,
+		config:   config,
+		progress: &pythBackfillProgress{PriceIDs: []string{"abc1"}, Start: 1000, End: 1000 + 14*60, Step: 60},
+	}
+
+	report, err := BackfillPyth(context.Background(), client, config, sink)
+	if err != nil {
+		t.Fatalf("BackfillPyth failed: %v", err)
+	}
+	if report.Fetched != 15 || len(sink.written) != 15 {
+		t.Fatalf("Expected 15 timestamps written, got %d (%+v)", len(sink.written), report)
+	}
+	if _, err := os.Stat(config.ProgressFile); err != nil {
+		t.Errorf("Expected the progress file to be saved: %v", err)
+	}
+}
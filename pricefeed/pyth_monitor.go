@@ -38,6 +38,18 @@ type PythPriceMonitor struct {
 	interval      time.Duration
 	priceFeeds    map[string]string // priceID -> symbol mapping
 	immediateMode bool              // If true, prints prices immediately when received
+	cycleTimeout  time.Duration     // Time budget of one fetch cycle
+	twapWindows   []time.Duration   // TWAP windows fetched every cycle, none by default
+
+	// WebSocket streaming, with HTTP polling while the socket is down
+	wsClient      *pyth.WebSocketClient // nil when streaming is disabled
+	wsStarted     bool                  // The socket was started and has not given up since
+	wsRestarting  bool                  // A restart of the socket is in progress
+	wsRetryAt     time.Time             // Earliest time of the next restart
+	streamRetry   time.Duration         // How often a socket that gave up is restarted
+	streamingMode bool                  // Whether updates currently arrive over the socket
+	streamSince   time.Time             // When the socket was last seen connected after being down
+	streamUpdates map[string]time.Time  // priceID -> time of the last update received over the socket
 }
 
 // NewPythPriceMonitor creates a new Pyth price monitor
@@ -59,7 +71,9 @@ func NewPythPriceMonitor(cacheManager *PriceCacheManager, endpoint string, inter
 		stopChan:      make(chan struct{}),
 		interval:      interval,
 		priceFeeds:    make(map[string]string),
+		streamUpdates: make(map[string]time.Time),
 		immediateMode: immediateMode,
+		cycleTimeout:  10 * time.Second,
 	}
 }
 
@@ -72,6 +86,15 @@ func (ppm *PythPriceMonitor) AddPriceFeed(priceID, symbol string) {
 	networkID := uint64(types.OracleNetworkIDPyth)
 	ppm.cacheManager.AddFeed(networkID, priceID, types.SourcePyth)
 	log.Printf("Added Pyth price feed: %s (%s)", symbol, priceID)
+
+	// A running socket is resubscribed with the new feed
+	if ppm.wsClient != nil && ppm.wsClient.IsConnected() {
+		go func() {
+			if err := ppm.subscribeStream(); err != nil {
+				log.Printf("Failed to subscribe Pyth feed %s over the WebSocket: %v", priceID, err)
+			}
+		}()
+	}
 }
 
 // GetPrice retrieves the latest price for a specific feed
@@ -110,7 +133,7 @@ func (ppm *PythPriceMonitor) GetAllPrices() map[string]*types.PythPrice {
 }
 
 // fetchPriceData fetches price data from Pyth for all monitored feeds
-func (ppm *PythPriceMonitor) fetchPriceData() error {
+func (ppm *PythPriceMonitor) fetchPriceData(ctx context.Context) error {
 	ppm.mu.RLock()
 	priceIDs := make([]pyth.HexString, 0, len(ppm.priceFeeds))
 	for priceID := range ppm.priceFeeds {
@@ -118,11 +141,20 @@ func (ppm *PythPriceMonitor) fetchPriceData() error {
 	}
 	ppm.mu.RUnlock()
 
+	return ppm.fetchPrices(ctx, priceIDs)
+}
+
+// fetchPrices fetches price data from Pyth for the given feeds
+func (ppm *PythPriceMonitor) fetchPrices(ctx context.Context, priceIDs []pyth.HexString) error {
+	ppm.mu.RLock()
+	cycleTimeout := ppm.cycleTimeout
+	ppm.mu.RUnlock()
+
 	if len(priceIDs) == 0 {
 		return fmt.Errorf("no price feeds to monitor")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, cycleTimeout)
 	defer cancel()
 
 	// Get latest price updates with parsed data
@@ -137,32 +169,54 @@ func (ppm *PythPriceMonitor) fetchPriceData() error {
 
 	// Process each price feed
 	for _, feed := range priceUpdate.Parsed {
-		pythPriceData := ppm.convertPythFeedToPriceData(feed)
+		ppm.storePriceFeed(feed)
+	}
 
-		// Update cache
-		networkID := uint64(types.OracleNetworkIDPyth)
-		ppm.cacheManager.UpdatePrice(networkID, feed.ID, types.SourcePyth, pythPriceData)
+	return nil
+}
 
-		// Update lastSaved timestamp in cache manager
-		ppm.cacheManager.UpdateLastSaved()
+// storePriceFeed writes a price feed received over HTTP or the WebSocket to the cache, with its
+// EMA as a separate series
+func (ppm *PythPriceMonitor) storePriceFeed(feed pyth.PriceFeed) {
+	pythPriceData := ppm.convertPythFeedToPriceData(feed)
 
-		// Print immediately if in immediate mode
-		if ppm.immediateMode {
-			ppm.printPriceUpdate(pythPriceData)
-		}
+	// Update cache
+	networkID := uint64(types.OracleNetworkIDPyth)
+	ppm.cacheManager.UpdatePrice(networkID, feed.ID, types.SourcePyth, pythPriceData)
+	if emaPrice := pythFeedToEMAPrice(feed, pythPriceData.Symbol); emaPrice != nil {
+		ppm.cacheManager.UpdatePrice(networkID, feed.ID, types.SourcePythEMA, emaPrice)
 	}
 
-	return nil
+	// Update lastSaved timestamp in cache manager
+	ppm.cacheManager.UpdateLastSaved()
+
+	// Print immediately if in immediate mode
+	ppm.mu.RLock()
+	immediateMode := ppm.immediateMode
+	ppm.mu.RUnlock()
+	if immediateMode {
+		ppm.printPriceUpdate(pythPriceData)
+	}
 }
 
 // convertPythFeedToPriceData converts a Pyth PriceFeed to our PythPrice structure
 func (ppm *PythPriceMonitor) convertPythFeedToPriceData(feed pyth.PriceFeed) *types.PythPrice {
+	ppm.mu.RLock()
+	symbol := ppm.priceFeeds[feed.ID]
+	ppm.mu.RUnlock()
+
+	return pythFeedToPrice(feed, symbol)
+}
+
+// pythFeedToPrice converts a Pyth PriceFeed to a PythPrice with the given symbol (may be empty)
+func pythFeedToPrice(feed pyth.PriceFeed, symbol string) *types.PythPrice {
 	// Convert price string to big.Int
 	price, _ := new(big.Int).SetString(feed.Price.Price, 10)
 	confidence, _ := new(big.Int).SetString(feed.Price.Conf, 10)
 
 	pythPriceData := &types.PythPrice{
 		ID:          feed.ID,
+		Symbol:      symbol,
 		Price:       price,
 		Confidence:  confidence,
 		Exponent:    feed.Price.Expo,
@@ -172,24 +226,41 @@ func (ppm *PythPriceMonitor) convertPythFeedToPriceData(feed pyth.PriceFeed) *ty
 		NetworkID:   uint64(types.OracleNetworkIDPyth),
 	}
 
-	// Add symbol if available
-	ppm.mu.RLock()
-	if symbol, exists := ppm.priceFeeds[feed.ID]; exists {
-		pythPriceData.Symbol = symbol
-	}
-	ppm.mu.RUnlock()
-
 	// Add EMA data if available
-	if feed.Ema.Price != "" {
-		ema, _ := new(big.Int).SetString(feed.Ema.Price, 10)
-		emaConf, _ := new(big.Int).SetString(feed.Ema.Conf, 10)
-		pythPriceData.EMA = ema
-		pythPriceData.EMAConfidence = emaConf
+	if emaPrice := pythFeedToEMAPrice(feed, symbol); emaPrice != nil {
+		pythPriceData.EMA = emaPrice.Price
+		pythPriceData.EMAConfidence = emaPrice.Confidence
 	}
 
 	return pythPriceData
 }
 
+// pythFeedToEMAPrice converts the EMA of a Pyth PriceFeed to a PythEMAPrice, or returns nil when
+// the feed has none. Hermes sends it as ema_price or emaPrice, both decoded into EmaPrice; Ema is
+// read for older payloads.
+func pythFeedToEMAPrice(feed pyth.PriceFeed, symbol string) *types.PythEMAPrice {
+	ema := feed.EmaPrice
+	if ema.Price == "" {
+		ema = pyth.Price{Price: feed.Ema.Price, Conf: feed.Ema.Conf, Expo: feed.Ema.Expo, PublishTime: feed.Ema.PublishTime}
+	}
+	price, ok := new(big.Int).SetString(ema.Price, 10)
+	if !ok {
+		return nil
+	}
+	confidence, _ := new(big.Int).SetString(ema.Conf, 10)
+
+	return &types.PythEMAPrice{
+		ID:          feed.ID,
+		Symbol:      symbol,
+		Price:       price,
+		Confidence:  confidence,
+		Exponent:    ema.Expo,
+		PublishTime: ema.PublishTime,
+		Timestamp:   time.Now(),
+		NetworkID:   uint64(types.OracleNetworkIDPyth),
+	}
+}
+
 // Legacy conversion methods (deprecated, kept for backward compatibility)
 // These are no longer needed with the unified cache system
 
@@ -227,34 +298,49 @@ func (ppm *PythPriceMonitor) printPriceUpdate(priceData *types.PythPrice) {
 	fmt.Printf("   Slot: %d\n", priceData.Slot)
 
 	if priceData.EMA != nil {
-		actualEMA := new(big.Float).SetInt(priceData.EMA)
-		actualEMA.Quo(actualEMA, exponent)
-		fmt.Printf("   EMA: %s\n", actualEMA.Text('f', 8))
+		fmt.Printf("   EMA: %s\n", formatPythPrice(priceData.EMA, priceData.Exponent))
 	}
 
 	fmt.Printf("   Last Saved: %s\n", ppm.cacheManager.GetLastSaved().Format("15:04:05"))
 	fmt.Println("   " + strings.Repeat("-", 50))
 }
 
-// Start begins monitoring Pyth price feeds
-func (ppm *PythPriceMonitor) Start() {
+// Start begins monitoring Pyth price feeds until ctx is cancelled or Stop is called.
+// Cancelling ctx or calling Stop also aborts any request in flight. With streaming enabled,
+// feeds are polled over HTTP only while the WebSocket is down or stops delivering their updates.
+func (ppm *PythPriceMonitor) Start(ctx context.Context) {
+	ctx, cancel := stopContext(ctx, ppm.stopChan)
+	defer cancel()
+
 	log.Printf("Starting Pyth price monitor with %v interval (immediate mode: %v)", ppm.interval, ppm.immediateMode)
 
 	ticker := time.NewTicker(ppm.interval)
 	defer ticker.Stop()
 
+	if ppm.wsClient != nil {
+		defer ppm.wsClient.Disconnect()
+		ppm.checkStream(time.Now()) // Starts the socket in the background
+	}
+
 	// Initial update
-	if err := ppm.fetchPriceData(); err != nil {
+	if err := ppm.fetchPriceData(ctx); err != nil {
 		log.Printf("Initial price fetch failed: %v", err)
 	}
+	ppm.updateTWAPs(ctx)
 
 	for {
 		select {
-		case <-ppm.stopChan:
+		case <-ctx.Done():
 			log.Println("Stopping Pyth price monitor")
 			return
 		case <-ticker.C:
-			if err := ppm.fetchPriceData(); err != nil {
+			// The socket carries no TWAPs, so they are polled either way
+			ppm.updateTWAPs(ctx)
+			poll := ppm.checkStream(time.Now())
+			if len(poll) == 0 {
+				continue
+			}
+			if err := ppm.fetchPrices(ctx, poll); err != nil {
 				log.Printf("Failed to fetch price data: %v", err)
 			}
 		}
@@ -284,6 +370,13 @@ func (ppm *PythPriceMonitor) PrintLastSavedStatus() {
 	fmt.Println("   " + strings.Repeat("-", 50))
 }
 
+// SetCycleTimeout sets the time budget of one fetch cycle (default 10s)
+func (ppm *PythPriceMonitor) SetCycleTimeout(timeout time.Duration) {
+	ppm.mu.Lock()
+	defer ppm.mu.Unlock()
+	ppm.cycleTimeout = timeout
+}
+
 // SetImmediateMode sets whether to print prices immediately
 func (ppm *PythPriceMonitor) SetImmediateMode(immediate bool) {
 	ppm.mu.Lock()
@@ -1,10 +1,18 @@
 package pricefeed
 
 import (
+	"context"
+	"fmt"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
+	"github.com/morpheum-labs/pricefeeding/pyth"
 	"github.com/morpheum-labs/pricefeeding/types"
 )
 
@@ -130,3 +138,299 @@ func TestImmediateModeToggle(t *testing.T) {
 		t.Errorf("Expected immediate mode to be true, got %v", monitor.immediateMode)
 	}
 }
+
+// fakeHermes serves the latest price over HTTP and streams a different price over its WebSocket
+type fakeHermes struct {
+	*httptest.Server
+	mu     sync.Mutex
+	accept bool // Whether WebSocket upgrades are accepted
+	silent bool // Whether open connections stop sending updates
+	conns  []*websocket.Conn
+	polls  int
+}
+
+func newFakeHermes(accept bool) *fakeHermes {
+	h := &fakeHermes{accept: accept}
+	upgrader := websocket.Upgrader{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/updates/price/latest", func(w http.ResponseWriter, r *http.Request) {
+		h.mu.Lock()
+		h.polls++
+		h.mu.Unlock()
+		fmt.Fprint(w, `{"parsed":[{"id":"abc1","price":{"price":"100","conf":"1","expo":-2,"publishTime":1}}]}`)
+	})
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		h.mu.Lock()
+		accept := h.accept
+		h.mu.Unlock()
+		if !accept {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		h.mu.Lock()
+		h.conns = append(h.conns, conn)
+		h.mu.Unlock()
+
+		var subscribe map[string]interface{}
+		if err := conn.ReadJSON(&subscribe); err != nil {
+			return
+		}
+		for {
+			h.mu.Lock()
+			silent := h.silent
+			h.mu.Unlock()
+			if silent {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
+			err := conn.WriteJSON(map[string]interface{}{
+				"type": "price_update",
+				"price_feed": map[string]interface{}{
+					"id":        "abc1",
+					"price":     map[string]interface{}{"price": "200", "conf": "1", "expo": -2, "publish_time": 2},
+					"ema_price": map[string]interface{}{"price": "190", "conf": "1", "expo": -2, "publish_time": 2},
+				},
+			})
+			if err != nil {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+	h.Server = httptest.NewServer(mux)
+	return h
+}
+
+// setAccept accepts or rejects WebSocket upgrades; rejecting also drops open connections
+func (h *fakeHermes) setAccept(accept bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.accept = accept
+	if !accept {
+		for _, conn := range h.conns {
+			conn.Close()
+		}
+		h.conns = nil
+	}
+}
+
+// setSilent stops or resumes updates on open connections without closing them
+func (h *fakeHermes) setSilent(silent bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.silent = silent
+}
+
+func (h *fakeHermes) pollCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.polls
+}
+
+// waitForPrice waits until the monitor caches the given price and streaming state
+func waitForPrice(t *testing.T, monitor *PythPriceMonitor, price int64, streaming bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if cached, err := monitor.GetPrice("abc1"); err == nil && cached.Price.Int64() == price && monitor.IsStreaming() == streaming {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cached, _ := monitor.GetPrice("abc1")
+	t.Fatalf("Expected price %d with streaming %v, got %+v with streaming %v", price, streaming, cached, monitor.IsStreaming())
+}
+
+func TestPythMonitorStreamsWithHTTPFallback(t *testing.T) {
+	hermes := newFakeHermes(true)
+	defer hermes.Close()
+
+	monitor := NewPythPriceMonitor(NewPriceCacheManager(), hermes.URL, 20*time.Millisecond, false)
+	monitor.AddPriceFeed("abc1", "BTC/USD")
+	monitor.EnableStreaming(&pyth.WebSocketConfig{
+		URL:            "ws" + strings.TrimPrefix(hermes.URL, "http") + "/ws",
+		ReconnectDelay: 10 * time.Millisecond,
+		MaxReconnects:  1,
+	})
+	monitor.streamRetry = 20 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go monitor.Start(ctx)
+
+	// Updates arrive over the socket and polling stops
+	waitForPrice(t, monitor, 200, true)
+	polls := hermes.pollCount()
+	time.Sleep(100 * time.Millisecond)
+	if extra := hermes.pollCount() - polls; extra > 1 {
+		t.Errorf("Expected no polling while streaming, got %d polls", extra)
+	}
+
+	// The socket gives up reconnecting and polling takes over
+	hermes.setAccept(false)
+	waitForPrice(t, monitor, 100, false)
+
+	// Once the socket can connect again, streaming resumes
+	hermes.setAccept(true)
+	waitForPrice(t, monitor, 200, true)
+}
+
+func TestPythMonitorPollsUntilWebSocketConnects(t *testing.T) {
+	hermes := newFakeHermes(false)
+	defer hermes.Close()
+
+	monitor := NewPythPriceMonitor(NewPriceCacheManager(), hermes.URL, 20*time.Millisecond, false)
+	monitor.AddPriceFeed("abc1", "BTC/USD")
+	monitor.EnableStreaming(&pyth.WebSocketConfig{URL: "ws" + strings.TrimPrefix(hermes.URL, "http") + "/ws"})
+	monitor.streamRetry = 20 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go monitor.Start(ctx)
+
+	waitForPrice(t, monitor, 100, false)
+	hermes.setAccept(true)
+	waitForPrice(t, monitor, 200, true)
+}
+
+func TestPythMonitorPollsWhenConnectedStreamGoesQuiet(t *testing.T) {
+	hermes := newFakeHermes(true)
+	defer hermes.Close()
+	hermes.setSilent(true)
+
+	monitor := NewPythPriceMonitor(NewPriceCacheManager(), hermes.URL, 20*time.Millisecond, false)
+	monitor.AddPriceFeed("abc1", "BTC/USD")
+	monitor.EnableStreaming(&pyth.WebSocketConfig{URL: "ws" + strings.TrimPrefix(hermes.URL, "http") + "/ws"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go monitor.Start(ctx)
+
+	// A connected socket that delivers nothing is polled around once it is 2 intervals quiet
+	deadline := time.Now().Add(5 * time.Second)
+	for hermes.pollCount() < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if monitor.IsStreaming() {
+		t.Fatalf("Expected polling while the connected socket is quiet")
+	}
+	waitForPrice(t, monitor, 100, false)
+
+	// Updates resume and polling stops
+	hermes.setSilent(false)
+	waitForPrice(t, monitor, 200, true)
+
+	// They stop again on the open connection and polling takes over
+	hermes.setSilent(true)
+	waitForPrice(t, monitor, 100, false)
+}
+
+func TestCachePriceUpdates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"parsed":[{"id":"abc1","price":{"price":"12345","conf":"6","expo":-2,"publish_time":1700000000},"ema_price":{"price":"12300","conf":"7","expo":-2,"publish_time":1700000000}}]}`+"\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	stream, err := pyth.NewHermesClient(server.URL, nil).StreamPriceUpdates(context.Background(), []pyth.HexString{"abc1"}, nil)
+	if err != nil {
+		t.Fatalf("StreamPriceUpdates failed: %v", err)
+	}
+
+	cacheManager := NewPriceCacheManager()
+	done := make(chan struct{})
+	go func() {
+		CachePriceUpdates(cacheManager, stream, map[string]string{"abc1": "BTC/USD"})
+		close(done)
+	}()
+
+	networkID := uint64(types.OracleNetworkIDPyth)
+	deadline := time.Now().Add(5 * time.Second)
+	var cached types.PriceInfo
+	for cached == nil && time.Now().Before(deadline) {
+		cached, _ = cacheManager.GetPrice(networkID, "abc1", types.SourcePyth)
+		time.Sleep(5 * time.Millisecond)
+	}
+	price, ok := cached.(*types.PythPrice)
+	if !ok {
+		t.Fatalf("Expected a cached PythPrice, got %T", cached)
+	}
+	if price.Symbol != "BTC/USD" || price.Price.Int64() != 12345 || price.PublishTime != 1700000000 || price.EMA.Int64() != 12300 {
+		t.Errorf("Unexpected cached price %+v", price)
+	}
+	if ema, err := cacheManager.GetPrice(networkID, "abc1", types.SourcePythEMA); err != nil || ema.(*types.PythEMAPrice).Price.Int64() != 12300 {
+		t.Errorf("Expected the EMA series to be cached, got %+v (%v)", ema, err)
+	}
+
+	stream.Close()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected CachePriceUpdates to return once the stream is closed")
+	}
+}
+
+func TestPythMonitorCachesEMAAndTWAPSeries(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/updates/price/latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"parsed":[{"id":"abc1","price":{"price":"100","conf":"1","expo":-2,"publishTime":1700000000},"emaPrice":{"price":"98","conf":"2","expo":-2,"publishTime":1700000000}}]}`)
+	})
+	for _, window := range []int{60, 300} {
+		window := window
+		mux.HandleFunc(fmt.Sprintf("/v2/updates/twap/%d/latest", window), func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"binary":{"encoding":"hex","data":["504e4155"]},"parsed":[{"id":"abc1","start_timestamp":%d,"end_timestamp":1700000000,"twap":{"price":"%d","conf":"3","expo":-2,"publish_time":1700000000},"down_slots_ratio":"0.05"}]}`,
+				1700000000-window, 90+window/60)
+		})
+	}
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	monitor := NewPythPriceMonitor(NewPriceCacheManager(), server.URL, time.Minute, false)
+	monitor.AddPriceFeed("abc1", "BTC/USD")
+	if err := monitor.SetTWAPWindows(5*time.Minute, time.Minute, time.Minute); err != nil {
+		t.Fatalf("SetTWAPWindows failed: %v", err)
+	}
+	if err := monitor.SetTWAPWindows(11 * time.Minute); err == nil {
+		t.Error("Expected a window beyond MaxPythTWAPWindow to be rejected")
+	}
+
+	if err := monitor.fetchPriceData(context.Background()); err != nil {
+		t.Fatalf("fetchPriceData failed: %v", err)
+	}
+	monitor.updateTWAPs(context.Background())
+
+	// The camelCase emaPrice fills the EMA of the spot price and its own series
+	spot, err := monitor.GetPrice("abc1")
+	if err != nil || spot.EMA == nil || spot.EMA.Int64() != 98 {
+		t.Fatalf("Expected the spot price to carry EMA 98, got %+v (%v)", spot, err)
+	}
+	ema, err := monitor.GetEMAPrice("abc1")
+	if err != nil || ema.Price.Int64() != 98 || ema.Confidence.Int64() != 2 || ema.Symbol != "BTC/USD" || ema.GetSource() != types.SourcePythEMA {
+		t.Errorf("Unexpected EMA price %+v (%v)", ema, err)
+	}
+
+	for window, want := range map[time.Duration]int64{time.Minute: 91, 5 * time.Minute: 95} {
+		twap, err := monitor.GetTWAPPrice("abc1", window)
+		if err != nil {
+			t.Fatalf("Expected a %v TWAP: %v", window, err)
+		}
+		if twap.Price.Int64() != want || twap.Window != window || twap.EndTime-twap.StartTime != int64(window/time.Second) || twap.DownSlotsRatio != 0.05 {
+			t.Errorf("Unexpected %v TWAP %+v", window, twap)
+		}
+	}
+
+	// Spot, EMA and every TWAP window are separate series
+	networkID := uint64(types.OracleNetworkIDPyth)
+	if twaps := monitor.GetCacheManager().GetAllPricesBySource(networkID, types.SourcePythTWAP); len(twaps) != 2 {
+		t.Errorf("Expected 2 TWAP series, got %d", len(twaps))
+	}
+	if spots := monitor.GetAllPrices(); len(spots) != 1 {
+		t.Errorf("Expected 1 spot price, got %d", len(spots))
+	}
+}
@@ -0,0 +1,338 @@
+package pricefeed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/morpheum-labs/pricefeeding/pyth"
+	"github.com/morpheum-labs/pricefeeding/pyth/pythevm"
+	"github.com/morpheum-labs/pricefeeding/rpcscan"
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// DefaultPythOnChainMaxAge is how old the price a Pyth contract holds may be before it is stale
+const DefaultPythOnChainMaxAge = time.Minute
+
+// PythOnChainMonitor reads the prices Pyth contracts hold on EVM networks, i.e. the last updates
+// pushed there, and caches them as types.SourcePythOnChain next to the Hermes prices
+type PythOnChainMonitor struct {
+	cacheManager *PriceCacheManager
+	clients      *evmClients // Client per network, switched through the network configuration
+	mu           sync.RWMutex
+	stopChan     chan struct{}
+	interval     time.Duration
+
+	contracts map[uint64]common.Address    // networkID -> Pyth contract address
+	feeds     map[uint64]map[string]string // networkID -> price ID -> symbol
+	maxAge    time.Duration                // Prices older than this are read as stale
+}
+
+// PythOnChainLag compares the price a Pyth contract holds for a feed with the latest Hermes price
+type PythOnChainLag struct {
+	NetworkID          uint64
+	PriceID            string
+	Symbol             string
+	OnChainPublishTime int64
+	HermesPublishTime  int64
+	Lag                time.Duration // How far the on-chain publish time is behind Hermes
+	Stale              bool          // The on-chain price is older than the monitor's max age
+}
+
+// NewPythOnChainMonitor creates a new on-chain Pyth price monitor
+// cacheManager: the price cache manager to use (required)
+// interval: how often to read prices
+func NewPythOnChainMonitor(cacheManager *PriceCacheManager, interval time.Duration) *PythOnChainMonitor {
+	return &PythOnChainMonitor{
+		cacheManager: cacheManager,
+		clients:      newEVMClients(),
+		stopChan:     make(chan struct{}),
+		interval:     interval,
+		contracts:    make(map[uint64]common.Address),
+		feeds:        make(map[uint64]map[string]string),
+		maxAge:       DefaultPythOnChainMaxAge,
+	}
+}
+
+// AddClient adds an Ethereum client for a specific network
+func (pm *PythOnChainMonitor) AddClient(networkID uint64, client bind.ContractBackend) {
+	pm.clients.set(networkID, client)
+}
+
+// UpdateClient updates an Ethereum client for a specific network (used after RPC switching)
+func (pm *PythOnChainMonitor) UpdateClient(networkID uint64, client bind.ContractBackend) {
+	pm.clients.set(networkID, client)
+}
+
+// SetNetworkConfig sets the network configuration for RPC switching
+func (pm *PythOnChainMonitor) SetNetworkConfig(networkConfig *rpcscan.NetworkConfiguration) {
+	pm.clients.setNetworkConfig(networkConfig)
+}
+
+// SetContract sets the Pyth contract of a network
+func (pm *PythOnChainMonitor) SetContract(networkID uint64, address string) error {
+	if !common.IsHexAddress(address) {
+		return fmt.Errorf("invalid Pyth contract address %q for network %d", address, networkID)
+	}
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.contracts[networkID] = common.HexToAddress(address)
+	return nil
+}
+
+// SetMaxAge sets how old an on-chain price may be before it is marked stale
+func (pm *PythOnChainMonitor) SetMaxAge(maxAge time.Duration) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.maxAge = maxAge
+}
+
+// AddPriceFeed adds a Pyth price feed to read on a network
+func (pm *PythOnChainMonitor) AddPriceFeed(networkID uint64, priceID, symbol string) error {
+	if _, err := pythevm.ParsePriceID(priceID); err != nil {
+		return err
+	}
+	id := normalizePythPriceID(priceID)
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if pm.feeds[networkID] == nil {
+		pm.feeds[networkID] = make(map[string]string)
+	}
+	pm.feeds[networkID][id] = symbol
+	pm.cacheManager.AddFeed(networkID, id, types.SourcePythOnChain)
+	return nil
+}
+
+// GetPrice retrieves the cached on-chain price of a feed
+func (pm *PythOnChainMonitor) GetPrice(networkID uint64, priceID string) (*types.PythOnChainPrice, error) {
+	priceInfo, err := pm.cacheManager.GetPrice(networkID, normalizePythPriceID(priceID), types.SourcePythOnChain)
+	if err != nil {
+		return nil, err
+	}
+	if onChainPrice, ok := priceInfo.(*types.PythOnChainPrice); ok {
+		return onChainPrice, nil
+	}
+	return nil, fmt.Errorf("price info is not on-chain Pyth data")
+}
+
+// GetAllPrices retrieves the cached on-chain prices of a network by price ID
+func (pm *PythOnChainMonitor) GetAllPrices(networkID uint64) map[string]*types.PythOnChainPrice {
+	results := make(map[string]*types.PythOnChainPrice)
+	for priceID, priceInfo := range pm.cacheManager.GetAllPricesBySource(networkID, types.SourcePythOnChain) {
+		if onChainPrice, ok := priceInfo.(*types.PythOnChainPrice); ok {
+			results[priceID] = onChainPrice
+		}
+	}
+	return results
+}
+
+// rpcClientProvider is a client that exposes its JSON-RPC client, like *ethclient.Client
+type rpcClientProvider interface {
+	Client() *rpc.Client
+}
+
+// updateAllPrices reads every feed of every network with a client and a Pyth contract. A network
+// whose reads fail is switched to its best RPC endpoint; the switch runs outside the cycle's time
+// budget, so it still happens when the reads timed out.
+func (pm *PythOnChainMonitor) updateAllPrices(ctx context.Context) {
+	pm.mu.RLock()
+	jobs := make(map[uint64][]pythevm.ReadPriceOptions)
+	for networkID, feeds := range pm.feeds {
+		client, hasClient := pm.clients.get(networkID)
+		contract, hasContract := pm.contracts[networkID]
+		if !hasClient || !hasContract {
+			continue
+		}
+		for priceID, symbol := range feeds {
+			jobs[networkID] = append(jobs[networkID], pythevm.ReadPriceOptions{
+				NetworkID: networkID,
+				Contract:  contract,
+				PriceID:   priceID,
+				Symbol:    symbol,
+				Caller:    client,
+				MaxAge:    pm.maxAge,
+			})
+		}
+	}
+	pm.mu.RUnlock()
+
+	cycleCtx, cancel := context.WithTimeout(ctx, pm.interval)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for networkID, networkJobs := range jobs {
+		wg.Add(1)
+		go func(networkID uint64, networkJobs []pythevm.ReadPriceOptions) {
+			defer wg.Done()
+			if !pm.readNetwork(cycleCtx, networkJobs) || ctx.Err() != nil {
+				return
+			}
+			if err := pm.clients.switchRPC(ctx, networkID); err != nil {
+				log.Printf("%v", err)
+			}
+		}(networkID, networkJobs)
+	}
+	wg.Wait()
+}
+
+// readNetwork reads the feeds of one network and caches their prices. Clients that expose their
+// JSON-RPC client send every call of the cycle in batches, others one read at a time per feed.
+// It returns whether any read failed for a reason other than a feed missing from the contract.
+func (pm *PythOnChainMonitor) readNetwork(ctx context.Context, jobs []pythevm.ReadPriceOptions) bool {
+	var prices []*types.PythOnChainPrice
+	var errs []error
+	if provider, ok := jobs[0].Caller.(rpcClientProvider); ok {
+		var err error
+		prices, errs, err = pythevm.ReadPrices(ctx, provider.Client(), jobs)
+		if err != nil {
+			log.Printf("Failed to read on-chain Pyth prices on network %d: %v", jobs[0].NetworkID, err)
+			return true
+		}
+	} else {
+		prices, errs = readEach(ctx, jobs)
+	}
+
+	failed := false
+	for i, job := range jobs {
+		if err := errs[i]; err != nil {
+			log.Printf("Failed to read on-chain Pyth price %s (%s) on network %d: %v", job.Symbol, job.PriceID, job.NetworkID, err)
+			// A feed missing from the contract is a configuration issue, not an RPC failure
+			if !errors.Is(err, pythevm.ErrPriceFeedNotFound) {
+				failed = true
+			}
+			continue
+		}
+
+		price := prices[i]
+		pm.cacheManager.UpdatePrice(job.NetworkID, price.ID, types.SourcePythOnChain, price)
+		if price.Stale {
+			log.Printf("On-chain Pyth price %s (%s) on network %d is stale, published at %s",
+				price.Symbol, price.ID, job.NetworkID, time.Unix(price.PublishTime, 0).Format(time.RFC3339))
+		}
+	}
+	return failed
+}
+
+// readEach reads feeds one ReadPrice at a time, up to 10 concurrently, returning a price or an
+// error per feed
+func readEach(ctx context.Context, jobs []pythevm.ReadPriceOptions) ([]*types.PythOnChainPrice, []error) {
+	prices := make([]*types.PythOnChainPrice, len(jobs))
+	errs := make([]error, len(jobs))
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, 10) // Limit concurrent requests
+	for i, job := range jobs {
+		wg.Add(1)
+		go func(i int, job pythevm.ReadPriceOptions) {
+			defer wg.Done()
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			prices[i], errs[i] = pythevm.ReadPrice(ctx, job)
+		}(i, job)
+	}
+	wg.Wait()
+	return prices, errs
+}
+
+// CompareWithHermes fetches the latest Hermes prices of the monitored feeds and reports how far
+// the cached on-chain prices lag behind them, sorted by network and price ID. Feeds without a
+// cached on-chain price or a Hermes price are left out.
+func (pm *PythOnChainMonitor) CompareWithHermes(ctx context.Context, client *pyth.HermesClient) ([]PythOnChainLag, error) {
+	pm.mu.RLock()
+	maxAge := pm.maxAge
+	feeds := make(map[uint64]map[string]string, len(pm.feeds))
+	unique := make(map[string]bool)
+	for networkID, networkFeeds := range pm.feeds {
+		feeds[networkID] = make(map[string]string, len(networkFeeds))
+		for priceID, symbol := range networkFeeds {
+			feeds[networkID][priceID] = symbol
+			unique[priceID] = true
+		}
+	}
+	pm.mu.RUnlock()
+	if len(unique) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]pyth.HexString, 0, len(unique))
+	for priceID := range unique {
+		ids = append(ids, pyth.HexString(priceID))
+	}
+	parsed, ignoreInvalid := true, true
+	update, err := client.GetLatestPriceUpdates(ctx, ids, &pyth.GetLatestPriceUpdatesOptions{Parsed: &parsed, IgnoreInvalidPriceIds: &ignoreInvalid})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Hermes prices: %v", err)
+	}
+	hermesPublishTimes := make(map[string]int64, len(update.Parsed))
+	for _, feed := range update.Parsed {
+		hermesPublishTimes[normalizePythPriceID(feed.ID)] = feed.Price.PublishTime
+	}
+
+	var lags []PythOnChainLag
+	for networkID, networkFeeds := range feeds {
+		for priceID, symbol := range networkFeeds {
+			hermesPublishTime, exists := hermesPublishTimes[priceID]
+			if !exists {
+				continue
+			}
+			onChainPrice, err := pm.GetPrice(networkID, priceID)
+			if err != nil {
+				continue
+			}
+			lag := time.Duration(hermesPublishTime-onChainPrice.PublishTime) * time.Second
+			lags = append(lags, PythOnChainLag{
+				NetworkID:          networkID,
+				PriceID:            priceID,
+				Symbol:             symbol,
+				OnChainPublishTime: onChainPrice.PublishTime,
+				HermesPublishTime:  hermesPublishTime,
+				Lag:                lag,
+				Stale:              onChainPrice.Stale || lag > maxAge,
+			})
+		}
+	}
+	sort.Slice(lags, func(i, j int) bool {
+		if lags[i].NetworkID != lags[j].NetworkID {
+			return lags[i].NetworkID < lags[j].NetworkID
+		}
+		return lags[i].PriceID < lags[j].PriceID
+	})
+	return lags, nil
+}
+
+// Start begins reading on-chain prices until ctx is cancelled or Stop is called
+func (pm *PythOnChainMonitor) Start(ctx context.Context) {
+	ctx, cancel := stopContext(ctx, pm.stopChan)
+	defer cancel()
+
+	log.Printf("Starting on-chain Pyth price monitor with %v interval", pm.interval)
+
+	pollEvery(ctx, pm.interval, pm.updateAllPrices)
+	log.Println("Stopping on-chain Pyth price monitor")
+}
+
+// Stop stops the monitor and cancels any RPC call in flight
+func (pm *PythOnChainMonitor) Stop() {
+	close(pm.stopChan)
+}
+
+// normalizePythPriceID lowercases a price ID and strips its 0x, the form Hermes returns
+func normalizePythPriceID(priceID string) string {
+	return strings.ToLower(strings.TrimPrefix(priceID, "0x"))
+}
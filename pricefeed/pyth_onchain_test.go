@@ -0,0 +1,100 @@
+package pricefeed
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/morpheum-labs/pricefeeding/ipyth"
+	"github.com/morpheum-labs/pricefeeding/pyth"
+	"github.com/morpheum-labs/pricefeeding/pyth/pythevm"
+	"github.com/morpheum-labs/pricefeeding/pyth/pythtest"
+)
+
+func TestPythOnChainMonitorComparesWithHermes(t *testing.T) {
+	chain, err := pythtest.NewChain()
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer chain.Close()
+
+	mock, err := chain.DeployPyth(big.NewInt(1))
+	if err != nil {
+		t.Fatalf("Failed to deploy mock Pyth: %v", err)
+	}
+	header, err := chain.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Failed to get latest header: %v", err)
+	}
+	now := int64(header.Time)
+
+	// BTC was pushed just now, ETH five minutes ago
+	btcID, ethID := strings.Repeat("e6", 32), strings.Repeat("ff", 32)
+	pushed := map[string]int64{btcID: now, ethID: now - 300}
+	for id, publishTime := range pushed {
+		priceID, err := pythevm.ParsePriceID(id)
+		if err != nil {
+			t.Fatalf("ParsePriceID failed: %v", err)
+		}
+		price := ipyth.PythStructsPrice{Price: 6500000000000, Conf: 1000, Expo: -8, PublishTime: big.NewInt(publishTime)}
+		if err := mock.SetPrice(priceID, price, 6400000000000, 900); err != nil {
+			t.Fatalf("SetPrice failed: %v", err)
+		}
+	}
+
+	monitor := NewPythOnChainMonitor(NewPriceCacheManager(), 30*time.Second)
+	networkID := uint64(pythtest.SimulatedChainID)
+	monitor.AddClient(networkID, chain.Client)
+	if err := monitor.SetContract(networkID, mock.Address.Hex()); err != nil {
+		t.Fatalf("SetContract failed: %v", err)
+	}
+	for id, symbol := range map[string]string{"0x" + btcID: "BTC/USD", ethID: "ETH/USD", strings.Repeat("01", 32): "NEW/USD"} {
+		if err := monitor.AddPriceFeed(networkID, id, symbol); err != nil {
+			t.Fatalf("AddPriceFeed failed: %v", err)
+		}
+	}
+	if err := monitor.AddPriceFeed(networkID, "0x1234", "BAD/USD"); err == nil {
+		t.Error("Expected an invalid price ID to be rejected")
+	}
+
+	monitor.updateAllPrices(context.Background())
+	btc, err := monitor.GetPrice(networkID, "0x"+btcID)
+	if err != nil {
+		t.Fatalf("Expected a BTC price after the first cycle: %v", err)
+	}
+	if btc.Price.Int64() != 6500000000000 || btc.EMA.Int64() != 6400000000000 || btc.Exponent != -8 || btc.Stale {
+		t.Errorf("Unexpected BTC price %+v", btc)
+	}
+	if eth, err := monitor.GetPrice(networkID, ethID); err != nil || !eth.Stale {
+		t.Errorf("Expected a stale ETH price, got %+v (%v)", eth, err)
+	}
+	// A feed never pushed to the contract has no price
+	if len(monitor.GetAllPrices(networkID)) != 2 {
+		t.Errorf("Expected 2 on-chain prices, got %d", len(monitor.GetAllPrices(networkID)))
+	}
+
+	hermes := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"parsed":[{"id":"%s","price":{"price":"1","conf":"1","expo":-8,"publishTime":%d}},{"id":"%s","price":{"price":"1","conf":"1","expo":-8,"publishTime":%d}}]}`,
+			btcID, now+5, ethID, now+5)
+	}))
+	defer hermes.Close()
+
+	lags, err := monitor.CompareWithHermes(context.Background(), pyth.NewHermesClient(hermes.URL, nil))
+	if err != nil {
+		t.Fatalf("CompareWithHermes failed: %v", err)
+	}
+	if len(lags) != 2 {
+		t.Fatalf("Expected 2 lags, got %+v", lags)
+	}
+	for _, lag := range lags {
+		want := time.Duration(now+5-pushed[lag.PriceID]) * time.Second
+		if lag.Lag != want || lag.Stale != (lag.PriceID == ethID) {
+			t.Errorf("Unexpected lag %+v, want %v", lag, want)
+		}
+	}
+}
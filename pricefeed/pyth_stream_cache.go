@@ -0,0 +1,41 @@
+package pricefeed
+
+import (
+	"log"
+
+	"github.com/morpheum-labs/pricefeeding/pyth"
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// CachePriceUpdates writes every parsed price feed of a Hermes stream to cacheManager as
+// types.PythPrice, and its EMA as types.PythEMAPrice, until the stream is closed. symbols maps price IDs to symbols and may be nil;
+// decode and connection errors are logged.
+func CachePriceUpdates(cacheManager *PriceCacheManager, stream *pyth.PriceUpdateStream, symbols map[string]string) {
+	networkID := uint64(types.OracleNetworkIDPyth)
+	updates, errs := stream.Updates(), stream.Errors()
+
+	for updates != nil || errs != nil {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				updates = nil
+				continue
+			}
+			for _, feed := range update.Parsed {
+				cacheManager.UpdatePrice(networkID, feed.ID, types.SourcePyth, pythFeedToPrice(feed, symbols[feed.ID]))
+				if emaPrice := pythFeedToEMAPrice(feed, symbols[feed.ID]); emaPrice != nil {
+					cacheManager.UpdatePrice(networkID, feed.ID, types.SourcePythEMA, emaPrice)
+				}
+			}
+			if len(update.Parsed) > 0 {
+				cacheManager.UpdateLastSaved()
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			log.Printf("Pyth price update stream: %v", err)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package pricefeed
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/morpheum-labs/pricefeeding/pyth"
+)
+
+// DefaultPythWebSocketURL is the Hermes WebSocket endpoint used when streaming is enabled without a URL
+const DefaultPythWebSocketURL = "wss://hermes.pyth.network/ws"
+
+// streamStaleAfter is how many poll intervals a streamed feed may go without an update before it is polled
+const streamStaleAfter = 2
+
+// defaultStreamRetry is how often a WebSocket that gave up reconnecting is restarted
+const defaultStreamRetry = time.Minute
+
+// EnableStreaming subscribes every monitored feed over the Hermes WebSocket and writes updates to
+// the cache as they arrive. HTTP polling takes over while the socket is down, and for any feed
+// that got no update over it in streamStaleAfter poll intervals; it stops again once updates
+// arrive. A socket that gave up reconnecting is restarted every minute. A nil config uses
+// DefaultPythWebSocketURL. Must be called before Start.
+func (ppm *PythPriceMonitor) EnableStreaming(config *pyth.WebSocketConfig) {
+	if config == nil {
+		config = pyth.DefaultWebSocketConfig(DefaultPythWebSocketURL)
+	}
+
+	wsClient := pyth.NewWebSocketClient(config)
+	wsClient.OnPriceUpdate(func(feed *pyth.PriceFeed) {
+		ppm.mu.Lock()
+		ppm.streamUpdates[feed.ID] = time.Now()
+		ppm.mu.Unlock()
+		ppm.storePriceFeed(*feed)
+	})
+	wsClient.OnError(func(err error) {
+		log.Printf("Pyth WebSocket error: %v", err)
+	})
+
+	ppm.mu.Lock()
+	defer ppm.mu.Unlock()
+	ppm.wsClient = wsClient
+	ppm.streamRetry = defaultStreamRetry
+	log.Printf("Pyth price monitor streaming enabled via %s", config.URL)
+}
+
+// IsStreaming returns whether price updates currently arrive over the WebSocket rather than HTTP polling
+func (ppm *PythPriceMonitor) IsStreaming() bool {
+	ppm.mu.RLock()
+	defer ppm.mu.RUnlock()
+	return ppm.streamingMode
+}
+
+// checkStream returns the feeds to poll over HTTP: all of them without a connected WebSocket,
+// otherwise those that got no update over it in streamStaleAfter poll intervals. Switches between
+// streaming and polling are logged, and a socket that never started or gave up reconnecting is
+// restarted once its retry time is reached.
+func (ppm *PythPriceMonitor) checkStream(now time.Time) []pyth.HexString {
+	ppm.mu.Lock()
+	wsClient := ppm.wsClient
+	connected := wsClient != nil && wsClient.IsConnected()
+	if !connected {
+		ppm.streamSince = time.Time{}
+	} else if ppm.streamSince.IsZero() {
+		ppm.streamSince = now
+	}
+
+	// A feed is stale once neither an update nor the connection is recent
+	staleAfter := streamStaleAfter * ppm.interval
+	var poll []pyth.HexString
+	for priceID := range ppm.priceFeeds {
+		if connected {
+			last := ppm.streamUpdates[priceID]
+			if last.Before(ppm.streamSince) {
+				last = ppm.streamSince
+			}
+			if now.Sub(last) <= staleAfter {
+				continue
+			}
+		}
+		poll = append(poll, pyth.HexString(priceID))
+	}
+	if wsClient == nil {
+		ppm.mu.Unlock()
+		return poll
+	}
+	ppm.setStreamingMode(connected && len(poll) == 0, connected)
+
+	if wsClient.HasGivenUp() {
+		ppm.wsStarted = false
+	}
+	restart := !connected && !ppm.wsStarted && !ppm.wsRestarting && !now.Before(ppm.wsRetryAt)
+	if restart {
+		ppm.wsRestarting = true
+	}
+	ppm.mu.Unlock()
+
+	if restart {
+		go func() {
+			if err := ppm.restartStream(); err != nil {
+				log.Printf("Pyth WebSocket restart: %v", err)
+			}
+		}()
+	}
+	return poll
+}
+
+// restartStream starts the WebSocket after it gave up reconnecting (or on the first Start) and
+// subscribes every monitored feed. A failed subscription is returned, but the socket stays up:
+// its own reconnect resubscribes, and the feeds are polled until their updates arrive.
+func (ppm *PythPriceMonitor) restartStream() error {
+	ppm.mu.RLock()
+	wsClient := ppm.wsClient
+	ppm.mu.RUnlock()
+
+	wsClient.Reset()
+	if err := wsClient.Start(); err != nil {
+		ppm.mu.Lock()
+		defer ppm.mu.Unlock()
+		ppm.wsRestarting = false
+		ppm.wsRetryAt = time.Now().Add(ppm.streamRetry)
+		return fmt.Errorf("failed to start, polling over HTTP until %s: %v", ppm.wsRetryAt.Format("15:04:05"), err)
+	}
+
+	err := ppm.subscribeStream()
+
+	ppm.mu.Lock()
+	defer ppm.mu.Unlock()
+	ppm.wsRestarting = false
+	ppm.wsStarted = true
+	if err != nil {
+		return fmt.Errorf("failed to subscribe feeds: %v", err)
+	}
+	return nil
+}
+
+// setStreamingMode records whether updates arrive over the WebSocket and logs switches, called with ppm.mu held
+func (ppm *PythPriceMonitor) setStreamingMode(streaming, connected bool) {
+	if streaming == ppm.streamingMode {
+		return
+	}
+	ppm.streamingMode = streaming
+	switch {
+	case streaming:
+		log.Println("Pyth WebSocket delivering updates, switching from HTTP polling to streaming")
+	case connected:
+		log.Println("Pyth WebSocket connected but updates stopped arriving, falling back to HTTP polling")
+	default:
+		log.Println("Pyth WebSocket down, falling back to HTTP polling")
+	}
+}
+
+// subscribeStream subscribes the WebSocket to every monitored feed
+func (ppm *PythPriceMonitor) subscribeStream() error {
+	ppm.mu.RLock()
+	wsClient := ppm.wsClient
+	priceIDs := make([]pyth.HexString, 0, len(ppm.priceFeeds))
+	for priceID := range ppm.priceFeeds {
+		priceIDs = append(priceIDs, pyth.HexString(priceID))
+	}
+	ppm.mu.RUnlock()
+
+	return wsClient.Subscribe(priceIDs)
+}
@@ -0,0 +1,157 @@
+package pricefeed
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/morpheum-labs/pricefeeding/pyth"
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// MaxPythTWAPWindow is the longest TWAP window Hermes computes
+const MaxPythTWAPWindow = 10 * time.Minute
+
+// PythTWAPIdentifier returns the cache identifier of a feed's TWAP over window, e.g.
+// "e62d...43/300" for five minutes, so that every window is a series of its own
+func PythTWAPIdentifier(priceID string, window time.Duration) string {
+	return fmt.Sprintf("%s/%d", priceID, int64(window/time.Second))
+}
+
+// SetTWAPWindows sets the windows the monitor fetches TWAPs over every cycle, in whole seconds up
+// to MaxPythTWAPWindow; no windows turns TWAPs off
+func (ppm *PythPriceMonitor) SetTWAPWindows(windows ...time.Duration) error {
+	seen := make(map[time.Duration]bool, len(windows))
+	unique := make([]time.Duration, 0, len(windows))
+	for _, window := range windows {
+		if window < time.Second || window > MaxPythTWAPWindow || window%time.Second != 0 {
+			return fmt.Errorf("TWAP window must be whole seconds between 1s and %v, got %v", MaxPythTWAPWindow, window)
+		}
+		if !seen[window] {
+			seen[window] = true
+			unique = append(unique, window)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool { return unique[i] < unique[j] })
+
+	ppm.mu.Lock()
+	defer ppm.mu.Unlock()
+	ppm.twapWindows = unique
+	return nil
+}
+
+// GetEMAPrice retrieves the latest EMA price of a feed
+func (ppm *PythPriceMonitor) GetEMAPrice(priceID string) (*types.PythEMAPrice, error) {
+	priceInfo, err := ppm.cacheManager.GetPrice(uint64(types.OracleNetworkIDPyth), priceID, types.SourcePythEMA)
+	if err != nil {
+		return nil, err
+	}
+	if emaPrice, ok := priceInfo.(*types.PythEMAPrice); ok {
+		return emaPrice, nil
+	}
+	return nil, fmt.Errorf("price info is not Pyth EMA data")
+}
+
+// GetTWAPPrice retrieves the latest TWAP of a feed over one of the configured windows
+func (ppm *PythPriceMonitor) GetTWAPPrice(priceID string, window time.Duration) (*types.PythTWAPPrice, error) {
+	priceInfo, err := ppm.cacheManager.GetPrice(uint64(types.OracleNetworkIDPyth), PythTWAPIdentifier(priceID, window), types.SourcePythTWAP)
+	if err != nil {
+		return nil, err
+	}
+	if twapPrice, ok := priceInfo.(*types.PythTWAPPrice); ok {
+		return twapPrice, nil
+	}
+	return nil, fmt.Errorf("price info is not Pyth TWAP data")
+}
+
+// updateTWAPs fetches the TWAPs of every configured window and logs failures
+func (ppm *PythPriceMonitor) updateTWAPs(ctx context.Context) {
+	ppm.mu.RLock()
+	windows := ppm.twapWindows
+	cycleTimeout := ppm.cycleTimeout
+	priceIDs := make([]pyth.HexString, 0, len(ppm.priceFeeds))
+	for priceID := range ppm.priceFeeds {
+		priceIDs = append(priceIDs, pyth.HexString(priceID))
+	}
+	ppm.mu.RUnlock()
+
+	if len(windows) == 0 || len(priceIDs) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cycleTimeout)
+	defer cancel()
+
+	for _, window := range windows {
+		if err := ppm.fetchTWAPs(ctx, priceIDs, window); err != nil {
+			log.Printf("Failed to fetch Pyth %v TWAPs: %v", window, err)
+		}
+	}
+}
+
+// fetchTWAPs fetches the TWAPs of the given feeds over one window and writes them to the cache
+func (ppm *PythPriceMonitor) fetchTWAPs(ctx context.Context, priceIDs []pyth.HexString, window time.Duration) error {
+	parsed := true
+	response, err := ppm.client.GetLatestTwaps(ctx, priceIDs, int(window/time.Second), &pyth.GetLatestTwapsOptions{Parsed: &parsed})
+	if err != nil {
+		return fmt.Errorf("failed to get latest TWAPs: %v", err)
+	}
+
+	networkID := uint64(types.OracleNetworkIDPyth)
+	for _, twap := range response.Parsed {
+		ppm.mu.RLock()
+		symbol := ppm.priceFeeds[twap.ID]
+		immediateMode := ppm.immediateMode
+		ppm.mu.RUnlock()
+
+		twapPrice := pythTwapToPrice(twap, symbol, window)
+		if twapPrice == nil {
+			continue
+		}
+		ppm.cacheManager.UpdatePrice(networkID, PythTWAPIdentifier(twap.ID, window), types.SourcePythTWAP, twapPrice)
+		if immediateMode {
+			fmt.Printf("📈 PYTH TWAP [%s] %s %v: %s (down slots %.2f%%)\n", time.Now().Format("15:04:05"),
+				twapPrice.Symbol, window, formatPythPrice(twapPrice.Price, twapPrice.Exponent), twapPrice.DownSlotsRatio*100)
+		}
+	}
+	return nil
+}
+
+// pythTwapToPrice converts a Hermes TWAP to a PythTWAPPrice, or returns nil when it has no price
+func pythTwapToPrice(twap pyth.Twap, symbol string, window time.Duration) *types.PythTWAPPrice {
+	price, ok := new(big.Int).SetString(twap.Twap.Price, 10)
+	if !ok {
+		return nil
+	}
+	confidence, _ := new(big.Int).SetString(twap.Twap.Conf, 10)
+	downSlotsRatio, _ := strconv.ParseFloat(twap.DownSlotsRatio, 64)
+
+	return &types.PythTWAPPrice{
+		ID:             twap.ID,
+		Symbol:         symbol,
+		Window:         window,
+		Price:          price,
+		Confidence:     confidence,
+		Exponent:       twap.Twap.Expo,
+		StartTime:      twap.StartTimestamp,
+		EndTime:        twap.EndTimestamp,
+		DownSlotsRatio: downSlotsRatio,
+		Timestamp:      time.Now(),
+		NetworkID:      uint64(types.OracleNetworkIDPyth),
+	}
+}
+
+// formatPythPrice formats a price scaled by 10^exponent with 8 decimals
+func formatPythPrice(price *big.Int, exponent int) string {
+	value := new(big.Float).SetInt(price)
+	if exponent < 0 {
+		value.Quo(value, new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exponent)), nil)))
+	} else {
+		value.Mul(value, new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)))
+	}
+	return value.Text('f', 8)
+}
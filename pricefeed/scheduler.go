@@ -0,0 +1,221 @@
+package pricefeed
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// SchedulerConfig tunes the heartbeat-adaptive poll scheduler
+type SchedulerConfig struct {
+	TickInterval      time.Duration // How often the monitor checks which feeds are due
+	DefaultInterval   time.Duration // Poll interval of feeds without a deviation threshold
+	MinInterval       time.Duration // Shortest time between two polls of one feed
+	MaxInterval       time.Duration // Longest time between two polls of one feed
+	IntervalPerPct    time.Duration // Deviation poll interval per 1% of deviation threshold
+	HeartbeatDelay    time.Duration // How long after an expected heartbeat round to poll
+	JitterFraction    float64       // Random spread applied to each interval, e.g. 0.1 = ±10%
+	MaxUnchangedShift int           // Cap on the exponent of the unchanged-round backoff
+	MaxDeviationShift int           // Cap on that exponent for feeds with a deviation threshold, until their heartbeat is overdue
+}
+
+// DefaultSchedulerConfig returns the scheduler defaults: a 0.5% feed is checked every 30s and a
+// 2% feed every 2m, always 15s after its expected heartbeat, with ±10% jitter. While its round
+// does not change, a feed with a deviation threshold is checked at most half as often until its
+// heartbeat is overdue.
+func DefaultSchedulerConfig() SchedulerConfig {
+	return SchedulerConfig{
+		TickInterval:      5 * time.Second,
+		DefaultInterval:   30 * time.Second,
+		MinInterval:       15 * time.Second,
+		MaxInterval:       30 * time.Minute,
+		IntervalPerPct:    time.Minute,
+		HeartbeatDelay:    15 * time.Second,
+		JitterFraction:    0.1,
+		MaxUnchangedShift: 5,
+		MaxDeviationShift: 1,
+	}
+}
+
+// FeedSchedule is the on-chain update behaviour of a feed, as configured in the feed YAML
+type FeedSchedule struct {
+	Heartbeat        time.Duration // Maximum time between two rounds
+	ThresholdPercent float64       // Deviation that triggers a new round, in percent
+}
+
+// feedScheduleKey identifies a feed on a network
+type feedScheduleKey struct {
+	networkID   uint64
+	feedAddress string
+}
+
+// feedScheduleState is the scheduling state of a single feed
+type feedScheduleState struct {
+	schedule  FeedSchedule
+	nextPoll  time.Time
+	lastRound *big.Int
+	unchanged int // Consecutive polls that returned the same round
+}
+
+// PollScheduler decides when each feed should be polled next. Instead of polling every feed
+// on one global ticker, it polls each feed often enough to catch deviation updates for its
+// threshold, right after its next expected heartbeat round, and progressively less often
+// while rounds do not change (e.g. when a market is closed).
+type PollScheduler struct {
+	mu     sync.Mutex
+	config SchedulerConfig
+	feeds  map[feedScheduleKey]*feedScheduleState
+	rand   *rand.Rand
+}
+
+// NewPollScheduler creates a new poll scheduler
+func NewPollScheduler(config SchedulerConfig) *PollScheduler {
+	return &PollScheduler{
+		config: config,
+		feeds:  make(map[feedScheduleKey]*feedScheduleState),
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// SetSchedule sets the heartbeat and deviation threshold of a feed. The feed is due immediately.
+func (s *PollScheduler) SetSchedule(networkID uint64, feedAddress string, schedule FeedSchedule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.feeds[feedScheduleKey{networkID, feedAddress}] = &feedScheduleState{schedule: schedule}
+}
+
+// Remove drops the schedule and polling state of a feed
+func (s *PollScheduler) Remove(networkID uint64, feedAddress string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.feeds, feedScheduleKey{networkID, feedAddress})
+}
+
+// Due reports whether a feed should be polled at now. Feeds that were never polled are always due.
+func (s *PollScheduler) Due(networkID uint64, feedAddress string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, exists := s.feeds[feedScheduleKey{networkID, feedAddress}]
+	if !exists {
+		return true
+	}
+	return !now.Before(state.nextPoll)
+}
+
+// NextPoll returns when a feed will be polled next
+func (s *PollScheduler) NextPoll(networkID uint64, feedAddress string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, exists := s.feeds[feedScheduleKey{networkID, feedAddress}]
+	if !exists {
+		return time.Time{}, false
+	}
+	return state.nextPoll, true
+}
+
+// Observe schedules the next poll of a feed from the round it just returned
+func (s *PollScheduler) Observe(networkID uint64, feedAddress string, price *types.ChainlinkPrice, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.state(networkID, feedAddress)
+
+	if state.lastRound != nil && price.RoundID != nil && state.lastRound.Cmp(price.RoundID) == 0 {
+		state.unchanged++
+	} else {
+		state.unchanged = 0
+	}
+	state.lastRound = price.RoundID
+
+	var updatedAt time.Time
+	if price.UpdatedAt != nil && price.UpdatedAt.Sign() > 0 {
+		updatedAt = time.Unix(price.UpdatedAt.Int64(), 0)
+	}
+
+	state.nextPoll = now.Add(s.jitter(s.interval(state, updatedAt, now)))
+}
+
+// ObserveError schedules a quick retry after a failed poll
+func (s *PollScheduler) ObserveError(networkID uint64, feedAddress string, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state(networkID, feedAddress).nextPoll = now.Add(s.jitter(s.config.MinInterval))
+}
+
+// state returns the scheduling state of a feed, creating an unscheduled one if needed
+func (s *PollScheduler) state(networkID uint64, feedAddress string) *feedScheduleState {
+	key := feedScheduleKey{networkID, feedAddress}
+	state, exists := s.feeds[key]
+	if !exists {
+		state = &feedScheduleState{}
+		s.feeds[key] = state
+	}
+	return state
+}
+
+// interval returns the time until the next poll of a feed
+func (s *PollScheduler) interval(state *feedScheduleState, updatedAt time.Time, now time.Time) time.Duration {
+	// Deviation updates can land at any time; the looser the threshold, the rarer they are
+	deviationInterval := s.config.DefaultInterval
+	if state.schedule.ThresholdPercent > 0 {
+		deviationInterval = time.Duration(state.schedule.ThresholdPercent * float64(s.config.IntervalPerPct))
+	}
+
+	// Back off while the round does not change (closed market, quiet feed). A deviation round
+	// can land at any time, so a feed with a threshold backs off only a little until its
+	// heartbeat is overdue, which shows that no rounds are being published at all.
+	maxShift := s.config.MaxUnchangedShift
+	if state.schedule.ThresholdPercent > 0 && !s.heartbeatOverdue(state, updatedAt, now) && s.config.MaxDeviationShift < maxShift {
+		maxShift = s.config.MaxDeviationShift
+	}
+	shift := state.unchanged
+	if shift > maxShift {
+		shift = maxShift
+	}
+	interval := clampDuration(deviationInterval<<uint(shift), s.config.MinInterval, s.config.MaxInterval)
+
+	// Never sleep past the next expected heartbeat round
+	if state.schedule.Heartbeat > 0 && !updatedAt.IsZero() {
+		untilHeartbeat := updatedAt.Add(state.schedule.Heartbeat + s.config.HeartbeatDelay).Sub(now)
+		if untilHeartbeat > 0 && untilHeartbeat < interval {
+			interval = untilHeartbeat
+		}
+	}
+
+	return interval
+}
+
+// heartbeatOverdue reports whether a feed's heartbeat round should have landed by now
+func (s *PollScheduler) heartbeatOverdue(state *feedScheduleState, updatedAt time.Time, now time.Time) bool {
+	if state.schedule.Heartbeat <= 0 || updatedAt.IsZero() {
+		return false
+	}
+	return now.After(updatedAt.Add(state.schedule.Heartbeat + s.config.HeartbeatDelay))
+}
+
+// jitter spreads an interval by ±JitterFraction so feeds do not all hit the RPC at once
+func (s *PollScheduler) jitter(interval time.Duration) time.Duration {
+	if s.config.JitterFraction <= 0 {
+		return interval
+	}
+	spread := (s.rand.Float64()*2 - 1) * s.config.JitterFraction
+	return time.Duration(math.Round(float64(interval) * (1 + spread)))
+}
+
+// clampDuration limits d to [min, max]
+func clampDuration(d, min, max time.Duration) time.Duration {
+	if d < min {
+		return min
+	}
+	if max > 0 && d > max {
+		return max
+	}
+	return d
+}
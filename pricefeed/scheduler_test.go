@@ -0,0 +1,124 @@
+package pricefeed
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+const testFeed = "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419"
+
+func newTestScheduler() *PollScheduler {
+	config := DefaultSchedulerConfig()
+	config.JitterFraction = 0
+	return NewPollScheduler(config)
+}
+
+func roundAt(roundID int64, updatedAt time.Time) *types.ChainlinkPrice {
+	return &types.ChainlinkPrice{
+		RoundID:   big.NewInt(roundID),
+		UpdatedAt: big.NewInt(updatedAt.Unix()),
+	}
+}
+
+func TestSchedulerPollsAfterHeartbeat(t *testing.T) {
+	s := newTestScheduler()
+	s.SetSchedule(1, testFeed, FeedSchedule{Heartbeat: time.Hour, ThresholdPercent: 1})
+
+	now := time.Unix(1_700_000_000, 0)
+	if !s.Due(1, testFeed, now) {
+		t.Fatal("Expected a new feed to be due")
+	}
+
+	// Last round 59m50s ago: the heartbeat round is expected in 10s, poll 15s after it
+	s.Observe(1, testFeed, roundAt(1, now.Add(-59*time.Minute-50*time.Second)), now)
+	next, _ := s.NextPoll(1, testFeed)
+	if want := now.Add(25 * time.Second); !next.Equal(want) {
+		t.Errorf("Expected next poll at %v, got %v", want, next)
+	}
+	if s.Due(1, testFeed, now.Add(20*time.Second)) {
+		t.Error("Expected feed not to be due before its next poll")
+	}
+	if !s.Due(1, testFeed, now.Add(25*time.Second)) {
+		t.Error("Expected feed to be due at its next poll")
+	}
+}
+
+func TestSchedulerDeviationInterval(t *testing.T) {
+	s := newTestScheduler()
+	s.SetSchedule(1, testFeed, FeedSchedule{Heartbeat: 24 * time.Hour, ThresholdPercent: 0.5})
+
+	now := time.Unix(1_700_000_000, 0)
+	s.Observe(1, testFeed, roundAt(1, now), now)
+	next, _ := s.NextPoll(1, testFeed)
+	if want := now.Add(30 * time.Second); !next.Equal(want) {
+		t.Errorf("Expected a 0.5%% feed to be polled after 30s, got %v", next.Sub(now))
+	}
+}
+
+func TestSchedulerBacksOffOnUnchangedRound(t *testing.T) {
+	s := newTestScheduler()
+	s.SetSchedule(1, testFeed, FeedSchedule{Heartbeat: time.Hour, ThresholdPercent: 1})
+
+	// The heartbeat round is overdue, as on a closed market
+	now := time.Unix(1_700_000_000, 0)
+	round := roundAt(7, now.Add(-2*time.Hour))
+
+	var intervals []time.Duration
+	for i := 0; i < 4; i++ {
+		s.Observe(1, testFeed, round, now)
+		next, _ := s.NextPoll(1, testFeed)
+		intervals = append(intervals, next.Sub(now))
+		now = next
+	}
+
+	want := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute}
+	for i := range want {
+		if intervals[i] != want[i] {
+			t.Errorf("Poll %d: expected interval %v, got %v", i, want[i], intervals[i])
+		}
+	}
+
+	// A new round resets the backoff
+	s.Observe(1, testFeed, roundAt(8, now), now)
+	next, _ := s.NextPoll(1, testFeed)
+	if got := next.Sub(now); got != time.Minute {
+		t.Errorf("Expected backoff to reset after a new round, got %v", got)
+	}
+}
+
+func TestSchedulerBoundsDeviationDelayWhileHeartbeatIsDue(t *testing.T) {
+	config := DefaultSchedulerConfig()
+	s := NewPollScheduler(config)
+
+	for _, threshold := range []float64{0.5, 1, 2} {
+		s.SetSchedule(1, testFeed, FeedSchedule{Heartbeat: 24 * time.Hour, ThresholdPercent: threshold})
+
+		// However long the round stays unchanged, a deviation round landing right after a poll
+		// is seen within twice the threshold interval, plus jitter
+		deviationInterval := time.Duration(threshold * float64(config.IntervalPerPct))
+		bound := time.Duration(float64(2*deviationInterval) * (1 + config.JitterFraction))
+		now := time.Unix(1_700_000_000, 0)
+		round := roundAt(7, now)
+		for i := 0; i < 20; i++ {
+			s.Observe(1, testFeed, round, now)
+			next, _ := s.NextPoll(1, testFeed)
+			if delay := next.Sub(now); delay > bound {
+				t.Fatalf("%v%% feed, poll %d: a deviation round could go unseen for %v, more than %v", threshold, i, delay, bound)
+			}
+			now = next
+		}
+	}
+}
+
+func TestSchedulerJitter(t *testing.T) {
+	s := NewPollScheduler(DefaultSchedulerConfig())
+	for i := 0; i < 100; i++ {
+		got := s.jitter(time.Minute)
+		if got < 54*time.Second || got > 66*time.Second {
+			t.Fatalf("Expected jitter within ±10%% of 1m, got %v", got)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package pyth
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	accumulatorMagic    = "PNAU" // Accumulator update data
+	wormholeMerkleMagic = "AUWV" // Wormhole Merkle root VAA payload
+
+	accumulatorMajorVersion  = 1
+	updateTypeWormholeMerkle = 0
+)
+
+var (
+	// ErrInvalidMerkleProof is returned when a message's proof does not lead to the signed root
+	ErrInvalidMerkleProof = errors.New("merkle proof does not match the root")
+	// ErrParsedMismatch is returned when a parsed price differs from the binary message
+	ErrParsedMismatch = errors.New("parsed price does not match the accumulator message")
+)
+
+// MessageType is the type of a message in an accumulator update
+type MessageType uint8
+
+const (
+	MessageTypePriceFeed MessageType = 0
+	MessageTypeTwap      MessageType = 1
+)
+
+// MerkleRoot is the payload of the VAA of an accumulator update: the root of the Merkle tree of
+// every message published in a Pythnet slot
+type MerkleRoot struct {
+	Slot     uint64
+	RingSize uint32
+	Root     [20]byte
+}
+
+// MerkleUpdate is one message of an accumulator update with its Merkle proof
+type MerkleUpdate struct {
+	Message []byte
+	Proof   [][20]byte
+}
+
+// AccumulatorUpdate is a decoded accumulator ("PNAU") update, the binary data that Pyth contracts
+// consume: a VAA signing a Merkle root, and messages proven against that root
+type AccumulatorUpdate struct {
+	MajorVersion uint8
+	MinorVersion uint8
+	VAA          *VAA
+	Root         MerkleRoot
+	Updates      []MerkleUpdate
+}
+
+// PriceFeedMessage is a price message, exactly as verified on-chain
+type PriceFeedMessage struct {
+	FeedID          [32]byte
+	Price           int64
+	Conf            uint64
+	Exponent        int32
+	PublishTime     int64
+	PrevPublishTime int64
+	EmaPrice        int64
+	EmaConf         uint64
+}
+
+// ID returns the feed ID as hex without 0x, as in parsed Hermes responses
+func (m *PriceFeedMessage) ID() string {
+	return hex.EncodeToString(m.FeedID[:])
+}
+
+// ParseAccumulatorUpdate decodes accumulator update data. Proofs are not verified; see Verify.
+func ParseAccumulatorUpdate(data []byte) (*AccumulatorUpdate, error) {
+	r := &byteReader{data: data}
+
+	if magic := r.bytes(4); r.err == nil && string(magic) != accumulatorMagic {
+		return nil, fmt.Errorf("not an accumulator update: magic %x", magic)
+	}
+	update := &AccumulatorUpdate{MajorVersion: r.uint8(), MinorVersion: r.uint8()}
+	if r.err == nil && update.MajorVersion != accumulatorMajorVersion {
+		return nil, fmt.Errorf("unsupported accumulator update version %d.%d", update.MajorVersion, update.MinorVersion)
+	}
+	r.bytes(int(r.uint8())) // Trailing header, reserved for minor versions
+
+	if updateType := r.uint8(); r.err == nil && updateType != updateTypeWormholeMerkle {
+		return nil, fmt.Errorf("unsupported accumulator update type %d", updateType)
+	}
+
+	vaaData := r.bytes(int(r.uint16()))
+	if r.err != nil {
+		return nil, fmt.Errorf("failed to parse accumulator update: %w", r.err)
+	}
+	vaa, err := ParseVAA(vaaData)
+	if err != nil {
+		return nil, err
+	}
+	update.VAA = vaa
+	if update.Root, err = parseMerkleRoot(vaa.Payload); err != nil {
+		return nil, err
+	}
+
+	numUpdates := int(r.uint8())
+	for i := 0; i < numUpdates && r.err == nil; i++ {
+		var merkleUpdate MerkleUpdate
+		merkleUpdate.Message = r.bytes(int(r.uint16()))
+		proofSize := int(r.uint8())
+		for j := 0; j < proofSize && r.err == nil; j++ {
+			var node [20]byte
+			copy(node[:], r.bytes(20))
+			merkleUpdate.Proof = append(merkleUpdate.Proof, node)
+		}
+		update.Updates = append(update.Updates, merkleUpdate)
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("failed to parse accumulator update: %w", r.err)
+	}
+	if r.pos != len(data) {
+		return nil, fmt.Errorf("failed to parse accumulator update: %d trailing bytes", len(data)-r.pos)
+	}
+
+	return update, nil
+}
+
+// parseMerkleRoot decodes the Wormhole Merkle root VAA payload
+func parseMerkleRoot(payload []byte) (MerkleRoot, error) {
+	r := &byteReader{data: payload}
+	var root MerkleRoot
+
+	if magic := r.bytes(4); r.err == nil && string(magic) != wormholeMerkleMagic {
+		return root, fmt.Errorf("VAA payload is not a Merkle root: magic %x", magic)
+	}
+	if payloadType := r.uint8(); r.err == nil && payloadType != updateTypeWormholeMerkle {
+		return root, fmt.Errorf("unsupported Merkle root payload type %d", payloadType)
+	}
+	root.Slot = r.uint64()
+	root.RingSize = r.uint32()
+	copy(root.Root[:], r.bytes(20))
+	if r.err != nil {
+		return root, fmt.Errorf("failed to parse Merkle root: %w", r.err)
+	}
+	return root, nil
+}
+
+// Verify checks the Merkle proof of every message against the root signed by the VAA. The VAA's
+// guardian signatures are not checked here.
+func (u *AccumulatorUpdate) Verify() error {
+	for i, update := range u.Updates {
+		if !VerifyMerkleProof(u.Root.Root, update.Message, update.Proof) {
+			return fmt.Errorf("message %d: %w", i, ErrInvalidMerkleProof)
+		}
+	}
+	return nil
+}
+
+// PriceFeedMessages decodes the price feed messages of the update, skipping other message types
+func (u *AccumulatorUpdate) PriceFeedMessages() ([]*PriceFeedMessage, error) {
+	var messages []*PriceFeedMessage
+	for i, update := range u.Updates {
+		if len(update.Message) == 0 || MessageType(update.Message[0]) != MessageTypePriceFeed {
+			continue
+		}
+		message, err := ParsePriceFeedMessage(update.Message)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// ParsePriceFeedMessage decodes a price feed message
+func ParsePriceFeedMessage(message []byte) (*PriceFeedMessage, error) {
+	r := &byteReader{data: message}
+
+	if messageType := r.uint8(); r.err == nil && MessageType(messageType) != MessageTypePriceFeed {
+		return nil, fmt.Errorf("not a price feed message: type %d", messageType)
+	}
+	var m PriceFeedMessage
+	copy(m.FeedID[:], r.bytes(32))
+	m.Price = int64(r.uint64())
+	m.Conf = r.uint64()
+	m.Exponent = int32(r.uint32())
+	m.PublishTime = int64(r.uint64())
+	m.PrevPublishTime = int64(r.uint64())
+	m.EmaPrice = int64(r.uint64())
+	m.EmaConf = r.uint64()
+	if r.err != nil {
+		return nil, fmt.Errorf("failed to parse price feed message: %w", r.err)
+	}
+	// Later versions may append fields
+	return &m, nil
+}
+
+// VerifyMerkleProof checks that message is a leaf of the Merkle tree with the given root. Pyth
+// hashes with keccak256 truncated to 20 bytes, prefixes leaves with 0 and nodes with 1, and sorts
+// the children of each node.
+func VerifyMerkleProof(root [20]byte, message []byte, proof [][20]byte) bool {
+	current := merkleLeafHash(message)
+	for _, sibling := range proof {
+		current = merkleNodeHash(current, sibling)
+	}
+	return current == root
+}
+
+// merkleLeafHash hashes a leaf of the accumulator Merkle tree
+func merkleLeafHash(message []byte) [20]byte {
+	return keccak160([]byte{0}, message)
+}
+
+// merkleNodeHash hashes two children of the accumulator Merkle tree, smallest first
+func merkleNodeHash(a, b [20]byte) [20]byte {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		a, b = b, a
+	}
+	return keccak160([]byte{1}, a[:], b[:])
+}
+
+// keccak160 returns the first 20 bytes of the keccak256 hash of data
+func keccak160(data ...[]byte) [20]byte {
+	var hash [20]byte
+	copy(hash[:], crypto.Keccak256(data...))
+	return hash
+}
+
+// DecodeBinaryData decodes the hex or base64 accumulator updates of a Hermes response
+func DecodeBinaryData(binaryData *BinaryData) ([][]byte, error) {
+	if binaryData == nil {
+		return nil, fmt.Errorf("price update has no binary data")
+	}
+
+	updates := make([][]byte, 0, len(binaryData.Data))
+	for i, encoded := range binaryData.Data {
+		var data []byte
+		var err error
+		switch EncodingType(binaryData.Encoding) {
+		case EncodingTypeHex:
+			data, err = hex.DecodeString(strings.TrimPrefix(encoded, "0x"))
+		case EncodingTypeBase64:
+			data, err = base64.StdEncoding.DecodeString(encoded)
+		default:
+			return nil, fmt.Errorf("unsupported binary encoding %q", binaryData.Encoding)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode binary update %d: %w", i, err)
+		}
+		updates = append(updates, data)
+	}
+	return updates, nil
+}
+
+// VerifyPriceUpdate decodes the binary data of a Hermes price update, verifies every Merkle proof
+// and checks that each parsed price feed matches its binary message, so the parsed values are
+// what would land on-chain. It returns the verified price feed messages. Guardian signatures are
+// not checked; see GuardianVerifier.VerifyPriceUpdate.
+func VerifyPriceUpdate(update *PriceUpdate) ([]*PriceFeedMessage, error) {
+	return verifyPriceUpdate(update, nil)
+}
+
+// verifyPriceUpdate verifies a price update, and its guardian signatures unless guardians is nil
+func verifyPriceUpdate(update *PriceUpdate, guardians *GuardianVerifier) ([]*PriceFeedMessage, error) {
+	binaryUpdates, err := DecodeBinaryData(update.Binary)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*PriceFeedMessage)
+	var messages []*PriceFeedMessage
+	for i, data := range binaryUpdates {
+		accumulatorUpdate, err := ParseAccumulatorUpdate(data)
+		if err != nil {
+			return nil, fmt.Errorf("binary update %d: %w", i, err)
+		}
+		if guardians != nil {
+			err = guardians.VerifyAccumulatorUpdate(accumulatorUpdate)
+		} else {
+			err = accumulatorUpdate.Verify()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("binary update %d: %w", i, err)
+		}
+		updateMessages, err := accumulatorUpdate.PriceFeedMessages()
+		if err != nil {
+			return nil, fmt.Errorf("binary update %d: %w", i, err)
+		}
+		for _, message := range updateMessages {
+			byID[message.ID()] = message
+		}
+		messages = append(messages, updateMessages...)
+	}
+
+	for _, feed := range update.Parsed {
+		message, exists := byID[normalizePriceID(feed.ID)]
+		if !exists {
+			return nil, fmt.Errorf("feed %s: %w: no binary message", feed.ID, ErrParsedMismatch)
+		}
+		if err := matchParsedFeed(feed, message); err != nil {
+			return nil, fmt.Errorf("feed %s: %w", feed.ID, err)
+		}
+	}
+
+	return messages, nil
+}
+
+// matchParsedFeed compares the fields of a parsed feed with its price feed message
+func matchParsedFeed(feed PriceFeed, message *PriceFeedMessage) error {
+	checks := []struct {
+		field  string
+		parsed string
+		binary string
+	}{
+		{"price", feed.Price.Price, strconv.FormatInt(message.Price, 10)},
+		{"conf", feed.Price.Conf, strconv.FormatUint(message.Conf, 10)},
+		{"expo", strconv.Itoa(feed.Price.Expo), strconv.Itoa(int(message.Exponent))},
+		{"publish time", strconv.FormatInt(feed.Price.PublishTime, 10), strconv.FormatInt(message.PublishTime, 10)},
+		{"ema price", feed.EmaPrice.Price, strconv.FormatInt(message.EmaPrice, 10)},
+		{"ema conf", feed.EmaPrice.Conf, strconv.FormatUint(message.EmaConf, 10)},
+	}
+	for _, check := range checks {
+		if check.parsed != check.binary {
+			return fmt.Errorf("%w: %s %s, binary %s", ErrParsedMismatch, check.field, check.parsed, check.binary)
+		}
+	}
+	if feed.Metadata.PrevPublishTime != 0 && feed.Metadata.PrevPublishTime != message.PrevPublishTime {
+		return fmt.Errorf("%w: prev publish time %d, binary %d", ErrParsedMismatch, feed.Metadata.PrevPublishTime, message.PrevPublishTime)
+	}
+	return nil
+}
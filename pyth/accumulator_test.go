@@ -0,0 +1,212 @@
+package pyth
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testPriceFeedMessage encodes a price feed message for feed ID 0x<id repeated>
+func testPriceFeedMessage(id byte, price int64, conf uint64, expo int32, publishTime int64) []byte {
+	message := []byte{byte(MessageTypePriceFeed)}
+	for i := 0; i < 32; i++ {
+		message = append(message, id)
+	}
+	message = binary.BigEndian.AppendUint64(message, uint64(price))
+	message = binary.BigEndian.AppendUint64(message, conf)
+	message = binary.BigEndian.AppendUint32(message, uint32(expo))
+	message = binary.BigEndian.AppendUint64(message, uint64(publishTime))
+	message = binary.BigEndian.AppendUint64(message, uint64(publishTime-1)) // Previous publish time
+	message = binary.BigEndian.AppendUint64(message, uint64(price-10))      // EMA price
+	message = binary.BigEndian.AppendUint64(message, conf+1)                // EMA conf
+	return message
+}
+
+// testMerkleTree returns the root of a tree over 4 leaves and the proof of each leaf
+func testMerkleTree(messages [4][]byte) ([20]byte, [4][][20]byte) {
+	var leaves [4][20]byte
+	for i, message := range messages {
+		leaves[i] = merkleLeafHash(message)
+	}
+	left := merkleNodeHash(leaves[0], leaves[1])
+	right := merkleNodeHash(leaves[2], leaves[3])
+	return merkleNodeHash(left, right), [4][][20]byte{
+		{leaves[1], right},
+		{leaves[0], right},
+		{leaves[3], left},
+		{leaves[2], left},
+	}
+}
+
+// testVAA encodes an unsigned VAA around payload
+func testVAA(payload []byte) []byte {
+	vaa := []byte{1}                                                         // Version
+	vaa = binary.BigEndian.AppendUint32(vaa, 4)                              // Guardian set index
+	vaa = append(vaa, 0)                                                     // Signatures
+	vaa = binary.BigEndian.AppendUint32(vaa, 100)                            // Timestamp
+	vaa = binary.BigEndian.AppendUint32(vaa, 0)                              // Nonce
+	vaa = binary.BigEndian.AppendUint16(vaa, PythnetDataSource.EmitterChain) // Pythnet
+	vaa = append(vaa, PythnetDataSource.EmitterAddress[:]...)                // Emitter address
+	vaa = binary.BigEndian.AppendUint64(vaa, 7)                              // Sequence
+	vaa = append(vaa, 1)                                                     // Consistency level
+	return append(vaa, payload...)
+}
+
+// testAccumulatorUpdate encodes a PNAU update of 4 messages: three price feeds and a TWAP message
+func testAccumulatorUpdate() ([]byte, [4][]byte) {
+	return testAccumulatorUpdateWithVAA(testVAA)
+}
+
+// testAccumulatorUpdateWithVAA encodes the update of testAccumulatorUpdate with encodeVAA wrapping
+// the Merkle root payload
+func testAccumulatorUpdateWithVAA(encodeVAA func(payload []byte) []byte) ([]byte, [4][]byte) {
+	messages := [4][]byte{
+		testPriceFeedMessage(0xaa, 6500012345678, 1234, -8, 1700000000),
+		testPriceFeedMessage(0xbb, -42, 1, -2, 1700000001),
+		append([]byte{byte(MessageTypeTwap)}, make([]byte, 100)...),
+		testPriceFeedMessage(0xcc, 1, 0, 0, 1700000002),
+	}
+	root, proofs := testMerkleTree(messages)
+
+	payload := []byte(wormholeMerkleMagic)
+	payload = append(payload, updateTypeWormholeMerkle)
+	payload = binary.BigEndian.AppendUint64(payload, 123456) // Slot
+	payload = binary.BigEndian.AppendUint32(payload, 10000)  // Ring size
+	payload = append(payload, root[:]...)
+	vaa := encodeVAA(payload)
+
+	data := []byte(accumulatorMagic)
+	data = append(data, 1, 0, 2, 0xde, 0xad) // Version 1.0 with a 2-byte trailing header
+	data = append(data, updateTypeWormholeMerkle)
+	data = binary.BigEndian.AppendUint16(data, uint16(len(vaa)))
+	data = append(data, vaa...)
+	data = append(data, byte(len(messages)))
+	for i, message := range messages {
+		data = binary.BigEndian.AppendUint16(data, uint16(len(message)))
+		data = append(data, message...)
+		data = append(data, byte(len(proofs[i])))
+		for _, node := range proofs[i] {
+			data = append(data, node[:]...)
+		}
+	}
+	return data, messages
+}
+
+func TestParseAccumulatorUpdate(t *testing.T) {
+	data, messages := testAccumulatorUpdate()
+
+	update, err := ParseAccumulatorUpdate(data)
+	if err != nil {
+		t.Fatalf("ParseAccumulatorUpdate failed: %v", err)
+	}
+	if update.MajorVersion != 1 || update.VAA.GuardianSetIndex != 4 || update.VAA.EmitterChain != 26 || update.VAA.Sequence != 7 {
+		t.Errorf("Unexpected header or VAA: %+v %+v", update, update.VAA)
+	}
+	if update.Root.Slot != 123456 || update.Root.RingSize != 10000 {
+		t.Errorf("Unexpected Merkle root %+v", update.Root)
+	}
+	if len(update.Updates) != 4 || string(update.Updates[1].Message) != string(messages[1]) || len(update.Updates[1].Proof) != 2 {
+		t.Fatalf("Unexpected updates %+v", update.Updates)
+	}
+	if err := update.Verify(); err != nil {
+		t.Errorf("Expected every proof to verify, got %v", err)
+	}
+
+	priceMessages, err := update.PriceFeedMessages()
+	if err != nil {
+		t.Fatalf("PriceFeedMessages failed: %v", err)
+	}
+	if len(priceMessages) != 3 {
+		t.Fatalf("Expected the TWAP message to be skipped, got %d price messages", len(priceMessages))
+	}
+	btc := priceMessages[0]
+	if btc.ID() != strings.Repeat("aa", 32) || btc.Price != 6500012345678 || btc.Conf != 1234 || btc.Exponent != -8 ||
+		btc.PublishTime != 1700000000 || btc.PrevPublishTime != 1699999999 || btc.EmaPrice != 6500012345668 || btc.EmaConf != 1235 {
+		t.Errorf("Unexpected price message %+v", btc)
+	}
+	if priceMessages[1].Price != -42 || priceMessages[1].Exponent != -2 {
+		t.Errorf("Expected negative price and exponent, got %+v", priceMessages[1])
+	}
+}
+
+func TestAccumulatorUpdateRejectsTampering(t *testing.T) {
+	data, _ := testAccumulatorUpdate()
+	update, err := ParseAccumulatorUpdate(data)
+	if err != nil {
+		t.Fatalf("ParseAccumulatorUpdate failed: %v", err)
+	}
+
+	// A changed price no longer hashes to its leaf
+	update.Updates[0].Message[50]++
+	if err := update.Verify(); !errors.Is(err, ErrInvalidMerkleProof) {
+		t.Errorf("Expected a tampered message to fail verification, got %v", err)
+	}
+	update.Updates[0].Message[50]--
+
+	// A proof of another message does not prove this one
+	update.Updates[3].Proof = update.Updates[0].Proof
+	if err := update.Verify(); !errors.Is(err, ErrInvalidMerkleProof) {
+		t.Errorf("Expected a wrong proof to fail verification, got %v", err)
+	}
+}
+
+func TestParseAccumulatorUpdateRejectsMalformedData(t *testing.T) {
+	data, _ := testAccumulatorUpdate()
+
+	for name, malformed := range map[string][]byte{
+		"empty":          nil,
+		"wrong magic":    append([]byte("PNAX"), data[4:]...),
+		"major version":  append(append([]byte(accumulatorMagic), 2), data[5:]...),
+		"truncated":      data[:len(data)-5],
+		"trailing bytes": append(append([]byte{}, data...), 0),
+	} {
+		if _, err := ParseAccumulatorUpdate(malformed); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestVerifyPriceUpdate(t *testing.T) {
+	data, _ := testAccumulatorUpdate()
+	parsed := []PriceFeed{{
+		ID:       strings.Repeat("aa", 32),
+		Price:    Price{Price: "6500012345678", Conf: "1234", Expo: -8, PublishTime: 1700000000},
+		EmaPrice: Price{Price: "6500012345668", Conf: "1235", Expo: -8, PublishTime: 1700000000},
+		Metadata: Metadata{PrevPublishTime: 1699999999},
+	}}
+
+	for _, binaryData := range []*BinaryData{
+		{Encoding: "hex", Data: []string{hex.EncodeToString(data)}},
+		{Encoding: "base64", Data: []string{base64.StdEncoding.EncodeToString(data)}},
+	} {
+		messages, err := VerifyPriceUpdate(&PriceUpdate{Binary: binaryData, Parsed: parsed})
+		if err != nil {
+			t.Fatalf("%s: VerifyPriceUpdate failed: %v", binaryData.Encoding, err)
+		}
+		if len(messages) != 3 {
+			t.Errorf("%s: expected 3 verified messages, got %d", binaryData.Encoding, len(messages))
+		}
+	}
+
+	binaryData := &BinaryData{Encoding: "hex", Data: []string{hex.EncodeToString(data)}}
+
+	// A parsed value that differs from the signed message is caught
+	tampered := append([]PriceFeed{}, parsed...)
+	tampered[0].Price.Price = "6500012345679"
+	if _, err := VerifyPriceUpdate(&PriceUpdate{Binary: binaryData, Parsed: tampered}); !errors.Is(err, ErrParsedMismatch) {
+		t.Errorf("Expected a parsed price mismatch, got %v", err)
+	}
+
+	// So is a parsed feed without a binary message
+	missing := []PriceFeed{{ID: strings.Repeat("dd", 32)}}
+	if _, err := VerifyPriceUpdate(&PriceUpdate{Binary: binaryData, Parsed: missing}); !errors.Is(err, ErrParsedMismatch) {
+		t.Errorf("Expected a missing binary message to be reported, got %v", err)
+	}
+
+	if _, err := VerifyPriceUpdate(&PriceUpdate{Parsed: parsed}); err == nil {
+		t.Error("Expected an update without binary data to be rejected")
+	}
+}
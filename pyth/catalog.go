@@ -0,0 +1,291 @@
+package pyth
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultTickerDecimals is the display precision of tickers added from the Hermes catalog
+const DefaultTickerDecimals = 5
+
+// Ticker is an entry of a tickers file such as conf/pyth_tickers.yaml
+type Ticker struct {
+	Key         string `yaml:"-"` // Key of the entry in the file, e.g. "btc"
+	Symbol      string `yaml:"symbol"`
+	PriceID     string `yaml:"priceId"`
+	Decimals    int    `yaml:"decimals"`
+	Description string `yaml:"description"`
+	Category    string `yaml:"category"` // Asset type, e.g. "crypto" or "equity"
+}
+
+// CatalogIssueKind classifies a ticker that does not match the Hermes catalog
+type CatalogIssueKind string
+
+const (
+	CatalogIssueInvalidID         CatalogIssueKind = "invalid_id"          // Not a 32-byte hex price ID
+	CatalogIssueDuplicateID       CatalogIssueKind = "duplicate_id"        // Another ticker has the same price ID
+	CatalogIssueRemoved           CatalogIssueKind = "removed"             // Hermes does not list the price ID
+	CatalogIssueSymbolMismatch    CatalogIssueKind = "symbol_mismatch"     // Hermes lists the feed under another symbol
+	CatalogIssueAssetTypeMismatch CatalogIssueKind = "asset_type_mismatch" // Hermes lists the feed with another asset type
+)
+
+// CatalogIssue is a ticker that does not match the Hermes catalog
+type CatalogIssue struct {
+	Key        string
+	PriceID    string
+	Kind       CatalogIssueKind
+	Configured string // Configured symbol or category; for duplicates the key of the first ticker with the ID
+	Hermes     string // Symbol or asset type in the Hermes catalog
+}
+
+// String describes the issue
+func (i CatalogIssue) String() string {
+	switch i.Kind {
+	case CatalogIssueInvalidID:
+		return fmt.Sprintf("%s: invalid price ID %q", i.Key, i.PriceID)
+	case CatalogIssueDuplicateID:
+		return fmt.Sprintf("%s: price ID %s is also used by %s", i.Key, i.PriceID, i.Configured)
+	case CatalogIssueRemoved:
+		return fmt.Sprintf("%s: price ID %s is not in the Hermes catalog", i.Key, i.PriceID)
+	case CatalogIssueSymbolMismatch:
+		return fmt.Sprintf("%s: symbol %s, Hermes lists %s", i.Key, i.Configured, i.Hermes)
+	case CatalogIssueAssetTypeMismatch:
+		return fmt.Sprintf("%s: category %s, Hermes lists asset type %s", i.Key, i.Configured, i.Hermes)
+	}
+	return fmt.Sprintf("%s: %s", i.Key, i.Kind)
+}
+
+// CatalogSyncOptions selects what SyncTickers changes besides validating
+type CatalogSyncOptions struct {
+	// Query and AssetType select catalog feeds to add when they are not configured yet,
+	// e.g. AssetTypeEquity for every equity feed. Nothing is added when both are nil.
+	Query     *string
+	AssetType *AssetType
+	// Prune removes tickers whose price ID Hermes no longer lists
+	Prune bool
+	// Decimals of added tickers, 0 uses DefaultTickerDecimals
+	Decimals int
+}
+
+// CatalogSyncReport is the result of SyncTickers
+type CatalogSyncReport struct {
+	Issues  []CatalogIssue // Problems of the tickers before the sync
+	Added   []Ticker
+	Removed []Ticker
+	Output  []byte // The updated tickers file
+}
+
+// ParseTickers parses a tickers file, keeping the order of its entries
+func ParseTickers(data []byte) ([]Ticker, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse tickers: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil // Empty or only comments
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("tickers must map keys to tickers")
+	}
+
+	tickers := make([]Ticker, 0, len(root.Content)/2)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		var ticker Ticker
+		if err := root.Content[i+1].Decode(&ticker); err != nil {
+			return nil, fmt.Errorf("failed to parse ticker %s: %w", root.Content[i].Value, err)
+		}
+		ticker.Key = root.Content[i].Value
+		tickers = append(tickers, ticker)
+	}
+	return tickers, nil
+}
+
+// ValidateTickers checks every ticker's price ID against the Hermes catalog, as returned by
+// GetPriceFeeds without options, and reports invalid, duplicate and removed IDs and feeds listed
+// under another symbol or asset type
+func ValidateTickers(tickers []Ticker, catalog []PriceFeedMetadata) []CatalogIssue {
+	byID := make(map[string]PriceFeedMetadata, len(catalog))
+	for _, feed := range catalog {
+		byID[normalizePriceID(feed.ID)] = feed
+	}
+
+	var issues []CatalogIssue
+	seen := make(map[string]string) // price ID -> key of the first ticker using it
+	for _, ticker := range tickers {
+		id := normalizePriceID(ticker.PriceID)
+		if decoded, err := hex.DecodeString(id); err != nil || len(decoded) != 32 {
+			issues = append(issues, CatalogIssue{Key: ticker.Key, PriceID: ticker.PriceID, Kind: CatalogIssueInvalidID})
+			continue
+		}
+		if first, exists := seen[id]; exists {
+			issues = append(issues, CatalogIssue{Key: ticker.Key, PriceID: id, Kind: CatalogIssueDuplicateID, Configured: first})
+		} else {
+			seen[id] = ticker.Key
+		}
+
+		feed, exists := byID[id]
+		if !exists {
+			issues = append(issues, CatalogIssue{Key: ticker.Key, PriceID: id, Kind: CatalogIssueRemoved})
+			continue
+		}
+		if feed.Symbol != "" && !strings.EqualFold(ticker.Symbol, feed.Symbol) {
+			issues = append(issues, CatalogIssue{Key: ticker.Key, PriceID: id, Kind: CatalogIssueSymbolMismatch, Configured: ticker.Symbol, Hermes: feed.Symbol})
+		}
+		if ticker.Category != "" && feed.AssetType != "" && !strings.EqualFold(ticker.Category, string(feed.AssetType)) {
+			issues = append(issues, CatalogIssue{Key: ticker.Key, PriceID: id, Kind: CatalogIssueAssetTypeMismatch, Configured: ticker.Category, Hermes: string(feed.AssetType)})
+		}
+	}
+	return issues
+}
+
+// TickerFromFeed returns the ticker of a catalog feed, without a key
+func TickerFromFeed(feed PriceFeedMetadata, decimals int) Ticker {
+	return Ticker{
+		Symbol:      feed.Symbol,
+		PriceID:     normalizePriceID(feed.ID),
+		Decimals:    decimals,
+		Description: feed.Description,
+		Category:    string(feed.AssetType),
+	}
+}
+
+// SyncTickers validates a tickers file against the Hermes catalog and returns it updated: feeds
+// selected by options.Query and options.AssetType that are not configured are appended, and with
+// options.Prune tickers Hermes no longer lists are removed. Other entries and comments are kept as
+// they are. Empty data generates a new file.
+func (c *HermesClient) SyncTickers(ctx context.Context, data []byte, options *CatalogSyncOptions) (*CatalogSyncReport, error) {
+	if options == nil {
+		options = &CatalogSyncOptions{}
+	}
+	decimals := options.Decimals
+	if decimals == 0 {
+		decimals = DefaultTickerDecimals
+	}
+
+	tickers, err := ParseTickers(data)
+	if err != nil {
+		return nil, err
+	}
+	catalog, err := c.GetPriceFeeds(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the Hermes catalog: %w", err)
+	}
+	report := &CatalogSyncReport{Issues: ValidateTickers(tickers, catalog)}
+
+	output := string(data)
+	if options.Prune {
+		removed := make(map[string]bool)
+		for _, issue := range report.Issues {
+			if issue.Kind == CatalogIssueRemoved {
+				removed[issue.Key] = true
+			}
+		}
+		for _, ticker := range tickers {
+			if removed[ticker.Key] {
+				report.Removed = append(report.Removed, ticker)
+			}
+		}
+		output = removeTickers(output, removed)
+	}
+
+	if options.Query != nil || options.AssetType != nil {
+		selected, err := c.GetPriceFeeds(ctx, &GetPriceFeedsOptions{Query: options.Query, AssetType: options.AssetType})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the selected Hermes feeds: %w", err)
+		}
+		sort.Slice(selected, func(i, j int) bool { return selected[i].Symbol < selected[j].Symbol })
+
+		configured := make(map[string]bool, len(tickers))
+		usedKeys := make(map[string]bool, len(tickers))
+		for _, ticker := range tickers {
+			configured[normalizePriceID(ticker.PriceID)] = true
+			usedKeys[ticker.Key] = true
+		}
+		for _, feed := range selected {
+			ticker := TickerFromFeed(feed, decimals)
+			if configured[ticker.PriceID] {
+				continue
+			}
+			configured[ticker.PriceID] = true
+			ticker.Key = tickerKey(ticker.Symbol, usedKeys)
+			report.Added = append(report.Added, ticker)
+		}
+	}
+
+	if strings.TrimSpace(output) == "" && len(report.Added) > 0 {
+		output = fmt.Sprintf("# Pyth Network price feeds\n# Source: Hermes price feed catalog (%s)\n", time.Now().UTC().Format("02-Jan-2006"))
+	}
+	for _, ticker := range report.Added {
+		if !strings.HasSuffix(output, "\n") {
+			output += "\n"
+		}
+		output += "\n" + formatTicker(ticker)
+	}
+	report.Output = []byte(output)
+	return report, nil
+}
+
+// plainScalar matches symbols written without quotes
+var plainScalar = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9./_-]*$`)
+
+// formatTicker formats a ticker the way conf/pyth_tickers.yaml lays out its entries
+func formatTicker(ticker Ticker) string {
+	symbol := ticker.Symbol
+	if !plainScalar.MatchString(symbol) {
+		symbol = fmt.Sprintf("%q", symbol)
+	}
+	return fmt.Sprintf("%s:\n  symbol:      %s\n  priceId:    %q\n  decimals:    %d\n  description: %q\n  category:    %q\n",
+		ticker.Key, symbol, ticker.PriceID, ticker.Decimals, ticker.Description, ticker.Category)
+}
+
+// nonKeyChars matches the characters replaced in keys derived from symbols
+var nonKeyChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// tickerKey derives an unused key from a symbol, e.g. "AAPL/USD" -> "aapl", and marks it used
+func tickerKey(symbol string, used map[string]bool) string {
+	base := strings.TrimSuffix(strings.ToLower(symbol), "/usd")
+	base = strings.Trim(nonKeyChars.ReplaceAllString(base, "_"), "_")
+	if base == "" {
+		base = "feed"
+	}
+	key := base
+	for n := 2; used[key]; n++ {
+		key = fmt.Sprintf("%s_%d", base, n)
+	}
+	used[key] = true
+	return key
+}
+
+// removeTickers removes the entries of the given keys, with the blank lines after them, from a
+// tickers file
+func removeTickers(data string, keys map[string]bool) string {
+	if len(keys) == 0 {
+		return data
+	}
+	var out strings.Builder
+	skipping := false
+	for _, line := range strings.SplitAfter(data, "\n") {
+		// Entries and comments start at the first column; everything else belongs to the entry above
+		if trimmed := strings.TrimSpace(line); trimmed != "" && line[0] != ' ' && line[0] != '\t' {
+			key, _, isEntry := strings.Cut(line, ":")
+			skipping = isEntry && keys[strings.TrimSpace(key)]
+		}
+		if !skipping {
+			out.WriteString(line)
+		}
+	}
+	return out.String()
+}
+
+// normalizePriceID lowercases a price ID and strips its 0x, the form Hermes returns
+func normalizePriceID(id string) string {
+	return strings.ToLower(strings.TrimPrefix(id, "0x"))
+}
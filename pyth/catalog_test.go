@@ -0,0 +1,158 @@
+package pyth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+var (
+	testBTCID  = strings.Repeat("e6", 32)
+	testAAPLID = strings.Repeat("49", 32)
+	testTSLAID = strings.Repeat("16", 32)
+)
+
+// newTestCatalog serves a Hermes price feed catalog of BTC, AAPL and TSLA in the format Hermes
+// uses, filtering by asset_type
+func newTestCatalog(t *testing.T) *httptest.Server {
+	t.Helper()
+	feeds := []struct{ id, symbol, assetType, description string }{
+		{testBTCID, "BTC/USD", "Crypto", "BITCOIN / US DOLLAR"},
+		{testAAPLID, "AAPL/USD", "Equity", "APPLE INC / US DOLLAR"},
+		{testTSLAID, "TSLA/USD", "Equity", "TESLA INC / US DOLLAR"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/price_feeds" {
+			http.NotFound(w, r)
+			return
+		}
+		var entries []string
+		for _, feed := range feeds {
+			if assetType := r.URL.Query().Get("asset_type"); assetType != "" && !strings.EqualFold(assetType, feed.assetType) {
+				continue
+			}
+			entries = append(entries, fmt.Sprintf(`{"id":"%s","attributes":{"asset_type":"%s","display_symbol":"%s","description":"%s","symbol":"%s.%s"}}`,
+				feed.id, feed.assetType, feed.symbol, feed.description, feed.assetType, feed.symbol))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(entries, ","))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestValidateTickers(t *testing.T) {
+	client := NewHermesClient(newTestCatalog(t).URL, nil)
+	catalog, err := client.GetPriceFeeds(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("GetPriceFeeds failed: %v", err)
+	}
+	if len(catalog) != 3 || catalog[1].Symbol != "AAPL/USD" || catalog[1].AssetType != AssetTypeEquity {
+		t.Fatalf("Expected the Hermes attributes to be decoded, got %+v", catalog)
+	}
+
+	tickers := []Ticker{
+		{Key: "btc", Symbol: "BTC/USD", PriceID: "0x" + strings.ToUpper(testBTCID), Category: "crypto"},
+		{Key: "aapl", Symbol: "AAPLX/USD", PriceID: testAAPLID, Category: "crypto"},
+		{Key: "btc2", Symbol: "BTC/USD", PriceID: testBTCID},
+		{Key: "gone", Symbol: "GONE/USD", PriceID: strings.Repeat("00", 32)},
+		{Key: "tbd", Symbol: "TBD/USD", PriceID: "TBD"},
+	}
+	issues := ValidateTickers(tickers, catalog)
+
+	want := []struct {
+		key  string
+		kind CatalogIssueKind
+	}{
+		{"aapl", CatalogIssueSymbolMismatch},
+		{"aapl", CatalogIssueAssetTypeMismatch},
+		{"btc2", CatalogIssueDuplicateID},
+		{"gone", CatalogIssueRemoved},
+		{"tbd", CatalogIssueInvalidID},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("Expected %d issues, got %v", len(want), issues)
+	}
+	for i, issue := range issues {
+		if issue.Key != want[i].key || issue.Kind != want[i].kind {
+			t.Errorf("Issue %d: expected %s %s, got %s", i, want[i].key, want[i].kind, issue)
+		}
+	}
+	if issues[0].Hermes != "AAPL/USD" || issues[2].Configured != "btc" {
+		t.Errorf("Unexpected issue details %+v", issues)
+	}
+}
+
+func TestSyncTickers(t *testing.T) {
+	client := NewHermesClient(newTestCatalog(t).URL, nil)
+	data := "# Configured feeds\n\nbtc:\n  symbol:      BTC/USD\n  priceId:    \"" + testBTCID + "\"\n  decimals:    5\n" +
+		"\ngone:\n  symbol:      GONE/USD\n  priceId:    \"" + strings.Repeat("00", 32) + "\"\n\n# tsla:\n#   symbol: TSLA/USD\n" +
+		"\naapl:\n  symbol:      AAPL/USD\n  priceId:    \"" + testAAPLID + "\"\n"
+
+	assetType := AssetTypeEquity
+	report, err := client.SyncTickers(context.Background(), []byte(data), &CatalogSyncOptions{AssetType: &assetType, Prune: true})
+	if err != nil {
+		t.Fatalf("SyncTickers failed: %v", err)
+	}
+	if len(report.Removed) != 1 || report.Removed[0].Key != "gone" {
+		t.Errorf("Expected gone to be removed, got %+v", report.Removed)
+	}
+	// AAPL is configured already, only TSLA is added
+	if len(report.Added) != 1 || report.Added[0].Key != "tsla" || report.Added[0].Category != "equity" {
+		t.Fatalf("Expected tsla to be added, got %+v", report.Added)
+	}
+
+	output := string(report.Output)
+	if strings.Contains(output, "gone:") || !strings.Contains(output, "# tsla:\n#   symbol: TSLA/USD\n") {
+		t.Errorf("Expected only the removed entry to be dropped:\n%s", output)
+	}
+	tickers, err := ParseTickers(report.Output)
+	if err != nil {
+		t.Fatalf("Failed to parse the synced tickers: %v", err)
+	}
+	var keys []string
+	for _, ticker := range tickers {
+		keys = append(keys, ticker.Key)
+	}
+	if strings.Join(keys, ",") != "btc,aapl,tsla" {
+		t.Errorf("Expected btc,aapl,tsla in order, got %v", keys)
+	}
+	if tsla := tickers[2]; tsla.PriceID != testTSLAID || tsla.Decimals != DefaultTickerDecimals || tsla.Description != "TESLA INC / US DOLLAR" {
+		t.Errorf("Unexpected added ticker %+v", tsla)
+	}
+
+	// Syncing an empty file generates one
+	report, err = client.SyncTickers(context.Background(), nil, &CatalogSyncOptions{AssetType: &assetType})
+	if err != nil {
+		t.Fatalf("SyncTickers failed: %v", err)
+	}
+	if tickers, err := ParseTickers(report.Output); err != nil || len(tickers) != 2 || !strings.HasPrefix(string(report.Output), "# ") {
+		t.Errorf("Expected a generated file with 2 tickers, got %v:\n%s", err, report.Output)
+	}
+}
+
+func TestConfiguredTickersAreValid(t *testing.T) {
+	data, err := os.ReadFile("../conf/pyth_tickers.yaml")
+	if err != nil {
+		t.Fatalf("Failed to read tickers: %v", err)
+	}
+	tickers, err := ParseTickers(data)
+	if err != nil {
+		t.Fatalf("ParseTickers failed: %v", err)
+	}
+	if len(tickers) == 0 || tickers[0].Key != "btc" || tickers[0].Symbol != "BTC/USD" {
+		t.Fatalf("Expected btc first, got %+v", tickers)
+	}
+
+	// Against a catalog of their own IDs only malformed and duplicate IDs are reported
+	catalog := make([]PriceFeedMetadata, 0, len(tickers))
+	for _, ticker := range tickers {
+		catalog = append(catalog, PriceFeedMetadata{ID: ticker.PriceID})
+	}
+	for _, issue := range ValidateTickers(tickers, catalog) {
+		t.Errorf("Unexpected issue %s", issue)
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/morpheum-labs/pricefeeding/internal/ctxutil"
 )
 
 // HermesClient represents a client for interacting with the Pyth Hermes service
@@ -20,6 +22,7 @@ type HermesClient struct {
 	httpRetries int
 	headers     map[string]string
 	httpClient  *http.Client
+	guardians   *GuardianVerifier
 }
 
 // NewHermesClient creates a new Hermes client
@@ -39,6 +42,11 @@ func NewHermesClient(endpoint string, config *HermesClientConfig) *HermesClient
 		headers = config.Headers
 	}
 
+	var guardians *GuardianVerifier
+	if config != nil {
+		guardians = config.Guardians
+	}
+
 	httpClient := &http.Client{
 		Timeout: time.Duration(timeout) * time.Millisecond,
 	}
@@ -49,6 +57,7 @@ func NewHermesClient(endpoint string, config *HermesClientConfig) *HermesClient
 		httpRetries: httpRetries,
 		headers:     headers,
 		httpClient:  httpClient,
+		guardians:   guardians,
 	}
 }
 
@@ -74,8 +83,10 @@ func (c *HermesClient) httpRequest(ctx context.Context, method, url string, body
 		if err != nil {
 			lastErr = fmt.Errorf("HTTP request failed: %w", err)
 			if attempt < c.httpRetries {
-				// Wait for backoff period before retrying
-				time.Sleep(time.Duration(backoff) * time.Millisecond)
+				// Wait for backoff period before retrying, unless the caller gives up first
+				if err := ctxutil.Sleep(ctx, time.Duration(backoff)*time.Millisecond); err != nil {
+					return lastErr
+				}
 				backoff *= 2 // Exponential backoff
 				continue
 			}
@@ -88,7 +99,10 @@ func (c *HermesClient) httpRequest(ctx context.Context, method, url string, body
 			bodyBytes, _ := io.ReadAll(resp.Body)
 			lastErr = fmt.Errorf("HTTP error! status: %d, body: %s", resp.StatusCode, string(bodyBytes))
 			if attempt < c.httpRetries {
-				time.Sleep(time.Duration(backoff) * time.Millisecond)
+				resp.Body.Close()
+				if err := ctxutil.Sleep(ctx, time.Duration(backoff)*time.Millisecond); err != nil {
+					return lastErr
+				}
 				backoff *= 2
 				continue
 			}
@@ -108,15 +122,10 @@ func (c *HermesClient) httpRequest(ctx context.Context, method, url string, body
 	return lastErr
 }
 
-// buildURL constructs a URL for the given endpoint
+// buildURL constructs the URL of a v2 API endpoint, e.g. "updates/price/latest", under the base URL
 func (c *HermesClient) buildURL(endpoint string) *url.URL {
-	baseURL := c.baseURL
-	if !strings.HasSuffix(baseURL, "/") {
-		baseURL += "/"
-	}
-
-	u, _ := url.Parse(baseURL)
-	u.Path = strings.TrimSuffix(u.Path, "/") + endpoint
+	u, _ := url.Parse(c.baseURL)
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/v2/" + strings.TrimPrefix(endpoint, "/")
 	return u
 }
 
@@ -150,7 +159,7 @@ func (c *HermesClient) appendURLSearchParams(u *url.URL, params map[string]inter
 
 // GetPriceFeeds fetches the set of available price feeds
 func (c *HermesClient) GetPriceFeeds(ctx context.Context, options *GetPriceFeedsOptions) ([]PriceFeedMetadata, error) {
-	u := c.buildURL("v2/priceFeeds")
+	u := c.buildURL("price_feeds")
 
 	if options != nil {
 		params := make(map[string]interface{})
@@ -158,7 +167,7 @@ func (c *HermesClient) GetPriceFeeds(ctx context.Context, options *GetPriceFeeds
 			params["query"] = *options.Query
 		}
 		if options.AssetType != nil {
-			params["assetType"] = string(*options.AssetType)
+			params["asset_type"] = string(*options.AssetType)
 		}
 		c.appendURLSearchParams(u, params)
 	}
@@ -170,7 +179,7 @@ func (c *HermesClient) GetPriceFeeds(ctx context.Context, options *GetPriceFeeds
 
 // GetLatestPriceUpdates fetches the latest price updates for a set of price feed IDs
 func (c *HermesClient) GetLatestPriceUpdates(ctx context.Context, ids []HexString, options *GetLatestPriceUpdatesOptions) (*PriceUpdate, error) {
-	u := c.buildURL("v2/updates/price/latest")
+	u := c.buildURL("updates/price/latest")
 
 	// Add price IDs as query parameters
 	query := u.Query()
@@ -194,13 +203,15 @@ func (c *HermesClient) GetLatestPriceUpdates(ctx context.Context, ids []HexStrin
 	}
 
 	var result PriceUpdate
-	err := c.httpRequest(ctx, "GET", u.String(), nil, &result)
-	return &result, err
+	if err := c.httpRequest(ctx, "GET", u.String(), nil, &result); err != nil {
+		return &result, err
+	}
+	return &result, c.verifyPriceUpdate(&result)
 }
 
 // GetPriceUpdatesAtTimestamp fetches price updates for a set of price feed IDs at a given timestamp
 func (c *HermesClient) GetPriceUpdatesAtTimestamp(ctx context.Context, publishTime UnixTimestamp, ids []HexString, options *GetPriceUpdatesAtTimestampOptions) (*PriceUpdate, error) {
-	u := c.buildURL(fmt.Sprintf("v2/updates/price/%d", publishTime))
+	u := c.buildURL(fmt.Sprintf("updates/price/%d", publishTime))
 
 	// Add price IDs as query parameters
 	query := u.Query()
@@ -224,13 +235,26 @@ func (c *HermesClient) GetPriceUpdatesAtTimestamp(ctx context.Context, publishTi
 	}
 
 	var result PriceUpdate
-	err := c.httpRequest(ctx, "GET", u.String(), nil, &result)
-	return &result, err
+	if err := c.httpRequest(ctx, "GET", u.String(), nil, &result); err != nil {
+		return &result, err
+	}
+	return &result, c.verifyPriceUpdate(&result)
+}
+
+// verifyPriceUpdate checks the guardian signatures of a price update when guardians are configured
+func (c *HermesClient) verifyPriceUpdate(update *PriceUpdate) error {
+	if c.guardians == nil {
+		return nil
+	}
+	if _, err := c.guardians.VerifyPriceUpdate(update); err != nil {
+		return fmt.Errorf("price update failed verification: %w", err)
+	}
+	return nil
 }
 
 // GetLatestTwaps fetches the latest TWAP (time weighted average price) for a set of price feed IDs
 func (c *HermesClient) GetLatestTwaps(ctx context.Context, ids []HexString, windowSeconds int, options *GetLatestTwapsOptions) (*TwapsResponse, error) {
-	u := c.buildURL(fmt.Sprintf("v2/updates/twap/%d/latest", windowSeconds))
+	u := c.buildURL(fmt.Sprintf("updates/twap/%d/latest", windowSeconds))
 
 	// Add price IDs as query parameters
 	query := u.Query()
@@ -260,7 +284,7 @@ func (c *HermesClient) GetLatestTwaps(ctx context.Context, ids []HexString, wind
 
 // GetLatestPublisherCaps fetches the latest publisher stake caps
 func (c *HermesClient) GetLatestPublisherCaps(ctx context.Context, options *GetLatestPublisherCapsOptions) (*PublisherCaps, error) {
-	u := c.buildURL("v2/updates/publisher_stake_caps/latest")
+	u := c.buildURL("updates/publisher_stake_caps/latest")
 
 	if options != nil {
 		params := make(map[string]interface{})
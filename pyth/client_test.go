@@ -54,8 +54,8 @@ func TestNewHermesClientWithConfig(t *testing.T) {
 func TestGetPriceFeeds(t *testing.T) {
 	// Create a mock server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/v2/priceFeeds" {
-			t.Errorf("Expected path '/v2/priceFeeds', got '%s'", r.URL.Path)
+		if r.URL.Path != "/v2/price_feeds" {
+			t.Errorf("Expected path '/v2/price_feeds', got '%s'", r.URL.Path)
 		}
 
 		// Check query parameters
@@ -64,7 +64,7 @@ func TestGetPriceFeeds(t *testing.T) {
 			t.Errorf("Expected query 'btc', got '%s'", query)
 		}
 
-		assetType := r.URL.Query().Get("assetType")
+		assetType := r.URL.Query().Get("asset_type")
 		if assetType != "crypto" {
 			t.Errorf("Expected assetType 'crypto', got '%s'", assetType)
 		}
@@ -324,3 +324,27 @@ func TestBuildURLWithTrailingSlash(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestHTTPRequestBackoffHonorsContext(t *testing.T) {
+	// Always fail so the client keeps backing off
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	// With 10 retries the backoff alone would take well over a minute
+	retries := 10
+	client := NewHermesClient(server.URL, &HermesClientConfig{HTTPRetries: &retries})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetLatestPriceUpdates(ctx, []HexString{"e62df6c8b4a85fe1a67db44dc12de5db330f7ac66b72dc658afedf0f4a415b43"}, nil)
+	if err == nil {
+		t.Fatal("Expected an error from a failing server")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the request to stop at the context deadline, took %v", elapsed)
+	}
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
@@ -100,9 +99,9 @@ func main() {
 		}
 	}
 
-	// Streaming price updates
+	// Streaming price updates, decoded
 	fmt.Printf("Streaming latest prices for price IDs %v...\n", ids)
-	eventSource, err := client.GetPriceUpdatesStream(ctx, hexIds, &pyth.GetPriceUpdatesStreamOptions{
+	stream, err := client.StreamPriceUpdates(ctx, hexIds, &pyth.GetPriceUpdatesStreamOptions{
 		Encoding:       &encoding,
 		Parsed:         &parsed,
 		AllowUnordered: boolPtr(false),
@@ -112,35 +111,24 @@ func main() {
 		log.Fatalf("Failed to get price updates stream: %v", err)
 	}
 
-	// Set up event handlers
-	eventSource.OnMessage(func(data string) {
-		fmt.Println("Received price update:", data)
+	// Close the stream after 5 seconds; Updates and Errors are closed with it
+	time.AfterFunc(5*time.Second, func() {
+		fmt.Println("Closing price update stream.")
+		stream.Close()
+	})
 
-		// Parse the price update
-		var priceUpdate pyth.PriceUpdate
-		if err := json.Unmarshal([]byte(data), &priceUpdate); err != nil {
-			fmt.Printf("Failed to parse price update: %v\n", err)
-			return
+	// Errors are not terminal: the stream reconnects and resumes from the last event ID
+	go func() {
+		for err := range stream.Errors() {
+			fmt.Printf("Error receiving updates: %v\n", err)
 		}
+	}()
 
-		if priceUpdate.Parsed != nil {
-			for _, feed := range priceUpdate.Parsed {
-				fmt.Printf("Streamed Price Feed ID: %s, Price: %s\n", feed.ID, feed.Price.Price)
-			}
+	for update := range stream.Updates() {
+		for _, feed := range update.Parsed {
+			fmt.Printf("Streamed Price Feed ID: %s, Price: %s\n", feed.ID, feed.Price.Price)
 		}
-	})
-
-	eventSource.OnError(func(err error) {
-		fmt.Printf("Error receiving updates: %v\n", err)
-		eventSource.Close()
-	})
-
-	// Wait for 5 seconds to receive updates
-	time.Sleep(5 * time.Second)
-
-	// Close the event source
-	fmt.Println("Closing event source.")
-	eventSource.Close()
+	}
 }
 
 // extractBasicAuthFromURL extracts basic authentication from a URL
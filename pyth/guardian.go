@@ -0,0 +1,169 @@
+package pyth
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrUnknownGuardianSet is returned when a VAA is signed by a guardian set that is not configured
+	ErrUnknownGuardianSet = errors.New("unknown guardian set")
+	// ErrGuardianSetExpired is returned when a VAA is signed by a replaced guardian set past its expiry
+	ErrGuardianSetExpired = errors.New("guardian set has expired")
+	// ErrNoQuorum is returned when a VAA has fewer signatures than the guardian set's quorum
+	ErrNoQuorum = errors.New("not enough guardian signatures")
+	// ErrInvalidSignature is returned when a signature was not made by the guardian it claims
+	ErrInvalidSignature = errors.New("invalid guardian signature")
+	// ErrUnknownDataSource is returned when a VAA was emitted by an emitter that is not allowed
+	ErrUnknownDataSource = errors.New("unknown data source")
+)
+
+// DataSource is a Wormhole emitter whose VAAs carry Pyth prices
+type DataSource struct {
+	EmitterChain   uint16
+	EmitterAddress [32]byte
+}
+
+// PythnetDataSource is the Pythnet accumulator emitter that signs the Merkle roots Hermes serves
+var PythnetDataSource = DataSource{
+	EmitterChain:   26,
+	EmitterAddress: common.HexToHash("0xe101faedac5851e32b9b23b5f9411a8c2bac4aae3ed4dd7b811dd1a72ea4aa71"),
+}
+
+// GuardianSet is a Wormhole guardian set: the guardians' Ethereum addresses, in guardian index order
+type GuardianSet struct {
+	Index uint32
+	Keys  []common.Address
+	// ExpirationTime is when the set stops being accepted once it has been replaced by a newer set.
+	// The latest set never expires; Wormhole keeps a replaced set valid for 24 hours.
+	ExpirationTime time.Time
+}
+
+// Quorum returns the number of signatures a VAA needs: more than two thirds of the guardians
+func (s *GuardianSet) Quorum() int {
+	return len(s.Keys)*2/3 + 1
+}
+
+// GuardianVerifier verifies VAA signatures against the configured guardian sets, accepting only
+// VAAs from the allowed data sources
+type GuardianVerifier struct {
+	mu      sync.RWMutex
+	sets    map[uint32]*GuardianSet
+	current uint32 // Index of the latest set
+	sources map[DataSource]bool
+
+	now func() time.Time
+}
+
+// NewGuardianVerifier creates a verifier for the given guardian sets that accepts VAAs from
+// PythnetDataSource
+func NewGuardianVerifier(sets ...*GuardianSet) *GuardianVerifier {
+	v := &GuardianVerifier{
+		sets:    make(map[uint32]*GuardianSet),
+		sources: map[DataSource]bool{PythnetDataSource: true},
+		now:     time.Now,
+	}
+	for _, set := range sets {
+		v.AddGuardianSet(set)
+	}
+	return v
+}
+
+// AddGuardianSet adds or replaces a guardian set. The set with the highest index is the current one.
+func (v *GuardianVerifier) AddGuardianSet(set *GuardianSet) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.sets[set.Index] = set
+	if set.Index > v.current {
+		v.current = set.Index
+	}
+}
+
+// SetDataSources replaces the emitters whose VAAs are accepted
+func (v *GuardianVerifier) SetDataSources(sources ...DataSource) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.sources = make(map[DataSource]bool, len(sources))
+	for _, source := range sources {
+		v.sources[source] = true
+	}
+}
+
+// GetGuardianSet returns a guardian set by index
+func (v *GuardianVerifier) GetGuardianSet(index uint32) (*GuardianSet, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	set, exists := v.sets[index]
+	return set, exists
+}
+
+// VerifyVAA checks that a VAA comes from an allowed data source and is signed by a quorum of its
+// guardian set. Signatures must be in strictly increasing guardian index order, as the Wormhole
+// contracts require.
+func (v *GuardianVerifier) VerifyVAA(vaa *VAA) error {
+	v.mu.RLock()
+	set, exists := v.sets[vaa.GuardianSetIndex]
+	current := v.current
+	allowed := v.sources[DataSource{EmitterChain: vaa.EmitterChain, EmitterAddress: vaa.EmitterAddress}]
+	v.mu.RUnlock()
+
+	// Guardians sign every emitter's messages, so a valid quorum alone does not make a VAA a price update
+	if !allowed {
+		return fmt.Errorf("%w: chain %d emitter %x", ErrUnknownDataSource, vaa.EmitterChain, vaa.EmitterAddress)
+	}
+	if !exists {
+		return fmt.Errorf("%w: %d", ErrUnknownGuardianSet, vaa.GuardianSetIndex)
+	}
+	if set.Index != current && !set.ExpirationTime.IsZero() && v.now().After(set.ExpirationTime) {
+		return fmt.Errorf("%w: %d expired at %s", ErrGuardianSetExpired, set.Index, set.ExpirationTime.Format(time.RFC3339))
+	}
+	if len(vaa.Signatures) < set.Quorum() {
+		return fmt.Errorf("%w: %d of %d required", ErrNoQuorum, len(vaa.Signatures), set.Quorum())
+	}
+
+	digest := vaa.SigningDigest()
+	lastIndex := -1
+	for _, signature := range vaa.Signatures {
+		index := int(signature.GuardianIndex)
+		if index <= lastIndex {
+			return fmt.Errorf("%w: guardian %d is out of order", ErrInvalidSignature, index)
+		}
+		lastIndex = index
+		if index >= len(set.Keys) {
+			return fmt.Errorf("%w: guardian %d is not in set %d", ErrInvalidSignature, index, set.Index)
+		}
+
+		publicKey, err := crypto.SigToPub(digest[:], signature.Signature[:])
+		if err != nil {
+			return fmt.Errorf("%w: guardian %d: %v", ErrInvalidSignature, index, err)
+		}
+		if signer := crypto.PubkeyToAddress(*publicKey); signer != set.Keys[index] {
+			return fmt.Errorf("%w: guardian %d signed by %s", ErrInvalidSignature, index, signer.Hex())
+		}
+	}
+
+	return nil
+}
+
+// VerifyAccumulatorUpdate checks the guardian signatures of an accumulator update's VAA and the
+// Merkle proofs of its messages
+func (v *GuardianVerifier) VerifyAccumulatorUpdate(update *AccumulatorUpdate) error {
+	if err := v.VerifyVAA(update.VAA); err != nil {
+		return err
+	}
+	return update.Verify()
+}
+
+// VerifyPriceUpdate is VerifyPriceUpdate that also requires every binary update to be signed by
+// a quorum of guardians
+func (v *GuardianVerifier) VerifyPriceUpdate(update *PriceUpdate) ([]*PriceFeedMessage, error) {
+	return verifyPriceUpdate(update, v)
+}
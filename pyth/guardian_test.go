@@ -0,0 +1,205 @@
+package pyth
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testGuardians generates n guardian keys and their guardian set
+func testGuardians(t *testing.T, index uint32, n int) ([]*ecdsa.PrivateKey, *GuardianSet) {
+	t.Helper()
+	keys := make([]*ecdsa.PrivateKey, n)
+	set := &GuardianSet{Index: index}
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatalf("Failed to generate guardian key: %v", err)
+		}
+		keys[i] = key
+		set.Keys = append(set.Keys, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	return keys, set
+}
+
+// testSignedVAA encodes a VAA around payload signed by the guardians at the given indexes
+func testSignedVAA(t *testing.T, payload []byte, setIndex uint32, keys []*ecdsa.PrivateKey, signers ...int) []byte {
+	t.Helper()
+	body := testVAA(payload)[6:] // Skip version, guardian set index and the empty signature count
+	digest := crypto.Keccak256(crypto.Keccak256(body))
+
+	vaa := []byte{1, byte(setIndex >> 24), byte(setIndex >> 16), byte(setIndex >> 8), byte(setIndex), byte(len(signers))}
+	for _, index := range signers {
+		signature, err := crypto.Sign(digest, keys[index])
+		if err != nil {
+			t.Fatalf("Failed to sign VAA: %v", err)
+		}
+		vaa = append(vaa, byte(index))
+		vaa = append(vaa, signature...)
+	}
+	return append(vaa, body...)
+}
+
+func TestGuardianVerifierVerifiesQuorum(t *testing.T) {
+	keys, set := testGuardians(t, 3, 4)
+	verifier := NewGuardianVerifier(set)
+	if set.Quorum() != 3 {
+		t.Fatalf("Expected a quorum of 3 of 4 guardians, got %d", set.Quorum())
+	}
+
+	parse := func(signers ...int) *VAA {
+		vaa, err := ParseVAA(testSignedVAA(t, []byte("payload"), 3, keys, signers...))
+		if err != nil {
+			t.Fatalf("ParseVAA failed: %v", err)
+		}
+		return vaa
+	}
+
+	if err := verifier.VerifyVAA(parse(0, 1, 3)); err != nil {
+		t.Errorf("Expected a quorum of valid signatures to verify, got %v", err)
+	}
+	if err := verifier.VerifyVAA(parse(0, 1)); !errors.Is(err, ErrNoQuorum) {
+		t.Errorf("Expected 2 of 4 signatures to lack quorum, got %v", err)
+	}
+
+	// A signature claimed by another guardian
+	vaa := parse(0, 1, 2)
+	vaa.Signatures[2].GuardianIndex = 3
+	if err := verifier.VerifyVAA(vaa); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected a misattributed signature to be rejected, got %v", err)
+	}
+
+	// The same guardian counted twice
+	vaa = parse(0, 1, 2)
+	vaa.Signatures[1] = vaa.Signatures[0]
+	if err := verifier.VerifyVAA(vaa); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected a duplicate signature to be rejected, got %v", err)
+	}
+
+	// A body changed after signing
+	vaa = parse(0, 1, 2)
+	vaa.Body = append([]byte{}, vaa.Body...)
+	vaa.Body[len(vaa.Body)-1]++
+	if err := verifier.VerifyVAA(vaa); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected a tampered body to be rejected, got %v", err)
+	}
+
+	vaa = parse(0, 1, 2)
+	vaa.GuardianSetIndex = 4
+	if err := verifier.VerifyVAA(vaa); !errors.Is(err, ErrUnknownGuardianSet) {
+		t.Errorf("Expected an unknown guardian set to be rejected, got %v", err)
+	}
+}
+
+func TestGuardianVerifierRejectsOtherEmitters(t *testing.T) {
+	keys, set := testGuardians(t, 0, 3)
+	verifier := NewGuardianVerifier(set)
+
+	// A full quorum of the real guardians over a message from another emitter
+	vaa, err := ParseVAA(testSignedVAA(t, []byte("payload"), 0, keys, 0, 1, 2))
+	if err != nil {
+		t.Fatalf("ParseVAA failed: %v", err)
+	}
+	if err := verifier.VerifyVAA(vaa); err != nil {
+		t.Fatalf("Expected a Pythnet VAA to verify, got %v", err)
+	}
+
+	other := DataSource{EmitterChain: 2, EmitterAddress: PythnetDataSource.EmitterAddress}
+	body := append([]byte{}, vaa.Body...)
+	body[8], body[9] = byte(other.EmitterChain>>8), byte(other.EmitterChain) // After timestamp and nonce
+	data := []byte{1, 0, 0, 0, 0, 3}
+	digest := crypto.Keccak256(crypto.Keccak256(body))
+	for index := 0; index < 3; index++ {
+		signature, err := crypto.Sign(digest, keys[index])
+		if err != nil {
+			t.Fatalf("Failed to sign VAA: %v", err)
+		}
+		data = append(append(data, byte(index)), signature...)
+	}
+	forged, err := ParseVAA(append(data, body...))
+	if err != nil {
+		t.Fatalf("ParseVAA failed: %v", err)
+	}
+	if err := verifier.VerifyVAA(forged); !errors.Is(err, ErrUnknownDataSource) {
+		t.Errorf("Expected a VAA from another emitter to be rejected, got %v", err)
+	}
+
+	// The allowlist is configurable
+	verifier.SetDataSources(other)
+	if err := verifier.VerifyVAA(forged); err != nil {
+		t.Errorf("Expected an allowed emitter to verify, got %v", err)
+	}
+	if err := verifier.VerifyVAA(vaa); !errors.Is(err, ErrUnknownDataSource) {
+		t.Errorf("Expected Pythnet to be rejected once it is not allowed, got %v", err)
+	}
+}
+
+func TestGuardianVerifierExpiresReplacedSets(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	oldKeys, oldSet := testGuardians(t, 1, 1)
+	newKeys, newSet := testGuardians(t, 2, 1)
+	newSet.ExpirationTime = now.Add(-time.Hour) // Ignored for the current set
+
+	verifier := NewGuardianVerifier(oldSet, newSet)
+	verifier.now = func() time.Time { return now }
+
+	oldVAA, _ := ParseVAA(testSignedVAA(t, nil, 1, oldKeys, 0))
+	newVAA, _ := ParseVAA(testSignedVAA(t, nil, 2, newKeys, 0))
+
+	oldSet.ExpirationTime = now.Add(time.Hour)
+	if err := verifier.VerifyVAA(oldVAA); err != nil {
+		t.Errorf("Expected the replaced set to be accepted before its expiry, got %v", err)
+	}
+	oldSet.ExpirationTime = now.Add(-time.Second)
+	if err := verifier.VerifyVAA(oldVAA); !errors.Is(err, ErrGuardianSetExpired) {
+		t.Errorf("Expected the replaced set to expire, got %v", err)
+	}
+	if err := verifier.VerifyVAA(newVAA); err != nil {
+		t.Errorf("Expected the current set never to expire, got %v", err)
+	}
+}
+
+func TestHermesClientVerifiesGuardianSignatures(t *testing.T) {
+	keys, set := testGuardians(t, 0, 3)
+	data, _ := testAccumulatorUpdateWithVAA(func(payload []byte) []byte {
+		return testSignedVAA(t, payload, 0, keys, 0, 1, 2)
+	})
+	response, _ := json.Marshal(PriceUpdate{
+		Binary: &BinaryData{Encoding: "hex", Data: []string{hex.EncodeToString(data)}},
+		Parsed: []PriceFeed{{
+			ID:       strings.Repeat("aa", 32),
+			Price:    Price{Price: "6500012345678", Conf: "1234", Expo: -8, PublishTime: 1700000000},
+			EmaPrice: Price{Price: "6500012345668", Conf: "1235", Expo: -8, PublishTime: 1700000000},
+		}},
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	client := NewHermesClient(server.URL, &HermesClientConfig{Guardians: NewGuardianVerifier(set)})
+	update, err := client.GetLatestPriceUpdates(t.Context(), []HexString{HexString(strings.Repeat("aa", 32))}, nil)
+	if err != nil {
+		t.Fatalf("Expected a signed update to be accepted, got %v", err)
+	}
+	if len(update.Parsed) != 1 {
+		t.Errorf("Expected the parsed feed, got %+v", update.Parsed)
+	}
+
+	// The same update checked against other guardians
+	otherSet := &GuardianSet{Keys: []common.Address{{1}, {2}, {3}}}
+	client = NewHermesClient(server.URL, &HermesClientConfig{Guardians: NewGuardianVerifier(otherSet)})
+	if _, err := client.GetLatestPriceUpdates(t.Context(), nil, nil); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Expected an update signed by other guardians to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package pyth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+const (
+	priceUpdateBuffer = 64 // Updates buffered before the stream waits for the consumer
+	streamErrorBuffer = 16 // Errors buffered before new ones are dropped
+)
+
+// DecodeError is a stream event whose data is not a valid price update
+type DecodeError struct {
+	Data string
+	Err  error
+}
+
+// Error implements error
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to decode price update: %v", e.Err)
+}
+
+// Unwrap returns the JSON error
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// PriceUpdateStream delivers the decoded price updates of a Hermes stream. Updates and Errors are
+// closed once the stream is closed for good; the underlying connection reconnects on its own.
+type PriceUpdateStream struct {
+	source    *eventSource
+	guardians *GuardianVerifier
+	updates   chan PriceUpdate
+	errors    chan error
+	done      chan struct{}
+
+	mu     sync.RWMutex // Held for reading while sending, so the channels are not closed mid-send
+	closed bool
+	once   sync.Once
+}
+
+// StreamPriceUpdates streams price updates for a set of price feed IDs, decoded as PriceUpdate.
+// Set options.Parsed to receive PriceUpdate.Parsed; decode and connection errors arrive on Errors.
+// With guardians configured, updates that fail verification are dropped and reported on Errors.
+func (c *HermesClient) StreamPriceUpdates(ctx context.Context, ids []HexString, options *GetPriceUpdatesStreamOptions) (*PriceUpdateStream, error) {
+	stream := &PriceUpdateStream{
+		source:    c.newPriceUpdatesEventSource(ctx, ids, options),
+		guardians: c.guardians,
+		updates:   make(chan PriceUpdate, priceUpdateBuffer),
+		errors:    make(chan error, streamErrorBuffer),
+		done:      make(chan struct{}),
+	}
+
+	// Handlers are set before connecting so no event is missed
+	stream.source.OnMessage(stream.handleMessage)
+	stream.source.OnError(stream.sendError)
+	stream.source.OnStateChange(func(state ConnectionState) {
+		if state == StateClosed {
+			stream.finish()
+		}
+	})
+
+	if err := stream.source.Start(); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// Updates returns the decoded price updates. A consumer that falls behind slows down the stream
+// rather than losing updates.
+func (s *PriceUpdateStream) Updates() <-chan PriceUpdate {
+	return s.updates
+}
+
+// Errors returns connection errors and *DecodeError for events that could not be decoded.
+// Errors are dropped while the channel is full.
+func (s *PriceUpdateStream) Errors() <-chan error {
+	return s.errors
+}
+
+// EventSource returns the underlying connection, e.g. for its state or last event ID
+func (s *PriceUpdateStream) EventSource() EventSource {
+	return s.source
+}
+
+// Close closes the stream and its channels
+func (s *PriceUpdateStream) Close() error {
+	err := s.source.Close()
+	s.finish()
+	return err
+}
+
+// handleMessage decodes an event and sends it to Updates
+func (s *PriceUpdateStream) handleMessage(data string) {
+	var update PriceUpdate
+	if err := json.Unmarshal([]byte(data), &update); err != nil {
+		s.sendError(&DecodeError{Data: data, Err: err})
+		return
+	}
+	if s.guardians != nil {
+		if _, err := s.guardians.VerifyPriceUpdate(&update); err != nil {
+			s.sendError(fmt.Errorf("price update failed verification: %w", err))
+			return
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.updates <- update:
+	case <-s.done:
+	}
+}
+
+// sendError sends an error to Errors unless the channel is full
+func (s *PriceUpdateStream) sendError(err error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.errors <- err:
+	default:
+	}
+}
+
+// finish closes the channels once; done is closed first to release senders waiting on a full channel
+func (s *PriceUpdateStream) finish() {
+	s.once.Do(func() {
+		close(s.done)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.closed = true
+		close(s.updates)
+		close(s.errors)
+	})
+}
@@ -0,0 +1,86 @@
+package pyth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// hermesStreamEvent is a stream event as Hermes sends it, with snake_case fields
+const hermesStreamEvent = `{"binary":{"encoding":"hex","data":["504e4155"]},"parsed":[{"id":"e62d","price":{"price":"6500012345678","conf":"123","expo":-8,"publish_time":1700000000},"ema_price":{"price":"6490000000000","conf":"150","expo":-8,"publish_time":1700000000},"metadata":{"slot":42,"proof_available_time":1700000001,"prev_publish_time":1699999999}}]}`
+
+func TestStreamPriceUpdates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query()["ids[]"][0] != "e62d" {
+			t.Errorf("Expected the stream to request e62d, got %v", r.URL.Query())
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "data: %s\n\ndata: {not json\n\n", hermesStreamEvent)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewHermesClient(server.URL, nil)
+	parsed := true
+	stream, err := client.StreamPriceUpdates(context.Background(), []HexString{"e62d"}, &GetPriceUpdatesStreamOptions{Parsed: &parsed})
+	if err != nil {
+		t.Fatalf("StreamPriceUpdates failed: %v", err)
+	}
+
+	select {
+	case update := <-stream.Updates():
+		if len(update.Parsed) != 1 || update.Binary == nil || update.Binary.Data[0] != "504e4155" {
+			t.Fatalf("Unexpected update %+v", update)
+		}
+		feed := update.Parsed[0]
+		if feed.ID != "e62d" || feed.Price.Price != "6500012345678" || feed.Price.Expo != -8 || feed.Price.PublishTime != 1700000000 {
+			t.Errorf("Unexpected price %+v", feed.Price)
+		}
+		if feed.EmaPrice.Price != "6490000000000" || feed.Ema.Price != "6490000000000" || feed.Ema.Conf != "150" {
+			t.Errorf("Expected ema_price to fill EmaPrice and Ema, got %+v and %+v", feed.EmaPrice, feed.Ema)
+		}
+		if feed.Metadata.Slot != 42 || feed.Metadata.ProofAvailableTime != 1700000001 || feed.Metadata.PrevPublishTime != 1699999999 {
+			t.Errorf("Unexpected metadata %+v", feed.Metadata)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for a price update")
+	}
+
+	select {
+	case err := <-stream.Errors():
+		var decodeErr *DecodeError
+		if !errors.As(err, &decodeErr) || decodeErr.Data != "{not json" {
+			t.Errorf("Expected a decode error for the invalid event, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the decode error")
+	}
+
+	stream.Close()
+	if _, ok := <-stream.Updates(); ok {
+		t.Error("Expected Updates to be closed")
+	}
+	if _, ok := <-stream.Errors(); ok {
+		t.Error("Expected Errors to be closed")
+	}
+	if stream.EventSource().ReadyState() != StateClosed {
+		t.Errorf("Expected the closed state, got %v", stream.EventSource().ReadyState())
+	}
+}
+
+func TestStreamPriceUpdatesFailsToConnect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewHermesClient(server.URL, nil)
+	if _, err := client.StreamPriceUpdates(context.Background(), []HexString{"e62d"}, nil); err == nil {
+		t.Error("Expected a rejected stream to fail")
+	}
+}
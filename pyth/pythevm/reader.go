@@ -0,0 +1,188 @@
+package pythevm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/morpheum-labs/pricefeeding/ipyth"
+	"github.com/morpheum-labs/pricefeeding/types"
+)
+
+// ReadPriceOptions selects the price read by ReadPrice
+type ReadPriceOptions struct {
+	NetworkID uint64
+	Contract  common.Address      // Pyth contract of the network
+	PriceID   string              // Price feed ID, hex with or without 0x
+	Symbol    string              // Optional, copied to the result
+	Caller    bind.ContractCaller // Any caller, e.g. an *ethclient.Client or a simulated backend client
+	// MaxAge, when positive, also reads the price with getPriceNoOlderThan and marks it Stale
+	// when the contract rejects it as older than MaxAge
+	MaxAge time.Duration
+}
+
+// ReadPrice reads the price and EMA price a Pyth contract holds for a feed with getPriceUnsafe and
+// getEmaPriceUnsafe. A feed that was never pushed to the contract fails with ErrPriceFeedNotFound.
+func ReadPrice(ctx context.Context, opts ReadPriceOptions) (*types.PythOnChainPrice, error) {
+	id, err := ParsePriceID(opts.PriceID)
+	if err != nil {
+		return nil, err
+	}
+	contract, err := ipyth.NewIPythCaller(opts.Contract, opts.Caller)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind Pyth contract at %s: %w", opts.Contract.Hex(), err)
+	}
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	price, err := contract.GetPriceUnsafe(callOpts, id)
+	if err != nil {
+		return nil, fmt.Errorf("getPriceUnsafe failed for %s: %w", opts.PriceID, wrapRevert(err))
+	}
+	ema, err := contract.GetEmaPriceUnsafe(callOpts, id)
+	if err != nil {
+		return nil, fmt.Errorf("getEmaPriceUnsafe failed for %s: %w", opts.PriceID, wrapRevert(err))
+	}
+
+	onChainPrice := newOnChainPrice(opts, price, ema)
+
+	if opts.MaxAge > 0 {
+		if _, err := contract.GetPriceNoOlderThan(callOpts, id, maxAgeSeconds(opts.MaxAge)); err != nil {
+			err = wrapRevert(err)
+			if !errors.Is(err, ErrStalePrice) {
+				return nil, fmt.Errorf("getPriceNoOlderThan failed for %s: %w", opts.PriceID, err)
+			}
+			onChainPrice.Stale = true
+		}
+	}
+
+	return onChainPrice, nil
+}
+
+// BatchCaller sends JSON-RPC batches, e.g. the *rpc.Client of an *ethclient.Client
+type BatchCaller interface {
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+// maxBatchCalls is the most eth_calls sent in one JSON-RPC batch, as public RPCs cap batch size
+const maxBatchCalls = 100
+
+// ReadPrices reads several feeds like ReadPrice, sending their eth_calls in JSON-RPC batches of
+// up to 100 calls instead of one request per call; Caller of each option is ignored. Prices and
+// errors are returned in the order of opts, one of them set per feed. The returned error is set
+// only when a batch could not be sent at all.
+func ReadPrices(ctx context.Context, client BatchCaller, opts []ReadPriceOptions) ([]*types.PythOnChainPrice, []error, error) {
+	pythABI, err := ipyth.IPythMetaData.GetAbi()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Every feed reads its price and EMA price, and with MaxAge whether the price is stale
+	type call struct {
+		feed   int
+		method string
+		result hexutil.Bytes
+	}
+	prices := make([]*types.PythOnChainPrice, len(opts))
+	errs := make([]error, len(opts))
+	var calls []*call
+	var batch []rpc.BatchElem
+	for i, opt := range opts {
+		id, err := ParsePriceID(opt.PriceID)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		methods := [][]interface{}{{"getPriceUnsafe", id}, {"getEmaPriceUnsafe", id}}
+		if opt.MaxAge > 0 {
+			methods = append(methods, []interface{}{"getPriceNoOlderThan", id, maxAgeSeconds(opt.MaxAge)})
+		}
+		for _, method := range methods {
+			data, err := pythABI.Pack(method[0].(string), method[1:]...)
+			if err != nil {
+				return nil, nil, err
+			}
+			c := &call{feed: i, method: method[0].(string)}
+			calls = append(calls, c)
+			batch = append(batch, rpc.BatchElem{
+				Method: "eth_call",
+				Args:   []interface{}{map[string]interface{}{"to": opt.Contract, "data": hexutil.Bytes(data)}, "latest"},
+				Result: &c.result,
+			})
+		}
+	}
+
+	for start := 0; start < len(batch); start += maxBatchCalls {
+		end := start + maxBatchCalls
+		if end > len(batch) {
+			end = len(batch)
+		}
+		if err := client.BatchCallContext(ctx, batch[start:end]); err != nil {
+			return nil, nil, fmt.Errorf("failed to send batch of %d calls: %w", end-start, err)
+		}
+	}
+
+	results := make([]map[string]ipyth.PythStructsPrice, len(opts))
+	for i, c := range calls {
+		if errs[c.feed] != nil {
+			continue
+		}
+		if err := batch[i].Error; err != nil {
+			err = wrapRevert(err)
+			if c.method == "getPriceNoOlderThan" && errors.Is(err, ErrStalePrice) {
+				continue
+			}
+			errs[c.feed] = fmt.Errorf("%s failed for %s: %w", c.method, opts[c.feed].PriceID, err)
+			continue
+		}
+		out, err := pythABI.Unpack(c.method, c.result)
+		if err != nil {
+			errs[c.feed] = fmt.Errorf("%s failed for %s: %w", c.method, opts[c.feed].PriceID, err)
+			continue
+		}
+		if results[c.feed] == nil {
+			results[c.feed] = make(map[string]ipyth.PythStructsPrice)
+		}
+		results[c.feed][c.method] = *abi.ConvertType(out[0], new(ipyth.PythStructsPrice)).(*ipyth.PythStructsPrice)
+	}
+
+	for i, opt := range opts {
+		if errs[i] != nil {
+			continue
+		}
+		prices[i] = newOnChainPrice(opt, results[i]["getPriceUnsafe"], results[i]["getEmaPriceUnsafe"])
+		_, fresh := results[i]["getPriceNoOlderThan"]
+		prices[i].Stale = opt.MaxAge > 0 && !fresh
+	}
+	return prices, errs, nil
+}
+
+// newOnChainPrice converts the price and EMA price a contract holds for a feed
+func newOnChainPrice(opts ReadPriceOptions, price, ema ipyth.PythStructsPrice) *types.PythOnChainPrice {
+	return &types.PythOnChainPrice{
+		ID:              strings.ToLower(strings.TrimPrefix(opts.PriceID, "0x")),
+		Symbol:          opts.Symbol,
+		ContractAddress: opts.Contract.Hex(),
+		Price:           big.NewInt(price.Price),
+		Confidence:      new(big.Int).SetUint64(price.Conf),
+		Exponent:        int(price.Expo),
+		PublishTime:     price.PublishTime.Int64(),
+		EMA:             big.NewInt(ema.Price),
+		EMAConfidence:   new(big.Int).SetUint64(ema.Conf),
+		Timestamp:       time.Now(),
+		NetworkID:       opts.NetworkID,
+	}
+}
+
+// maxAgeSeconds converts a maximum age to the seconds getPriceNoOlderThan takes
+func maxAgeSeconds(maxAge time.Duration) *big.Int {
+	return big.NewInt(int64(maxAge / time.Second))
+}
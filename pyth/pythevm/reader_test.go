@@ -0,0 +1,96 @@
+package pythevm
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/morpheum-labs/pricefeeding/ipyth"
+)
+
+// simBatcher sends the eth_calls of a batch one by one to a simulated client, which does not
+// expose its JSON-RPC client, and counts the batches
+type simBatcher struct {
+	client  ethereum.ContractCaller
+	batches int
+	calls   int
+}
+
+func (b *simBatcher) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	b.batches++
+	b.calls += len(batch)
+	for i := range batch {
+		args := batch[i].Args[0].(map[string]interface{})
+		to := args["to"].(common.Address)
+		result, err := b.client.CallContract(ctx, ethereum.CallMsg{To: &to, Data: args["data"].(hexutil.Bytes)}, nil)
+		if err != nil {
+			batch[i].Error = err
+			continue
+		}
+		*batch[i].Result.(*hexutil.Bytes) = result
+	}
+	return nil
+}
+
+func TestReadPricesBatchesCalls(t *testing.T) {
+	chain, mock, _ := newTestUpdater(t)
+	header, err := chain.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Failed to get latest header: %v", err)
+	}
+	now := int64(header.Time)
+
+	// BTC is fresh, ETH five minutes old and NEW was never pushed
+	btcID, ethID, newID := strings.Repeat("e6", 32), strings.Repeat("ff", 32), strings.Repeat("01", 32)
+	for id, publishTime := range map[string]int64{btcID: now, ethID: now - 300} {
+		priceID, _ := ParsePriceID(id)
+		price := ipyth.PythStructsPrice{Price: 6500000000000, Conf: 1000, Expo: -8, PublishTime: big.NewInt(publishTime)}
+		if err := mock.SetPrice(priceID, price, 6400000000000, 900); err != nil {
+			t.Fatalf("SetPrice failed: %v", err)
+		}
+	}
+
+	var opts []ReadPriceOptions
+	for _, id := range []string{btcID, "0x" + ethID, newID} {
+		opts = append(opts, ReadPriceOptions{NetworkID: 1, Contract: mock.Address, PriceID: id, Caller: chain.Client, MaxAge: time.Minute})
+	}
+	batcher := &simBatcher{client: chain.Client}
+
+	prices, errs, err := ReadPrices(context.Background(), batcher, opts)
+	if err != nil {
+		t.Fatalf("ReadPrices failed: %v", err)
+	}
+	if batcher.batches != 1 || batcher.calls != 9 {
+		t.Errorf("Expected 9 calls in one batch, got %d in %d", batcher.calls, batcher.batches)
+	}
+
+	// Each feed matches what ReadPrice reads one call at a time
+	for i, opt := range opts[:2] {
+		if errs[i] != nil {
+			t.Fatalf("Unexpected error for %s: %v", opt.PriceID, errs[i])
+		}
+		single, err := ReadPrice(context.Background(), opt)
+		if err != nil {
+			t.Fatalf("ReadPrice failed: %v", err)
+		}
+		got := prices[i]
+		if got.ID != single.ID || got.Price.Cmp(single.Price) != 0 || got.EMA.Cmp(single.EMA) != 0 ||
+			got.PublishTime != single.PublishTime || got.Stale != single.Stale {
+			t.Errorf("Batched %+v differs from %+v", got, single)
+		}
+	}
+	if prices[0].Stale || !prices[1].Stale {
+		t.Errorf("Expected only ETH to be stale, got %v and %v", prices[0].Stale, prices[1].Stale)
+	}
+	if prices[2] != nil || !errors.Is(errs[2], ErrPriceFeedNotFound) {
+		t.Errorf("Expected ErrPriceFeedNotFound for a feed never pushed, got %+v, %v", prices[2], errs[2])
+	}
+}
@@ -0,0 +1,209 @@
+// Package pythevm pushes Hermes price updates to the Pyth contract on EVM chains
+package pythevm
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/morpheum-labs/pricefeeding/ipyth"
+	"github.com/morpheum-labs/pricefeeding/pyth"
+)
+
+// Errors of the Pyth contract, recognized in reverts
+var (
+	ErrInsufficientFee   = errors.New("pyth: insufficient fee")
+	ErrNoFreshUpdate     = errors.New("pyth: no fresh update")
+	ErrPriceFeedNotFound = errors.New("pyth: price feed not found")
+	ErrStalePrice        = errors.New("pyth: stale price")
+)
+
+// pythErrors maps the names of the contract's custom errors to their Go errors
+var pythErrors = map[string]error{
+	"InsufficientFee":   ErrInsufficientFee,
+	"NoFreshUpdate":     ErrNoFreshUpdate,
+	"PriceFeedNotFound": ErrPriceFeedNotFound,
+	"StalePrice":        ErrStalePrice,
+}
+
+// Updater pushes price updates to a Pyth contract, paying the update fee the contract asks for
+type Updater struct {
+	Address common.Address
+
+	contract *ipyth.IPyth
+}
+
+// NewUpdater binds the Pyth contract at address
+func NewUpdater(address common.Address, backend bind.ContractBackend) (*Updater, error) {
+	contract, err := ipyth.NewIPyth(address, backend)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind Pyth contract at %s: %w", address.Hex(), err)
+	}
+	return &Updater{Address: address, contract: contract}, nil
+}
+
+// UpdateData decodes the binary data of a Hermes price update into the updateData argument of
+// updatePriceFeeds. Request the update with pyth.EncodingTypeHex (the default) or base64.
+func UpdateData(update *pyth.PriceUpdate) ([][]byte, error) {
+	return pyth.DecodeBinaryData(update.Binary)
+}
+
+// FreshnessArgs returns the priceIds and publishTimes arguments of updatePriceFeedsIfNecessary
+// for the parsed feeds of a Hermes price update, so the contract only updates feeds it has older
+// prices for. The update must be requested with parsed set.
+func FreshnessArgs(update *pyth.PriceUpdate) ([][32]byte, []uint64, error) {
+	if len(update.Parsed) == 0 {
+		return nil, nil, fmt.Errorf("price update has no parsed feeds")
+	}
+
+	ids := make([][32]byte, 0, len(update.Parsed))
+	publishTimes := make([]uint64, 0, len(update.Parsed))
+	for _, feed := range update.Parsed {
+		id, err := ParsePriceID(feed.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids = append(ids, id)
+		publishTimes = append(publishTimes, uint64(feed.Price.PublishTime))
+	}
+	return ids, publishTimes, nil
+}
+
+// ParsePriceID parses a hex price feed ID, with or without 0x
+func ParsePriceID(id string) ([32]byte, error) {
+	var priceID [32]byte
+	decoded, err := hex.DecodeString(strings.TrimPrefix(id, "0x"))
+	if err != nil || len(decoded) != len(priceID) {
+		return priceID, fmt.Errorf("invalid price feed ID %q", id)
+	}
+	copy(priceID[:], decoded)
+	return priceID, nil
+}
+
+// GetUpdateFee returns the fee the contract charges for updateData
+func (u *Updater) GetUpdateFee(ctx context.Context, updateData [][]byte) (*big.Int, error) {
+	fee, err := u.contract.GetUpdateFee(&bind.CallOpts{Context: ctx}, updateData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get update fee: %w", wrapRevert(err))
+	}
+	return fee, nil
+}
+
+// UpdatePriceFeeds sends updatePriceFeeds with the update fee as value. With opts.NoSend set the
+// signed transaction is built and returned without being sent.
+func (u *Updater) UpdatePriceFeeds(opts *bind.TransactOpts, updateData [][]byte) (*types.Transaction, error) {
+	payOpts, err := u.withFee(opts, updateData)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := u.contract.UpdatePriceFeeds(payOpts, updateData)
+	if err != nil {
+		return nil, fmt.Errorf("updatePriceFeeds failed: %w", wrapRevert(err))
+	}
+	return tx, nil
+}
+
+// UpdatePriceFeedsIfNecessary sends updatePriceFeedsIfNecessary with the update fee as value. It
+// fails with ErrNoFreshUpdate when the contract already has every price at least as recent as
+// publishTimes; see FreshnessArgs.
+func (u *Updater) UpdatePriceFeedsIfNecessary(opts *bind.TransactOpts, updateData [][]byte, priceIDs [][32]byte, publishTimes []uint64) (*types.Transaction, error) {
+	if len(priceIDs) != len(publishTimes) {
+		return nil, fmt.Errorf("got %d price IDs and %d publish times", len(priceIDs), len(publishTimes))
+	}
+	payOpts, err := u.withFee(opts, updateData)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := u.contract.UpdatePriceFeedsIfNecessary(payOpts, updateData, priceIDs, publishTimes)
+	if err != nil {
+		return nil, fmt.Errorf("updatePriceFeedsIfNecessary failed: %w", wrapRevert(err))
+	}
+	return tx, nil
+}
+
+// PushPriceUpdate pushes a Hermes price update with updatePriceFeedsIfNecessary for its parsed
+// feeds. It returns nil and ErrNoFreshUpdate when the contract is already up to date.
+func (u *Updater) PushPriceUpdate(opts *bind.TransactOpts, update *pyth.PriceUpdate) (*types.Transaction, error) {
+	updateData, err := UpdateData(update)
+	if err != nil {
+		return nil, err
+	}
+	priceIDs, publishTimes, err := FreshnessArgs(update)
+	if err != nil {
+		return nil, err
+	}
+	return u.UpdatePriceFeedsIfNecessary(opts, updateData, priceIDs, publishTimes)
+}
+
+// withFee returns a copy of opts paying the update fee of updateData
+func (u *Updater) withFee(opts *bind.TransactOpts, updateData [][]byte) (*bind.TransactOpts, error) {
+	fee, err := u.GetUpdateFee(opts.Context, updateData)
+	if err != nil {
+		return nil, err
+	}
+	payOpts := *opts
+	payOpts.Value = fee
+	return &payOpts, nil
+}
+
+// UpdatePriceFeedsCalldata ABI-encodes a updatePriceFeeds call, e.g. to relay it through another
+// contract or a multisig
+func UpdatePriceFeedsCalldata(updateData [][]byte) ([]byte, error) {
+	return packCall("updatePriceFeeds", updateData)
+}
+
+// UpdatePriceFeedsIfNecessaryCalldata ABI-encodes a updatePriceFeedsIfNecessary call
+func UpdatePriceFeedsIfNecessaryCalldata(updateData [][]byte, priceIDs [][32]byte, publishTimes []uint64) ([]byte, error) {
+	return packCall("updatePriceFeedsIfNecessary", updateData, priceIDs, publishTimes)
+}
+
+// packCall ABI-encodes a call to the Pyth contract
+func packCall(method string, args ...interface{}) ([]byte, error) {
+	parsed, err := ipyth.IPythMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	data, err := parsed.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", method, err)
+	}
+	return data, nil
+}
+
+// wrapRevert wraps a revert with one of the contract's custom errors in its Go error
+func wrapRevert(err error) error {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return err
+	}
+	encoded, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return err
+	}
+	data, decodeErr := hexutil.Decode(encoded)
+	if decodeErr != nil || len(data) < 4 {
+		return err
+	}
+
+	parsed, abiErr := ipyth.IPythMetaData.GetAbi()
+	if abiErr != nil {
+		return err
+	}
+	customError, lookupErr := parsed.ErrorByID([4]byte(data[:4]))
+	if lookupErr != nil {
+		return err
+	}
+	if sentinel, exists := pythErrors[customError.Name]; exists {
+		return fmt.Errorf("%w (%v)", sentinel, err)
+	}
+	return err
+}
@@ -0,0 +1,189 @@
+package pythevm
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/morpheum-labs/pricefeeding/ipyth"
+	"github.com/morpheum-labs/pricefeeding/pyth"
+	"github.com/morpheum-labs/pricefeeding/pyth/pythtest"
+)
+
+var testUpdateFee = big.NewInt(1000)
+
+func newTestUpdater(t *testing.T) (*pythtest.Chain, *pythtest.MockPyth, *Updater) {
+	t.Helper()
+	chain, err := pythtest.NewChain()
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	t.Cleanup(func() { chain.Close() })
+
+	mock, err := chain.DeployPyth(testUpdateFee)
+	if err != nil {
+		t.Fatalf("Failed to deploy mock Pyth: %v", err)
+	}
+	updater, err := NewUpdater(mock.Address, chain.Client)
+	if err != nil {
+		t.Fatalf("NewUpdater failed: %v", err)
+	}
+	return chain, mock, updater
+}
+
+// mineTx commits a block and checks that tx succeeded
+func mineTx(t *testing.T, chain *pythtest.Chain, tx *gethtypes.Transaction) {
+	t.Helper()
+	chain.Commit()
+	receipt, err := chain.Client.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		t.Fatalf("Failed to get receipt: %v", err)
+	}
+	if receipt.Status != gethtypes.ReceiptStatusSuccessful {
+		t.Fatalf("Transaction %s reverted", tx.Hash().Hex())
+	}
+}
+
+// testPriceUpdate returns a Hermes price update with two binary updates, carrying 2 and 1 price
+// update messages, and one parsed feed
+func testPriceUpdate(publishTime int64) *pyth.PriceUpdate {
+	return &pyth.PriceUpdate{
+		Binary: &pyth.BinaryData{Encoding: "hex", Data: []string{
+			hex.EncodeToString(pythtest.AccumulatorUpdateData(2)),
+			"0x" + hex.EncodeToString(pythtest.AccumulatorUpdateData(1)),
+		}},
+		Parsed: []pyth.PriceFeed{{
+			ID:    strings.Repeat("e6", 32),
+			Price: pyth.Price{Price: "6500000000000", Conf: "1000", Expo: -8, PublishTime: publishTime},
+		}},
+	}
+}
+
+func TestUpdaterUpdatePriceFeeds(t *testing.T) {
+	chain, mock, updater := newTestUpdater(t)
+
+	updateData, err := UpdateData(testPriceUpdate(100))
+	if err != nil {
+		t.Fatalf("UpdateData failed: %v", err)
+	}
+	if len(updateData) != 2 || !bytes.Equal(updateData[1], pythtest.AccumulatorUpdateData(1)) {
+		t.Fatalf("Unexpected update data %x", updateData)
+	}
+
+	fee, err := updater.GetUpdateFee(context.Background(), updateData)
+	// The contract charges per price update message, not per element
+	if err != nil || fee.Cmp(big.NewInt(3000)) != 0 {
+		t.Fatalf("GetUpdateFee = %v, %v; want 3000", fee, err)
+	}
+
+	tx, err := updater.UpdatePriceFeeds(chain.TransactOpts(), updateData)
+	if err != nil {
+		t.Fatalf("UpdatePriceFeeds failed: %v", err)
+	}
+	if tx.Value().Cmp(fee) != 0 {
+		t.Errorf("Expected the transaction to pay %v, got %v", fee, tx.Value())
+	}
+	mineTx(t, chain, tx)
+	if count, err := mock.UpdateCount(); err != nil || count != 1 {
+		t.Errorf("UpdateCount = %d, %v; want 1", count, err)
+	}
+
+	// Paying less than the fee reverts with InsufficientFee
+	opts := chain.TransactOpts()
+	opts.Value = big.NewInt(2999)
+	contract, err := ipyth.NewIPythTransactor(mock.Address, chain.Client)
+	if err != nil {
+		t.Fatalf("Failed to bind Pyth: %v", err)
+	}
+	if _, err := contract.UpdatePriceFeeds(opts, updateData); !errors.Is(wrapRevert(err), ErrInsufficientFee) {
+		t.Errorf("Expected ErrInsufficientFee, got %v", err)
+	}
+}
+
+func TestUpdaterPushPriceUpdateOnlyWhenFresher(t *testing.T) {
+	chain, mock, updater := newTestUpdater(t)
+
+	id, err := ParsePriceID("0x" + strings.Repeat("e6", 32))
+	if err != nil {
+		t.Fatalf("ParsePriceID failed: %v", err)
+	}
+	err = mock.SetPrice(id, ipyth.PythStructsPrice{Price: 6400000000000, Conf: 900, Expo: -8, PublishTime: big.NewInt(100)}, 6400000000000, 900)
+	if err != nil {
+		t.Fatalf("SetPrice failed: %v", err)
+	}
+
+	if _, err := updater.PushPriceUpdate(chain.TransactOpts(), testPriceUpdate(100)); !errors.Is(err, ErrNoFreshUpdate) {
+		t.Errorf("Expected ErrNoFreshUpdate for a price the contract already has, got %v", err)
+	}
+
+	tx, err := updater.PushPriceUpdate(chain.TransactOpts(), testPriceUpdate(101))
+	if err != nil {
+		t.Fatalf("PushPriceUpdate failed: %v", err)
+	}
+	mineTx(t, chain, tx)
+	if count, err := mock.UpdateCount(); err != nil || count != 1 {
+		t.Errorf("UpdateCount = %d, %v; want 1", count, err)
+	}
+}
+
+func TestUpdatePriceFeedsCalldata(t *testing.T) {
+	chain, _, updater := newTestUpdater(t)
+	update := testPriceUpdate(100)
+	updateData, _ := UpdateData(update)
+	priceIDs, publishTimes, err := FreshnessArgs(update)
+	if err != nil {
+		t.Fatalf("FreshnessArgs failed: %v", err)
+	}
+	if len(priceIDs) != 1 || publishTimes[0] != 100 {
+		t.Fatalf("Unexpected freshness arguments %x %v", priceIDs, publishTimes)
+	}
+
+	// NoSend builds the signed transaction without sending it
+	opts := chain.TransactOpts()
+	opts.NoSend = true
+	for name, build := range map[string]func() ([]byte, *gethtypes.Transaction, error){
+		"updatePriceFeeds": func() ([]byte, *gethtypes.Transaction, error) {
+			calldata, err := UpdatePriceFeedsCalldata(updateData)
+			if err != nil {
+				return nil, nil, err
+			}
+			tx, err := updater.UpdatePriceFeeds(opts, updateData)
+			return calldata, tx, err
+		},
+		"updatePriceFeedsIfNecessary": func() ([]byte, *gethtypes.Transaction, error) {
+			calldata, err := UpdatePriceFeedsIfNecessaryCalldata(updateData, priceIDs, publishTimes)
+			if err != nil {
+				return nil, nil, err
+			}
+			tx, err := updater.UpdatePriceFeedsIfNecessary(opts, updateData, priceIDs, publishTimes)
+			return calldata, tx, err
+		},
+	} {
+		calldata, tx, err := build()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !bytes.Equal(tx.Data(), calldata) {
+			t.Errorf("%s: transaction data differs from the calldata", name)
+		}
+		if tx.To() == nil || *tx.To() != updater.Address || tx.Value().Cmp(big.NewInt(3000)) != 0 {
+			t.Errorf("%s: unexpected transaction to %v with value %v", name, tx.To(), tx.Value())
+		}
+	}
+	if pending, err := chain.Client.PendingNonceAt(context.Background(), opts.From); err != nil || pending != 1 {
+		t.Errorf("Expected only the deployment to be sent, pending nonce %d, %v", pending, err)
+	}
+
+	if _, _, err := FreshnessArgs(&pyth.PriceUpdate{}); err == nil {
+		t.Error("Expected an update without parsed feeds to be rejected")
+	}
+	if _, err := ParsePriceID("0x1234"); err == nil {
+		t.Error("Expected a short price ID to be rejected")
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+pragma solidity ^0.8.0;
+
+// Mock Pyth contract for tests on a simulated chain. It implements IPyth's read and fee functions
+// and charges updates like the real contract: singleUpdateFee per price update message in each
+// accumulator ("PNAU") update. The Wormhole VAA and Merkle proofs are not verified and the
+// messages are not stored: prices are set with setPrice and every accepted update only
+// increments updateCount.
+//
+// Regenerate mock_pyth.go after changing this file:
+//   solc --optimize --abi --bin -o . MockPyth.sol
+//   abigen --abi MockPyth.abi --bin MockPyth.bin --pkg pythtest --type MockPythContract --out mock_pyth.go
+contract MockPyth {
+  struct Price {
+    int64 price;
+    uint64 conf;
+    int32 expo;
+    uint256 publishTime;
+  }
+
+  struct PriceFeed {
+    Price price;
+    Price emaPrice;
+  }
+
+  error InsufficientFee();
+  error InvalidArgument();
+  error InvalidUpdateData();
+  error NoFreshUpdate();
+  error PriceFeedNotFound();
+  error StalePrice();
+
+  bytes4 internal constant ACCUMULATOR_MAGIC = 0x504e4155; // "PNAU"
+  uint8 internal constant MAJOR_VERSION = 1;
+  uint8 internal constant UPDATE_TYPE_WORMHOLE_MERKLE = 0;
+
+  uint256 public constant getValidTimePeriod = 60;
+
+  uint256 public immutable singleUpdateFee;
+  uint256 public updateCount;
+
+  mapping(bytes32 => PriceFeed) internal feeds;
+
+  constructor(uint256 _singleUpdateFee) {
+    singleUpdateFee = _singleUpdateFee;
+  }
+
+  // setPrice stores the price and EMA price of a feed. The EMA price shares the exponent and
+  // publish time of the price, as in Pyth price feed messages.
+  function setPrice(bytes32 id, int64 price, uint64 conf, int32 expo, uint256 publishTime, int64 emaPrice, uint64 emaConf)
+    external
+  {
+    feeds[id] = PriceFeed(Price(price, conf, expo, publishTime), Price(emaPrice, emaConf, expo, publishTime));
+  }
+
+  function getPriceUnsafe(bytes32 id) public view returns (Price memory) {
+    return feed(id).price;
+  }
+
+  function getPriceNoOlderThan(bytes32 id, uint256 age) external view returns (Price memory) {
+    return fresh(feed(id).price, age);
+  }
+
+  function getEmaPriceUnsafe(bytes32 id) external view returns (Price memory) {
+    return feed(id).emaPrice;
+  }
+
+  function getEmaPriceNoOlderThan(bytes32 id, uint256 age) external view returns (Price memory) {
+    return fresh(feed(id).emaPrice, age);
+  }
+
+  function getUpdateFee(bytes[] calldata updateData) public view returns (uint256 feeAmount) {
+    uint256 numUpdates;
+    for (uint256 i = 0; i < updateData.length; i++) {
+      numUpdates += accumulatorNumUpdates(updateData[i]);
+    }
+    return numUpdates * singleUpdateFee;
+  }
+
+  function updatePriceFeeds(bytes[] calldata updateData) public payable {
+    if (msg.value < getUpdateFee(updateData)) {
+      revert InsufficientFee();
+    }
+    updateCount++;
+  }
+
+  // updatePriceFeedsIfNecessary updates if any feed is missing or older than its publish time
+  function updatePriceFeedsIfNecessary(bytes[] calldata updateData, bytes32[] calldata priceIds, uint64[] calldata publishTimes)
+    external
+    payable
+  {
+    if (priceIds.length != publishTimes.length) {
+      revert InvalidArgument();
+    }
+    for (uint256 i = 0; i < priceIds.length; i++) {
+      if (feeds[priceIds[i]].price.publishTime < publishTimes[i]) {
+        updatePriceFeeds(updateData);
+        return;
+      }
+    }
+    revert NoFreshUpdate();
+  }
+
+  function feed(bytes32 id) internal view returns (PriceFeed storage) {
+    PriceFeed storage stored = feeds[id];
+    if (stored.price.publishTime == 0) {
+      revert PriceFeedNotFound();
+    }
+    return stored;
+  }
+
+  function fresh(Price memory price, uint256 age) internal view returns (Price memory) {
+    if (price.publishTime + age < block.timestamp) {
+      revert StalePrice();
+    }
+    return price;
+  }
+
+  // accumulatorNumUpdates reads the number of price update messages from the header of an
+  // accumulator update: magic, major and minor version, trailing header, update type, the VAA
+  // behind a uint16 length, then the uint8 message count
+  function accumulatorNumUpdates(bytes calldata data) internal pure returns (uint256) {
+    if (data.length < 7 || bytes4(data[0:4]) != ACCUMULATOR_MAGIC || uint8(data[4]) != MAJOR_VERSION) {
+      revert InvalidUpdateData();
+    }
+    uint256 offset = 7 + uint8(data[6]);
+    if (data.length < offset + 3 || uint8(data[offset]) != UPDATE_TYPE_WORMHOLE_MERKLE) {
+      revert InvalidUpdateData();
+    }
+    uint256 vaaSize = uint16(bytes2(data[offset + 1:offset + 3]));
+    offset += 3 + vaaSize;
+    if (data.length <= offset) {
+      revert InvalidUpdateData();
+    }
+    return uint8(data[offset]);
+  }
+}
@@ -0,0 +1,25 @@
+// Package pythtest runs a mock Pyth contract on go-ethereum's simulated backend, so on-chain Pyth
+// reads and updates can be tested end to end against a real EVM instead of a live RPC
+package pythtest
+
+import (
+	"github.com/morpheum-labs/pricefeeding/internal/evmtest"
+)
+
+// SimulatedChainID is the chain ID of go-ethereum's simulated backend
+const SimulatedChainID = evmtest.SimulatedChainID
+
+// Chain is a simulated chain with a funded account that deploys and updates the mock contract.
+// Every transaction is mined immediately. Close it when done.
+type Chain struct {
+	*evmtest.Chain
+}
+
+// NewChain starts a simulated chain
+func NewChain() (*Chain, error) {
+	chain, err := evmtest.NewChain()
+	if err != nil {
+		return nil, err
+	}
+	return &Chain{Chain: chain}, nil
+}
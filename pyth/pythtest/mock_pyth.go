@@ -0,0 +1,522 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package pythtest
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// MockPythPrice is an auto generated low-level Go binding around an user-defined struct.
+type MockPythPrice struct {
+	Price       int64
+	Conf        uint64
+	Expo        int32
+	PublishTime *big.Int
+}
+
+// MockPythContractMetaData contains all meta data concerning the MockPythContract contract.
+var MockPythContractMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_singleUpdateFee\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"InsufficientFee\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidArgument\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidUpdateData\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"NoFreshUpdate\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"PriceFeedNotFound\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"StalePrice\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"id\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"age\",\"type\":\"uint256\"}],\"name\":\"getEmaPriceNoOlderThan\",\"outputs\":[{\"components\":[{\"internalType\":\"int64\",\"name\":\"price\",\"type\":\"int64\"},{\"internalType\":\"uint64\",\"name\":\"conf\",\"type\":\"uint64\"},{\"internalType\":\"int32\",\"name\":\"expo\",\"type\":\"int32\"},{\"internalType\":\"uint256\",\"name\":\"publishTime\",\"type\":\"uint256\"}],\"internalType\":\"structMockPyth.Price\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"id\",\"type\":\"bytes32\"}],\"name\":\"getEmaPriceUnsafe\",\"outputs\":[{\"components\":[{\"internalType\":\"int64\",\"name\":\"price\",\"type\":\"int64\"},{\"internalType\":\"uint64\",\"name\":\"conf\",\"type\":\"uint64\"},{\"internalType\":\"int32\",\"name\":\"expo\",\"type\":\"int32\"},{\"internalType\":\"uint256\",\"name\":\"publishTime\",\"type\":\"uint256\"}],\"internalType\":\"structMockPyth.Price\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"id\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"age\",\"type\":\"uint256\"}],\"name\":\"getPriceNoOlderThan\",\"outputs\":[{\"components\":[{\"internalType\":\"int64\",\"name\":\"price\",\"type\":\"int64\"},{\"internalType\":\"uint64\",\"name\":\"conf\",\"type\":\"uint64\"},{\"internalType\":\"int32\",\"name\":\"expo\",\"type\":\"int32\"},{\"internalType\":\"uint256\",\"name\":\"publishTime\",\"type\":\"uint256\"}],\"internalType\":\"structMockPyth.Price\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"id\",\"type\":\"bytes32\"}],\"name\":\"getPriceUnsafe\",\"outputs\":[{\"components\":[{\"internalType\":\"int64\",\"name\":\"price\",\"type\":\"int64\"},{\"internalType\":\"uint64\",\"name\":\"conf\",\"type\":\"uint64\"},{\"internalType\":\"int32\",\"name\":\"expo\",\"type\":\"int32\"},{\"internalType\":\"uint256\",\"name\":\"publishTime\",\"type\":\"uint256\"}],\"internalType\":\"structMockPyth.Price\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes[]\",\"name\":\"updateData\",\"type\":\"bytes[]\"}],\"name\":\"getUpdateFee\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"feeAmount\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getValidTimePeriod\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"id\",\"type\":\"bytes32\"},{\"internalType\":\"int64\",\"name\":\"price\",\"type\":\"int64\"},{\"internalType\":\"uint64\",\"name\":\"conf\",\"type\":\"uint64\"},{\"internalType\":\"int32\",\"name\":\"expo\",\"type\":\"int32\"},{\"internalType\":\"uint256\",\"name\":\"publishTime\",\"type\":\"uint256\"},{\"internalType\":\"int64\",\"name\":\"emaPrice\",\"type\":\"int64\"},{\"internalType\":\"uint64\",\"name\":\"emaConf\",\"type\":\"uint64\"}],\"name\":\"setPrice\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"singleUpdateFee\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"updateCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes[]\",\"name\":\"updateData\",\"type\":\"bytes[]\"}],\"name\":\"updatePriceFeeds\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes[]\",\"name\":\"updateData\",\"type\":\"bytes[]\"},{\"internalType\":\"bytes32[]\",\"name\":\"priceIds\",\"type\":\"bytes32[]\"},{\"internalType\":\"uint64[]\",\"name\":\"publishTimes\",\"type\":\"uint64[]\"}],\"name\":\"updatePriceFeedsIfNecessary\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"}]",
+	Bin: "0x60a0604052348015600e575f5ffd5b50604051610cde380380610cde833981016040819052602b916032565b6080526048565b5f602082840312156041575f5ffd5b5051919050565b608051610c776100675f395f818161026501526106430152610c775ff3fe60806040526004361061009a575f3560e01c806396834ad31161006257806396834ad3146102a6578063a4ae35e0146102c5578063b9256d28146102e4578063d47eed45146102f7578063e18910a314610316578063ef9e5e281461032a575f5ffd5b80630b1559981461009e57806369b4ecc9146101cc578063711a2e28146101f357806388e6043a146102545780639474f45b14610287575b5f5ffd5b3480156100a9575f5ffd5b506101ca6100b83660046108fc565b6040805160c081018252600797880b8183019081526001600160401b03978816606080840191909152600397880b608080850182905260a08501899052928452845192830185529590990b815292871660208481019190915283830194909452828801949094528284019182525f978852600180845297819020935180518554828601518385015163ffffffff908116600160801b90810263ffffffff60801b19938d16600160401b9081026fffffffffffffffffffffffffffffffff19968716978f1697909717969096178416178a55948c01519c89019c909c55945180516002890180549883015196830151909d16909402948a1690920295169190971617929092171617909455910151910155565b005b3480156101d7575f5ffd5b506101e05f5481565b6040519081526020015b60405180910390f35b3480156101fe575f5ffd5b5061021261020d366004610976565b61033d565b6040516101ea9190815160070b81526020808301516001600160401b03169082015260408083015160030b908201526060918201519181019190915260800190565b34801561025f575f5ffd5b506101e07f000000000000000000000000000000000000000000000000000000000000000081565b348015610292575f5ffd5b506102126102a1366004610996565b6103c1565b3480156102b1575f5ffd5b506102126102c0366004610996565b610439565b3480156102d0575f5ffd5b506102126102df366004610976565b6104af565b6101ca6102f23660046109f4565b610528565b348015610302575f5ffd5b506101e0610311366004610a90565b6105f1565b348015610321575f5ffd5b506101e0603c81565b6101ca610338366004610a90565b610670565b604080516080810182525f8082526020820181905291810182905260608101919091526103b861036c846106b0565b604080516080810182526002830154600781900b82526001600160401b03600160401b8204166020830152600160801b9004600390810b928201929092529101546060820152836106e2565b90505b92915050565b604080516080810182525f8082526020820181905291810182905260608101919091526103ed826106b0565b604080516080810182526002830154600781900b82526001600160401b03600160401b8204166020830152600160801b9004600390810b92820192909252910154606082015292915050565b604080516080810182525f808252602082018190529181018290526060810191909152610465826106b0565b604080516080810182528254600781900b82526001600160401b03600160401b8204166020830152600160801b900460030b91810191909152600190910154606082015292915050565b604080516080810182525f8082526020820181905291810182905260608101919091526103b86104de846106b0565b604080516080810182528254600781900b82526001600160401b03600160401b8204166020830152600160801b900460030b918101919091526001909101546060820152836106e2565b8281146105485760405163a9cb9e0d60e01b815260040160405180910390fd5b5f5b838110156105cf5782828281811061056457610564610ace565b90506020020160208101906105799190610ae2565b6001600160401b031660015f87878581811061059757610597610ace565b9050602002013581526020019081526020015f205f016001015410156105c7576105c18787610670565b506105e9565b60010161054a565b50604051636f162bfd60e11b815260040160405180910390fd5b505050505050565b5f80805b8381101561063d5761062985858381811061061257610612610ace565b90506020028101906106249190610afb565b61073c565b6106339083610b51565b91506001016105f5565b506106687f000000000000000000000000000000000000000000000000000000000000000082610b64565b949350505050565b61067a82826105f1565b3410156106995760405162976f7560e21b815260040160405180910390fd5b5f805490806106a783610b7b565b91905055505050565b5f81815260016020819052604082209081015482036103bb57604051630295d7cd60e31b815260040160405180910390fd5b604080516080810182525f808252602082018190529181018290526060810191909152428284606001516107169190610b51565b101561073557604051630cd5fa0760e11b815260040160405180910390fd5b5090919050565b5f6007821080610772575063504e415560e01b61075c60045f8587610b93565b61076591610bba565b6001600160e01b03191614155b806107a0575060018383600481811061078d5761078d610ace565b9050013560f81c60f81b60f81c60ff1614155b156107be5760405163734fff6760e11b815260040160405180910390fd5b5f838360068181106107d2576107d2610ace565b6107e492013560f81c90506007610bf2565b60ff1690506107f4816003610b51565b83108061082257505f84848381811061080f5761080f610ace565b9050013560f81c60f81b60f81c60ff1614155b156108405760405163734fff6760e11b815260040160405180910390fd5b5f848461084e846001610b51565b9061085a856003610b51565b9261086793929190610b93565b61087091610c0b565b60f01c9050610880816003610b51565b61088a9083610b51565b91508184116108ac5760405163734fff6760e11b815260040160405180910390fd5b8484838181106108be576108be610ace565b919091013560f81c9695505050505050565b8035600781900b81146108e1575f5ffd5b919050565b80356001600160401b03811681146108e1575f5ffd5b5f5f5f5f5f5f5f60e0888a031215610912575f5ffd5b87359650610922602089016108d0565b9550610930604089016108e6565b945060608801358060030b8114610945575f5ffd5b93506080880135925061095a60a089016108d0565b915061096860c089016108e6565b905092959891949750929550565b5f5f60408385031215610987575f5ffd5b50508035926020909101359150565b5f602082840312156109a6575f5ffd5b5035919050565b5f5f83601f8401126109bd575f5ffd5b5081356001600160401b038111156109d3575f5ffd5b6020830191508360208260051b85010111156109ed575f5ffd5b9250929050565b5f5f5f5f5f5f60608789031215610a09575f5ffd5b86356001600160401b03811115610a1e575f5ffd5b610a2a89828a016109ad565b90975095505060208701356001600160401b03811115610a48575f5ffd5b610a5489828a016109ad565b90955093505060408701356001600160401b03811115610a72575f5ffd5b610a7e89828a016109ad565b979a9699509497509295939492505050565b5f5f60208385031215610aa1575f5ffd5b82356001600160401b03811115610ab6575f5ffd5b610ac2858286016109ad565b90969095509350505050565b634e487b7160e01b5f52603260045260245ffd5b5f60208284031215610af2575f5ffd5b6103b8826108e6565b5f5f8335601e19843603018112610b10575f5ffd5b8301803591506001600160401b03821115610b29575f5ffd5b6020019150368190038213156109ed575f5ffd5b634e487b7160e01b5f52601160045260245ffd5b808201808211156103bb576103bb610b3d565b80820281158282048414176103bb576103bb610b3d565b5f60018201610b8c57610b8c610b3d565b5060010190565b5f5f85851115610ba1575f5ffd5b83861115610bad575f5ffd5b5050820193919092039150565b80356001600160e01b03198116906004841015610beb576001600160e01b0319600485900360031b81901b82161691505b5092915050565b60ff81811683821601908111156103bb576103bb610b3d565b80356001600160f01b03198116906002841015610beb576001600160f01b031960029490940360031b84901b169092169291505056fea26469706673582212206eefa25e57440fdb107d5d1d9c5cc1ca8653543d4eed69a6c660d6a28c35273b64736f6c634300081e0033",
+}
+
+// MockPythContractABI is the input ABI used to generate the binding from.
+// Deprecated: Use MockPythContractMetaData.ABI instead.
+var MockPythContractABI = MockPythContractMetaData.ABI
+
+// MockPythContractBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use MockPythContractMetaData.Bin instead.
+var MockPythContractBin = MockPythContractMetaData.Bin
+
+// DeployMockPythContract deploys a new Ethereum contract, binding an instance of MockPythContract to it.
+func DeployMockPythContract(auth *bind.TransactOpts, backend bind.ContractBackend, _singleUpdateFee *big.Int) (common.Address, *types.Transaction, *MockPythContract, error) {
+	parsed, err := MockPythContractMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(MockPythContractBin), backend, _singleUpdateFee)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &MockPythContract{MockPythContractCaller: MockPythContractCaller{contract: contract}, MockPythContractTransactor: MockPythContractTransactor{contract: contract}, MockPythContractFilterer: MockPythContractFilterer{contract: contract}}, nil
+}
+
+// MockPythContract is an auto generated Go binding around an Ethereum contract.
+type MockPythContract struct {
+	MockPythContractCaller     // Read-only binding to the contract
+	MockPythContractTransactor // Write-only binding to the contract
+	MockPythContractFilterer   // Log filterer for contract events
+}
+
+// MockPythContractCaller is an auto generated read-only Go binding around an Ethereum contract.
+type MockPythContractCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// MockPythContractTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type MockPythContractTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// MockPythContractFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type MockPythContractFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// MockPythContractSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type MockPythContractSession struct {
+	Contract     *MockPythContract // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// MockPythContractCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type MockPythContractCallerSession struct {
+	Contract *MockPythContractCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts           // Call options to use throughout this session
+}
+
+// MockPythContractTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type MockPythContractTransactorSession struct {
+	Contract     *MockPythContractTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts           // Transaction auth options to use throughout this session
+}
+
+// MockPythContractRaw is an auto generated low-level Go binding around an Ethereum contract.
+type MockPythContractRaw struct {
+	Contract *MockPythContract // Generic contract binding to access the raw methods on
+}
+
+// MockPythContractCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type MockPythContractCallerRaw struct {
+	Contract *MockPythContractCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// MockPythContractTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type MockPythContractTransactorRaw struct {
+	Contract *MockPythContractTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewMockPythContract creates a new instance of MockPythContract, bound to a specific deployed contract.
+func NewMockPythContract(address common.Address, backend bind.ContractBackend) (*MockPythContract, error) {
+	contract, err := bindMockPythContract(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &MockPythContract{MockPythContractCaller: MockPythContractCaller{contract: contract}, MockPythContractTransactor: MockPythContractTransactor{contract: contract}, MockPythContractFilterer: MockPythContractFilterer{contract: contract}}, nil
+}
+
+// NewMockPythContractCaller creates a new read-only instance of MockPythContract, bound to a specific deployed contract.
+func NewMockPythContractCaller(address common.Address, caller bind.ContractCaller) (*MockPythContractCaller, error) {
+	contract, err := bindMockPythContract(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MockPythContractCaller{contract: contract}, nil
+}
+
+// NewMockPythContractTransactor creates a new write-only instance of MockPythContract, bound to a specific deployed contract.
+func NewMockPythContractTransactor(address common.Address, transactor bind.ContractTransactor) (*MockPythContractTransactor, error) {
+	contract, err := bindMockPythContract(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MockPythContractTransactor{contract: contract}, nil
+}
+
+// NewMockPythContractFilterer creates a new log filterer instance of MockPythContract, bound to a specific deployed contract.
+func NewMockPythContractFilterer(address common.Address, filterer bind.ContractFilterer) (*MockPythContractFilterer, error) {
+	contract, err := bindMockPythContract(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &MockPythContractFilterer{contract: contract}, nil
+}
+
+// bindMockPythContract binds a generic wrapper to an already deployed contract.
+func bindMockPythContract(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := MockPythContractMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_MockPythContract *MockPythContractRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _MockPythContract.Contract.MockPythContractCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_MockPythContract *MockPythContractRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _MockPythContract.Contract.MockPythContractTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_MockPythContract *MockPythContractRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _MockPythContract.Contract.MockPythContractTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_MockPythContract *MockPythContractCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _MockPythContract.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_MockPythContract *MockPythContractTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _MockPythContract.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_MockPythContract *MockPythContractTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _MockPythContract.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetEmaPriceNoOlderThan is a free data retrieval call binding the contract method 0x711a2e28.
+//
+// Solidity: function getEmaPriceNoOlderThan(bytes32 id, uint256 age) view returns((int64,uint64,int32,uint256))
+func (_MockPythContract *MockPythContractCaller) GetEmaPriceNoOlderThan(opts *bind.CallOpts, id [32]byte, age *big.Int) (MockPythPrice, error) {
+	var out []interface{}
+	err := _MockPythContract.contract.Call(opts, &out, "getEmaPriceNoOlderThan", id, age)
+
+	if err != nil {
+		return *new(MockPythPrice), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(MockPythPrice)).(*MockPythPrice)
+
+	return out0, err
+
+}
+
+// GetEmaPriceNoOlderThan is a free data retrieval call binding the contract method 0x711a2e28.
+//
+// Solidity: function getEmaPriceNoOlderThan(bytes32 id, uint256 age) view returns((int64,uint64,int32,uint256))
+func (_MockPythContract *MockPythContractSession) GetEmaPriceNoOlderThan(id [32]byte, age *big.Int) (MockPythPrice, error) {
+	return _MockPythContract.Contract.GetEmaPriceNoOlderThan(&_MockPythContract.CallOpts, id, age)
+}
+
+// GetEmaPriceNoOlderThan is a free data retrieval call binding the contract method 0x711a2e28.
+//
+// Solidity: function getEmaPriceNoOlderThan(bytes32 id, uint256 age) view returns((int64,uint64,int32,uint256))
+func (_MockPythContract *MockPythContractCallerSession) GetEmaPriceNoOlderThan(id [32]byte, age *big.Int) (MockPythPrice, error) {
+	return _MockPythContract.Contract.GetEmaPriceNoOlderThan(&_MockPythContract.CallOpts, id, age)
+}
+
+// GetEmaPriceUnsafe is a free data retrieval call binding the contract method 0x9474f45b.
+//
+// Solidity: function getEmaPriceUnsafe(bytes32 id) view returns((int64,uint64,int32,uint256))
+func (_MockPythContract *MockPythContractCaller) GetEmaPriceUnsafe(opts *bind.CallOpts, id [32]byte) (MockPythPrice, error) {
+	var out []interface{}
+	err := _MockPythContract.contract.Call(opts, &out, "getEmaPriceUnsafe", id)
+
+	if err != nil {
+		return *new(MockPythPrice), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(MockPythPrice)).(*MockPythPrice)
+
+	return out0, err
+
+}
+
+// GetEmaPriceUnsafe is a free data retrieval call binding the contract method 0x9474f45b.
+//
+// Solidity: function getEmaPriceUnsafe(bytes32 id) view returns((int64,uint64,int32,uint256))
+func (_MockPythContract *MockPythContractSession) GetEmaPriceUnsafe(id [32]byte) (MockPythPrice, error) {
+	return _MockPythContract.Contract.GetEmaPriceUnsafe(&_MockPythContract.CallOpts, id)
+}
+
+// GetEmaPriceUnsafe is a free data retrieval call binding the contract method 0x9474f45b.
+//
+// Solidity: function getEmaPriceUnsafe(bytes32 id) view returns((int64,uint64,int32,uint256))
+func (_MockPythContract *MockPythContractCallerSession) GetEmaPriceUnsafe(id [32]byte) (MockPythPrice, error) {
+	return _MockPythContract.Contract.GetEmaPriceUnsafe(&_MockPythContract.CallOpts, id)
+}
+
+// GetPriceNoOlderThan is a free data retrieval call binding the contract method 0xa4ae35e0.
+//
+// Solidity: function getPriceNoOlderThan(bytes32 id, uint256 age) view returns((int64,uint64,int32,uint256))
+func (_MockPythContract *MockPythContractCaller) GetPriceNoOlderThan(opts *bind.CallOpts, id [32]byte, age *big.Int) (MockPythPrice, error) {
+	var out []interface{}
+	err := _MockPythContract.contract.Call(opts, &out, "getPriceNoOlderThan", id, age)
+
+	if err != nil {
+		return *new(MockPythPrice), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(MockPythPrice)).(*MockPythPrice)
+
+	return out0, err
+
+}
+
+// GetPriceNoOlderThan is a free data retrieval call binding the contract method 0xa4ae35e0.
+//
+// Solidity: function getPriceNoOlderThan(bytes32 id, uint256 age) view returns((int64,uint64,int32,uint256))
+func (_MockPythContract *MockPythContractSession) GetPriceNoOlderThan(id [32]byte, age *big.Int) (MockPythPrice, error) {
+	return _MockPythContract.Contract.GetPriceNoOlderThan(&_MockPythContract.CallOpts, id, age)
+}
+
+// GetPriceNoOlderThan is a free data retrieval call binding the contract method 0xa4ae35e0.
+//
+// Solidity: function getPriceNoOlderThan(bytes32 id, uint256 age) view returns((int64,uint64,int32,uint256))
+func (_MockPythContract *MockPythContractCallerSession) GetPriceNoOlderThan(id [32]byte, age *big.Int) (MockPythPrice, error) {
+	return _MockPythContract.Contract.GetPriceNoOlderThan(&_MockPythContract.CallOpts, id, age)
+}
+
+// GetPriceUnsafe is a free data retrieval call binding the contract method 0x96834ad3.
+//
+// Solidity: function getPriceUnsafe(bytes32 id) view returns((int64,uint64,int32,uint256))
+func (_MockPythContract *MockPythContractCaller) GetPriceUnsafe(opts *bind.CallOpts, id [32]byte) (MockPythPrice, error) {
+	var out []interface{}
+	err := _MockPythContract.contract.Call(opts, &out, "getPriceUnsafe", id)
+
+	if err != nil {
+		return *new(MockPythPrice), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(MockPythPrice)).(*MockPythPrice)
+
+	return out0, err
+
+}
+
+// GetPriceUnsafe is a free data retrieval call binding the contract method 0x96834ad3.
+//
+// Solidity: function getPriceUnsafe(bytes32 id) view returns((int64,uint64,int32,uint256))
+func (_MockPythContract *MockPythContractSession) GetPriceUnsafe(id [32]byte) (MockPythPrice, error) {
+	return _MockPythContract.Contract.GetPriceUnsafe(&_MockPythContract.CallOpts, id)
+}
+
+// GetPriceUnsafe is a free data retrieval call binding the contract method 0x96834ad3.
+//
+// Solidity: function getPriceUnsafe(bytes32 id) view returns((int64,uint64,int32,uint256))
+func (_MockPythContract *MockPythContractCallerSession) GetPriceUnsafe(id [32]byte) (MockPythPrice, error) {
+	return _MockPythContract.Contract.GetPriceUnsafe(&_MockPythContract.CallOpts, id)
+}
+
+// GetUpdateFee is a free data retrieval call binding the contract method 0xd47eed45.
+//
+// Solidity: function getUpdateFee(bytes[] updateData) view returns(uint256 feeAmount)
+func (_MockPythContract *MockPythContractCaller) GetUpdateFee(opts *bind.CallOpts, updateData [][]byte) (*big.Int, error) {
+	var out []interface{}
+	err := _MockPythContract.contract.Call(opts, &out, "getUpdateFee", updateData)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetUpdateFee is a free data retrieval call binding the contract method 0xd47eed45.
+//
+// Solidity: function getUpdateFee(bytes[] updateData) view returns(uint256 feeAmount)
+func (_MockPythContract *MockPythContractSession) GetUpdateFee(updateData [][]byte) (*big.Int, error) {
+	return _MockPythContract.Contract.GetUpdateFee(&_MockPythContract.CallOpts, updateData)
+}
+
+// GetUpdateFee is a free data retrieval call binding the contract method 0xd47eed45.
+//
+// Solidity: function getUpdateFee(bytes[] updateData) view returns(uint256 feeAmount)
+func (_MockPythContract *MockPythContractCallerSession) GetUpdateFee(updateData [][]byte) (*big.Int, error) {
+	return _MockPythContract.Contract.GetUpdateFee(&_MockPythContract.CallOpts, updateData)
+}
+
+// GetValidTimePeriod is a free data retrieval call binding the contract method 0xe18910a3.
+//
+// Solidity: function getValidTimePeriod() view returns(uint256)
+func (_MockPythContract *MockPythContractCaller) GetValidTimePeriod(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _MockPythContract.contract.Call(opts, &out, "getValidTimePeriod")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetValidTimePeriod is a free data retrieval call binding the contract method 0xe18910a3.
+//
+// Solidity: function getValidTimePeriod() view returns(uint256)
+func (_MockPythContract *MockPythContractSession) GetValidTimePeriod() (*big.Int, error) {
+	return _MockPythContract.Contract.GetValidTimePeriod(&_MockPythContract.CallOpts)
+}
+
+// GetValidTimePeriod is a free data retrieval call binding the contract method 0xe18910a3.
+//
+// Solidity: function getValidTimePeriod() view returns(uint256)
+func (_MockPythContract *MockPythContractCallerSession) GetValidTimePeriod() (*big.Int, error) {
+	return _MockPythContract.Contract.GetValidTimePeriod(&_MockPythContract.CallOpts)
+}
+
+// SingleUpdateFee is a free data retrieval call binding the contract method 0x88e6043a.
+//
+// Solidity: function singleUpdateFee() view returns(uint256)
+func (_MockPythContract *MockPythContractCaller) SingleUpdateFee(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _MockPythContract.contract.Call(opts, &out, "singleUpdateFee")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SingleUpdateFee is a free data retrieval call binding the contract method 0x88e6043a.
+//
+// Solidity: function singleUpdateFee() view returns(uint256)
+func (_MockPythContract *MockPythContractSession) SingleUpdateFee() (*big.Int, error) {
+	return _MockPythContract.Contract.SingleUpdateFee(&_MockPythContract.CallOpts)
+}
+
+// SingleUpdateFee is a free data retrieval call binding the contract method 0x88e6043a.
+//
+// Solidity: function singleUpdateFee() view returns(uint256)
+func (_MockPythContract *MockPythContractCallerSession) SingleUpdateFee() (*big.Int, error) {
+	return _MockPythContract.Contract.SingleUpdateFee(&_MockPythContract.CallOpts)
+}
+
+// UpdateCount is a free data retrieval call binding the contract method 0x69b4ecc9.
+//
+// Solidity: function updateCount() view returns(uint256)
+func (_MockPythContract *MockPythContractCaller) UpdateCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _MockPythContract.contract.Call(opts, &out, "updateCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// UpdateCount is a free data retrieval call binding the contract method 0x69b4ecc9.
+//
+// Solidity: function updateCount() view returns(uint256)
+func (_MockPythContract *MockPythContractSession) UpdateCount() (*big.Int, error) {
+	return _MockPythContract.Contract.UpdateCount(&_MockPythContract.CallOpts)
+}
+
+// UpdateCount is a free data retrieval call binding the contract method 0x69b4ecc9.
+//
+// Solidity: function updateCount() view returns(uint256)
+func (_MockPythContract *MockPythContractCallerSession) UpdateCount() (*big.Int, error) {
+	return _MockPythContract.Contract.UpdateCount(&_MockPythContract.CallOpts)
+}
+
+// SetPrice is a paid mutator transaction binding the contract method 0x0b155998.
+//
+// Solidity: function setPrice(bytes32 id, int64 price, uint64 conf, int32 expo, uint256 publishTime, int64 emaPrice, uint64 emaConf) returns()
+func (_MockPythContract *MockPythContractTransactor) SetPrice(opts *bind.TransactOpts, id [32]byte, price int64, conf uint64, expo int32, publishTime *big.Int, emaPrice int64, emaConf uint64) (*types.Transaction, error) {
+	return _MockPythContract.contract.Transact(opts, "setPrice", id, price, conf, expo, publishTime, emaPrice, emaConf)
+}
+
+// SetPrice is a paid mutator transaction binding the contract method 0x0b155998.
+//
+// Solidity: function setPrice(bytes32 id, int64 price, uint64 conf, int32 expo, uint256 publishTime, int64 emaPrice, uint64 emaConf) returns()
+func (_MockPythContract *MockPythContractSession) SetPrice(id [32]byte, price int64, conf uint64, expo int32, publishTime *big.Int, emaPrice int64, emaConf uint64) (*types.Transaction, error) {
+	return _MockPythContract.Contract.SetPrice(&_MockPythContract.TransactOpts, id, price, conf, expo, publishTime, emaPrice, emaConf)
+}
+
+// SetPrice is a paid mutator transaction binding the contract method 0x0b155998.
+//
+// Solidity: function setPrice(bytes32 id, int64 price, uint64 conf, int32 expo, uint256 publishTime, int64 emaPrice, uint64 emaConf) returns()
+func (_MockPythContract *MockPythContractTransactorSession) SetPrice(id [32]byte, price int64, conf uint64, expo int32, publishTime *big.Int, emaPrice int64, emaConf uint64) (*types.Transaction, error) {
+	return _MockPythContract.Contract.SetPrice(&_MockPythContract.TransactOpts, id, price, conf, expo, publishTime, emaPrice, emaConf)
+}
+
+// UpdatePriceFeeds is a paid mutator transaction binding the contract method 0xef9e5e28.
+//
+// Solidity: function updatePriceFeeds(bytes[] updateData) payable returns()
+func (_MockPythContract *MockPythContractTransactor) UpdatePriceFeeds(opts *bind.TransactOpts, updateData [][]byte) (*types.Transaction, error) {
+	return _MockPythContract.contract.Transact(opts, "updatePriceFeeds", updateData)
+}
+
+// UpdatePriceFeeds is a paid mutator transaction binding the contract method 0xef9e5e28.
+//
+// Solidity: function updatePriceFeeds(bytes[] updateData) payable returns()
+func (_MockPythContract *MockPythContractSession) UpdatePriceFeeds(updateData [][]byte) (*types.Transaction, error) {
+	return _MockPythContract.Contract.UpdatePriceFeeds(&_MockPythContract.TransactOpts, updateData)
+}
+
+// UpdatePriceFeeds is a paid mutator transaction binding the contract method 0xef9e5e28.
+//
+// Solidity: function updatePriceFeeds(bytes[] updateData) payable returns()
+func (_MockPythContract *MockPythContractTransactorSession) UpdatePriceFeeds(updateData [][]byte) (*types.Transaction, error) {
+	return _MockPythContract.Contract.UpdatePriceFeeds(&_MockPythContract.TransactOpts, updateData)
+}
+
+// UpdatePriceFeedsIfNecessary is a paid mutator transaction binding the contract method 0xb9256d28.
+//
+// Solidity: function updatePriceFeedsIfNecessary(bytes[] updateData, bytes32[] priceIds, uint64[] publishTimes) payable returns()
+func (_MockPythContract *MockPythContractTransactor) UpdatePriceFeedsIfNecessary(opts *bind.TransactOpts, updateData [][]byte, priceIds [][32]byte, publishTimes []uint64) (*types.Transaction, error) {
+	return _MockPythContract.contract.Transact(opts, "updatePriceFeedsIfNecessary", updateData, priceIds, publishTimes)
+}
+
+// UpdatePriceFeedsIfNecessary is a paid mutator transaction binding the contract method 0xb9256d28.
+//
+// Solidity: function updatePriceFeedsIfNecessary(bytes[] updateData, bytes32[] priceIds, uint64[] publishTimes) payable returns()
+func (_MockPythContract *MockPythContractSession) UpdatePriceFeedsIfNecessary(updateData [][]byte, priceIds [][32]byte, publishTimes []uint64) (*types.Transaction, error) {
+	return _MockPythContract.Contract.UpdatePriceFeedsIfNecessary(&_MockPythContract.TransactOpts, updateData, priceIds, publishTimes)
+}
+
+// UpdatePriceFeedsIfNecessary is a paid mutator transaction binding the contract method 0xb9256d28.
+//
+// Solidity: function updatePriceFeedsIfNecessary(bytes[] updateData, bytes32[] priceIds, uint64[] publishTimes) payable returns()
+func (_MockPythContract *MockPythContractTransactorSession) UpdatePriceFeedsIfNecessary(updateData [][]byte, priceIds [][32]byte, publishTimes []uint64) (*types.Transaction, error) {
+	return _MockPythContract.Contract.UpdatePriceFeedsIfNecessary(&_MockPythContract.TransactOpts, updateData, priceIds, publishTimes)
+}
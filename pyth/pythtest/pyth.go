@@ -0,0 +1,89 @@
+package pythtest
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/morpheum-labs/pricefeeding/ipyth"
+)
+
+// MockValidTimePeriod is the getValidTimePeriod() of the mock Pyth contract, in seconds
+const MockValidTimePeriod = 60
+
+// MockPyth is a mock Pyth contract deployed on a simulated Chain, built from MockPyth.sol. It
+// implements IPyth's fee and read functions, and charges updatePriceFeeds and
+// updatePriceFeedsIfNecessary like the real contract, per price update message. It does not
+// verify or store the messages: prices are stored with SetPrice and every accepted update only
+// increments UpdateCount.
+type MockPyth struct {
+	Address common.Address
+	// UpdateFee is the fee per price update message
+	UpdateFee *big.Int
+
+	chain    *Chain
+	contract *MockPythContract
+}
+
+// DeployPyth deploys a mock Pyth contract charging updateFee per price update message
+func (c *Chain) DeployPyth(updateFee *big.Int) (*MockPyth, error) {
+	address, tx, contract, err := DeployMockPythContract(c.TransactOpts(), c.Client, updateFee)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy mock Pyth: %v", err)
+	}
+	if _, err := c.Mine(tx); err != nil {
+		return nil, fmt.Errorf("failed to deploy mock Pyth: %v", err)
+	}
+
+	return &MockPyth{
+		Address:   address,
+		UpdateFee: new(big.Int).Set(updateFee),
+		chain:     c,
+		contract:  contract,
+	}, nil
+}
+
+// SetPrice stores the price and EMA price of a feed and commits a block. The EMA price shares the
+// exponent and publish time of price, as in Pyth price feed messages.
+func (m *MockPyth) SetPrice(id [32]byte, price ipyth.PythStructsPrice, emaPrice int64, emaConf uint64) error {
+	publishTime := price.PublishTime
+	if publishTime == nil {
+		publishTime = new(big.Int)
+	}
+	tx, err := m.contract.SetPrice(m.chain.TransactOpts(), id, price.Price, price.Conf, price.Expo, publishTime, emaPrice, emaConf)
+	if err != nil {
+		return fmt.Errorf("failed to send setPrice: %v", err)
+	}
+	if _, err := m.chain.Mine(tx); err != nil {
+		return fmt.Errorf("setPrice failed: %v", err)
+	}
+	return nil
+}
+
+// UpdateCount returns the number of updates the contract accepted
+func (m *MockPyth) UpdateCount() (uint64, error) {
+	count, err := m.contract.UpdateCount(&bind.CallOpts{})
+	if err != nil {
+		return 0, err
+	}
+	return count.Uint64(), nil
+}
+
+// AccumulatorUpdateData encodes an accumulator ("PNAU") update with numUpdates price update
+// messages, which the mock charges numUpdates times its fee. The VAA and messages are empty, so
+// the data is for fees only and does not pass pyth.ParseAccumulatorUpdate.
+func AccumulatorUpdateData(numUpdates uint8) []byte {
+	data := []byte("PNAU")
+	data = append(data, 1, 0, 0)                  // Major and minor version, no trailing header
+	data = append(data, 0)                        // Wormhole Merkle update type
+	data = binary.BigEndian.AppendUint16(data, 0) // VAA size
+	data = append(data, numUpdates)
+	for i := uint8(0); i < numUpdates; i++ {
+		data = binary.BigEndian.AppendUint16(data, 0) // Message size
+		data = append(data, 0)                        // Proof size
+	}
+	return data
+}
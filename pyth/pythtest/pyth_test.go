@@ -0,0 +1,111 @@
+package pythtest
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+
+	"github.com/morpheum-labs/pricefeeding/ipyth"
+)
+
+func TestMockPythPrices(t *testing.T) {
+	chain, err := NewChain()
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer chain.Close()
+
+	mock, err := chain.DeployPyth(big.NewInt(1))
+	if err != nil {
+		t.Fatalf("Failed to deploy mock Pyth: %v", err)
+	}
+	contract, err := ipyth.NewIPythCaller(mock.Address, chain.Client)
+	if err != nil {
+		t.Fatalf("Failed to bind Pyth: %v", err)
+	}
+	opts := &bind.CallOpts{}
+
+	id := [32]byte{0xe6}
+	if _, err := contract.GetPriceUnsafe(opts, id); err == nil {
+		t.Error("Expected getPriceUnsafe to revert for an unknown feed")
+	}
+
+	header, err := chain.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Failed to get latest header: %v", err)
+	}
+	publishTime := new(big.Int).SetUint64(header.Time - 100)
+	if err := mock.SetPrice(id, ipyth.PythStructsPrice{Price: -6500000000000, Conf: 1000, Expo: -8, PublishTime: publishTime}, 6400000000000, 900); err != nil {
+		t.Fatalf("SetPrice failed: %v", err)
+	}
+
+	price, err := contract.GetPriceUnsafe(opts, id)
+	if err != nil || price.Price != -6500000000000 || price.Conf != 1000 || price.Expo != -8 || price.PublishTime.Cmp(publishTime) != 0 {
+		t.Errorf("getPriceUnsafe = %+v, %v", price, err)
+	}
+	ema, err := contract.GetEmaPriceUnsafe(opts, id)
+	if err != nil || ema.Price != 6400000000000 || ema.Conf != 900 || ema.Expo != -8 || ema.PublishTime.Cmp(publishTime) != 0 {
+		t.Errorf("getEmaPriceUnsafe = %+v, %v", ema, err)
+	}
+
+	if _, err := contract.GetPriceNoOlderThan(opts, id, big.NewInt(3600)); err != nil {
+		t.Errorf("Expected a price within its age to be returned, got %v", err)
+	}
+	if _, err := contract.GetEmaPriceNoOlderThan(opts, id, big.NewInt(10)); err == nil {
+		t.Error("Expected getEmaPriceNoOlderThan to revert for a stale price")
+	}
+
+	if period, err := contract.GetValidTimePeriod(opts); err != nil || period.Int64() != MockValidTimePeriod {
+		t.Errorf("getValidTimePeriod = %v, %v; want %d", period, err, MockValidTimePeriod)
+	}
+}
+
+func TestMockPythChargesPerUpdateMessage(t *testing.T) {
+	chain, err := NewChain()
+	if err != nil {
+		t.Fatalf("Failed to start simulated chain: %v", err)
+	}
+	defer chain.Close()
+
+	mock, err := chain.DeployPyth(big.NewInt(1000))
+	if err != nil {
+		t.Fatalf("Failed to deploy mock Pyth: %v", err)
+	}
+	contract, err := ipyth.NewIPyth(mock.Address, chain.Client)
+	if err != nil {
+		t.Fatalf("Failed to bind Pyth: %v", err)
+	}
+
+	// Two elements carrying 3 and 1 messages pay for 4 messages
+	updateData := [][]byte{AccumulatorUpdateData(3), AccumulatorUpdateData(1)}
+	fee, err := contract.GetUpdateFee(&bind.CallOpts{}, updateData)
+	if err != nil || fee.Int64() != 4000 {
+		t.Fatalf("getUpdateFee = %v, %v; want 4000", fee, err)
+	}
+
+	opts := chain.TransactOpts()
+	opts.Value = big.NewInt(3999)
+	if _, err := contract.UpdatePriceFeeds(opts, updateData); err == nil {
+		t.Error("Expected updatePriceFeeds to revert without the fee for every message")
+	}
+	opts.Value = fee
+	tx, err := contract.UpdatePriceFeeds(opts, updateData)
+	if err != nil {
+		t.Fatalf("updatePriceFeeds failed: %v", err)
+	}
+	if _, err := chain.Mine(tx); err != nil {
+		t.Fatalf("updatePriceFeeds failed: %v", err)
+	}
+	if count, err := mock.UpdateCount(); err != nil || count != 1 {
+		t.Errorf("UpdateCount = %d, %v; want 1", count, err)
+	}
+
+	// Data that is not an accumulator update is rejected, like the real contract does
+	for _, data := range [][]byte{[]byte("PNAU"), []byte("not an update")} {
+		if _, err := contract.GetUpdateFee(&bind.CallOpts{}, [][]byte{data}); err == nil {
+			t.Errorf("Expected getUpdateFee to revert for %q", data)
+		}
+	}
+}
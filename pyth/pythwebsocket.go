@@ -94,7 +94,7 @@ func (ws *WebSocketClient) Connect() error {
 		header.Set(key, value)
 	}
 
-	conn, _, err := dialer.Dial(ws.url, header)
+	conn, _, err := dialer.DialContext(ws.ctx, ws.url, header)
 	if err != nil {
 		return fmt.Errorf("failed to connect to WebSocket: %w", err)
 	}
@@ -318,8 +318,11 @@ func (ws *WebSocketClient) processMessage(msg map[string]interface{}) {
 
 // handlePriceUpdate processes price update messages
 func (ws *WebSocketClient) handlePriceUpdate(msg map[string]interface{}) {
-	// Extract priceFeed data (matches PythWebSocketMessage format)
-	priceFeedData, ok := msg["priceFeed"].(map[string]interface{})
+	// Extract priceFeed data: Hermes sends "price_feed", PythWebSocketMessage uses "priceFeed"
+	priceFeedData, ok := msg["price_feed"].(map[string]interface{})
+	if !ok {
+		priceFeedData, ok = msg["priceFeed"].(map[string]interface{})
+	}
 	if !ok {
 		// Try alternative format with "data" field (some WebSocket implementations use this)
 		if data, ok := msg["data"].(map[string]interface{}); ok {
@@ -389,7 +392,14 @@ func (ws *WebSocketClient) handleReconnect() {
 
 	// Exponential backoff
 	delay := ws.reconnectDelay * time.Duration(ws.reconnectCount)
-	time.Sleep(delay)
+	timer := time.NewTimer(delay)
+	select {
+	case <-ws.ctx.Done():
+		// Disconnect was called while waiting, stop reconnecting
+		timer.Stop()
+		return
+	case <-timer.C:
+	}
 
 	// Attempt to reconnect
 	if err := ws.Connect(); err != nil {
@@ -2,13 +2,60 @@ package pyth
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/morpheum-labs/pricefeeding/internal/ctxutil"
 )
 
+const (
+	// DefaultSSERetry is the reconnection delay until the server sets one with a retry field
+	DefaultSSERetry = 3 * time.Second
+	// DefaultSSEMaxBackoff caps the reconnection delay after consecutive failed attempts
+	DefaultSSEMaxBackoff = time.Minute
+
+	minSSEBackoff  = 100 * time.Millisecond // Base of the backoff when the server asks for immediate retries
+	maxSSELineSize = 16 << 20               // Binary price updates of many feeds come as one data line
+)
+
+// ConnectionState is the state of an EventSource, as the readyState of a browser EventSource
+type ConnectionState int
+
+const (
+	StateConnecting ConnectionState = iota // Connecting, or waiting to reconnect
+	StateOpen                              // Connected and receiving events
+	StateClosed                            // Closed for good: by Close, the context or a response that must not be retried
+)
+
+// String returns the name of the state
+func (s ConnectionState) String() string {
+	switch s {
+	case StateConnecting:
+		return "connecting"
+	case StateOpen:
+		return "open"
+	case StateClosed:
+		return "closed"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// Event is a dispatched Server-Sent Event
+type Event struct {
+	ID   string // Last event ID when the event was dispatched
+	Type string // "message" unless the server set an event type
+	Data string // Data lines joined with "\n"
+}
+
 // eventSource implements the EventSource interface for Server-Sent Events
 type eventSource struct {
 	url     string
@@ -18,47 +65,87 @@ type eventSource struct {
 	cancel  context.CancelFunc
 
 	messageHandler func(data string)
+	eventHandler   func(event Event)
 	errorHandler   func(err error)
-
-	mu     sync.RWMutex
-	closed bool
-	conn   *http.Response
-	reader *bufio.Reader
+	stateHandler   func(state ConnectionState)
+
+	mu          sync.RWMutex
+	closed      bool
+	state       ConnectionState
+	conn        *http.Response
+	lastEventID string        // Sent as Last-Event-ID when reconnecting
+	retry       time.Duration // Reconnection delay, set by the server's retry field
+	maxBackoff  time.Duration
 }
 
 // NewEventSource creates a new EventSource for Server-Sent Events
 func NewEventSource(url string, client *http.Client, headers map[string]string) EventSource {
-	ctx, cancel := context.WithCancel(context.Background())
+	return newEventSource(context.Background(), url, client, headers)
+}
+
+// newEventSource creates an EventSource that is closed when parent is done
+func newEventSource(parent context.Context, url string, client *http.Client, headers map[string]string) *eventSource {
+	ctx, cancel := context.WithCancel(parent)
 
 	return &eventSource{
-		url:     url,
-		client:  client,
-		headers: headers,
-		ctx:     ctx,
-		cancel:  cancel,
+		url:        url,
+		client:     client,
+		headers:    headers,
+		ctx:        ctx,
+		cancel:     cancel,
+		retry:      DefaultSSERetry,
+		maxBackoff: DefaultSSEMaxBackoff,
 	}
 }
 
-// OnMessage sets the message handler
+// OnMessage sets the handler for the data of events without an event type (or of type "message")
 func (es *eventSource) OnMessage(handler func(data string)) {
 	es.mu.Lock()
 	defer es.mu.Unlock()
 	es.messageHandler = handler
 }
 
-// OnError sets the error handler
+// OnEvent sets the handler for every event, whatever its type
+func (es *eventSource) OnEvent(handler func(event Event)) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	es.eventHandler = handler
+}
+
+// OnError sets the error handler. Errors of a stream that reconnects are not terminal; the
+// stream is only over once the state is StateClosed.
 func (es *eventSource) OnError(handler func(err error)) {
 	es.mu.Lock()
 	defer es.mu.Unlock()
 	es.errorHandler = handler
 }
 
-// Close closes the EventSource connection
-func (es *eventSource) Close() error {
+// OnStateChange sets the handler called when the connection opens, drops or closes
+func (es *eventSource) OnStateChange(handler func(state ConnectionState)) {
 	es.mu.Lock()
 	defer es.mu.Unlock()
+	es.stateHandler = handler
+}
 
+// ReadyState returns the current connection state
+func (es *eventSource) ReadyState() ConnectionState {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	return es.state
+}
+
+// LastEventID returns the ID of the last event received, sent as Last-Event-ID on reconnect
+func (es *eventSource) LastEventID() string {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	return es.lastEventID
+}
+
+// Close closes the EventSource connection
+func (es *eventSource) Close() error {
+	es.mu.Lock()
 	if es.closed {
+		es.mu.Unlock()
 		return nil
 	}
 
@@ -68,11 +155,14 @@ func (es *eventSource) Close() error {
 	if es.conn != nil {
 		es.conn.Body.Close()
 	}
+	es.mu.Unlock()
 
+	es.setState(StateClosed)
 	return nil
 }
 
-// Start begins the EventSource connection and starts reading events
+// Start opens the connection and reads events in the background, reconnecting whenever the
+// connection drops. An error is returned only if the first connection attempt fails.
 func (es *eventSource) Start() error {
 	es.mu.Lock()
 	if es.closed {
@@ -81,93 +171,259 @@ func (es *eventSource) Start() error {
 	}
 	es.mu.Unlock()
 
-	req, err := http.NewRequestWithContext(es.ctx, "GET", es.url, nil)
+	es.setState(StateConnecting)
+	resp, _, err := es.connect()
 	if err != nil {
-		es.handleError(fmt.Errorf("failed to create request: %w", err))
+		es.handleError(err)
+		es.fail()
 		return err
 	}
 
+	go es.run(resp)
+
+	return nil
+}
+
+// connect opens the stream, resuming from the last event ID. fatal is set when the response
+// must not be retried: 204 No Content, a client error or a body that is not an event stream.
+func (es *eventSource) connect() (resp *http.Response, fatal bool, err error) {
+	req, err := http.NewRequestWithContext(es.ctx, "GET", es.url, nil)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to create request: %w", err)
+	}
+
 	// Set headers
 	req.Header.Set("Accept", "text/event-stream")
 	req.Header.Set("Cache-Control", "no-cache")
 	for key, value := range es.headers {
 		req.Header.Set(key, value)
 	}
+	if lastEventID := es.LastEventID(); lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
 
-	resp, err := es.client.Do(req)
+	resp, err = es.client.Do(req)
 	if err != nil {
-		es.handleError(fmt.Errorf("failed to connect: %w", err))
-		return err
+		return nil, false, fmt.Errorf("failed to connect: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusNoContent {
+		resp.Body.Close()
+		return nil, true, fmt.Errorf("server closed the stream: %s", resp.Status)
+	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		resp.Body.Close()
-		err := fmt.Errorf("HTTP error: %d %s", resp.StatusCode, resp.Status)
-		es.handleError(err)
-		return err
+		retryable := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+		return nil, !retryable, fmt.Errorf("HTTP error: %d %s", resp.StatusCode, resp.Status)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/event-stream" {
+		resp.Body.Close()
+		return nil, true, fmt.Errorf("unexpected content type %q", resp.Header.Get("Content-Type"))
 	}
 
 	es.mu.Lock()
+	if es.closed {
+		es.mu.Unlock()
+		resp.Body.Close()
+		return nil, true, fmt.Errorf("event source is closed")
+	}
 	es.conn = resp
-	es.reader = bufio.NewReader(resp.Body)
 	es.mu.Unlock()
 
-	// Start reading events in a goroutine
-	go es.readEvents()
-
-	return nil
+	es.setState(StateOpen)
+	return resp, false, nil
 }
 
-// readEvents reads Server-Sent Events from the connection
-func (es *eventSource) readEvents() {
-	defer func() {
-		es.mu.Lock()
-		if es.conn != nil {
-			es.conn.Body.Close()
-		}
-		es.mu.Unlock()
-	}()
-
+// run reads events from resp and reconnects after the connection drops, backing off while
+// attempts keep failing, until the event source is closed
+func (es *eventSource) run(resp *http.Response) {
 	for {
-		select {
-		case <-es.ctx.Done():
+		err := es.readEvents(resp.Body)
+		resp.Body.Close()
+		if es.ctx.Err() != nil {
+			es.fail()
 			return
-		default:
 		}
+		if err == nil {
+			err = io.EOF
+		}
+		es.handleError(fmt.Errorf("stream interrupted, reconnecting: %w", err))
+		es.setState(StateConnecting)
+
+		var fatal bool
+		for attempt := 0; ; attempt++ {
+			if ctxutil.Sleep(es.ctx, es.reconnectDelay(attempt)) != nil {
+				es.fail()
+				return
+			}
+			resp, fatal, err = es.connect()
+			if err == nil {
+				break
+			}
+			es.handleError(fmt.Errorf("reconnection attempt %d failed: %w", attempt+1, err))
+			if fatal || es.ctx.Err() != nil {
+				es.fail()
+				return
+			}
+		}
+	}
+}
 
-		es.mu.RLock()
-		reader := es.reader
-		es.mu.RUnlock()
+// reconnectDelay returns the server-driven retry interval, doubled for every failed attempt
+// since the connection dropped, up to the maximum backoff
+func (es *eventSource) reconnectDelay(failedAttempts int) time.Duration {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
 
-		if reader == nil {
-			return
-		}
+	delay := es.retry
+	if failedAttempts > 0 && delay < minSSEBackoff {
+		delay = minSSEBackoff
+	}
+	for i := 0; i < failedAttempts && delay < es.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > es.maxBackoff {
+		delay = es.maxBackoff
+	}
+	return delay
+}
 
-		line, err := reader.ReadString('\n')
-		if err != nil {
-			es.handleError(fmt.Errorf("failed to read line: %w", err))
-			return
+// readEvents parses the event stream as specified by the WHATWG HTML standard: lines end in
+// CRLF, LF or CR, comments start with a colon, data lines accumulate until a blank line
+// dispatches the event, and an event cut off by the end of the stream is discarded.
+func (es *eventSource) readEvents(body io.Reader) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), maxSSELineSize)
+	scanner.Split(scanSSELines)
+
+	var data strings.Builder
+	var eventType string
+	first := true
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if first {
+			line = strings.TrimPrefix(line, "\ufeff") // Byte order mark
+			first = false
 		}
 
-		line = strings.TrimSpace(line)
 		if line == "" {
+			es.dispatch(&data, &eventType)
 			continue
 		}
+		if strings.HasPrefix(line, ":") {
+			continue // Comment, e.g. a keep-alive
+		}
 
-		// Parse Server-Sent Events format
-		if strings.HasPrefix(line, "data: ") {
-			data := strings.TrimPrefix(line, "data: ")
-			es.handleMessage(data)
-		} else if strings.HasPrefix(line, "event: ") {
-			// Handle event type if needed
-			continue
-		} else if strings.HasPrefix(line, "id: ") {
-			// Handle event ID if needed
-			continue
-		} else if strings.HasPrefix(line, "retry: ") {
-			// Handle retry interval if needed
-			continue
+		field, value := line, ""
+		if i := strings.IndexByte(line, ':'); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
 		}
+
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				es.mu.Lock()
+				es.lastEventID = value
+				es.mu.Unlock()
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 32); err == nil {
+				es.mu.Lock()
+				es.retry = time.Duration(ms) * time.Millisecond
+				es.mu.Unlock()
+			}
+		default:
+			// Unknown fields are ignored
+		}
+	}
+
+	return scanner.Err()
+}
+
+// dispatch delivers the buffered event and resets the buffers; events without data are dropped
+func (es *eventSource) dispatch(data *strings.Builder, eventType *string) {
+	defer func() {
+		data.Reset()
+		*eventType = ""
+	}()
+	if data.Len() == 0 {
+		return
+	}
+
+	event := Event{
+		ID:   es.LastEventID(),
+		Type: *eventType,
+		Data: strings.TrimSuffix(data.String(), "\n"),
+	}
+	if event.Type == "" {
+		event.Type = "message"
+	}
+
+	es.mu.RLock()
+	eventHandler := es.eventHandler
+	es.mu.RUnlock()
+	if eventHandler != nil {
+		eventHandler(event)
+	}
+	if event.Type == "message" {
+		es.handleMessage(event.Data)
+	}
+}
+
+// scanSSELines is a bufio.SplitFunc for lines ending in CRLF, LF or CR
+func scanSSELines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		if data[i] == '\n' {
+			return i + 1, data[:i], nil
+		}
+		// A CR at the end of the buffer may be the first half of a CRLF
+		if i+1 == len(data) && !atEOF {
+			return 0, nil, nil
+		}
+		if i+1 < len(data) && data[i+1] == '\n' {
+			return i + 2, data[:i], nil
+		}
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		// An unterminated last line is part of an incomplete event, which is discarded
+		return len(data), nil, nil
+	}
+	return 0, nil, nil
+}
+
+// fail marks the event source closed for good after the context ended or a fatal response
+func (es *eventSource) fail() {
+	es.mu.Lock()
+	es.closed = true
+	es.conn = nil
+	es.mu.Unlock()
+	es.cancel()
+	es.setState(StateClosed)
+}
+
+// setState records the connection state and notifies the state handler when it changes
+func (es *eventSource) setState(state ConnectionState) {
+	es.mu.Lock()
+	if es.state == state {
+		es.mu.Unlock()
+		return
+	}
+	es.state = state
+	handler := es.stateHandler
+	es.mu.Unlock()
+
+	if handler != nil {
+		handler(state)
 	}
 }
 
@@ -193,9 +449,22 @@ func (es *eventSource) handleError(err error) {
 	}
 }
 
-// GetPriceUpdatesStream fetches streaming price updates for a set of price feed IDs
+// GetPriceUpdatesStream fetches streaming price updates for a set of price feed IDs. The stream
+// reconnects after network errors, resuming from the last event ID.
 func (c *HermesClient) GetPriceUpdatesStream(ctx context.Context, ids []HexString, options *GetPriceUpdatesStreamOptions) (EventSource, error) {
-	u := c.buildURL("v2/updates/price/stream")
+	es := c.newPriceUpdatesEventSource(ctx, ids, options)
+
+	// Start the connection
+	if err := es.Start(); err != nil {
+		return nil, err
+	}
+
+	return es, nil
+}
+
+// newPriceUpdatesEventSource creates the event source of a price update stream without starting it
+func (c *HermesClient) newPriceUpdatesEventSource(ctx context.Context, ids []HexString, options *GetPriceUpdatesStreamOptions) *eventSource {
+	u := c.buildURL("updates/price/stream")
 
 	// Add price IDs as query parameters
 	query := u.Query()
@@ -229,12 +498,6 @@ func (c *HermesClient) GetPriceUpdatesStream(ctx context.Context, ids []HexStrin
 		Timeout: 0, // No timeout for streaming
 	}
 
-	es := NewEventSource(u.String(), streamClient, c.headers)
-
-	// Start the connection
-	if err := es.(*eventSource).Start(); err != nil {
-		return nil, err
-	}
-
-	return es, nil
+	// The stream ends when ctx is cancelled or Close is called
+	return newEventSource(ctx, u.String(), streamClient, c.headers)
 }
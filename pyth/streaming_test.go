@@ -0,0 +1,221 @@
+package pyth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// eventRecorder collects what an event source reports
+type eventRecorder struct {
+	mu       sync.Mutex
+	events   []Event
+	messages []string
+	states   []ConnectionState
+}
+
+func (r *eventRecorder) attach(es *eventSource) {
+	es.OnEvent(func(event Event) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.events = append(r.events, event)
+	})
+	es.OnMessage(func(data string) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.messages = append(r.messages, data)
+	})
+	es.OnStateChange(func(state ConnectionState) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.states = append(r.states, state)
+	})
+}
+
+// waitFor waits until cond holds for the recorded events
+func (r *eventRecorder) waitFor(t *testing.T, what string, cond func(r *eventRecorder) bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		r.mu.Lock()
+		done := cond(r)
+		r.mu.Unlock()
+		if done {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t.Fatalf("Timed out waiting for %s: events %+v, states %v", what, r.events, r.states)
+}
+
+func TestEventSourceParsesEventStream(t *testing.T) {
+	var requests int
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		first := requests == 1
+		mu.Unlock()
+		if !first {
+			w.WriteHeader(http.StatusNoContent) // Tells the client to stop reconnecting
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		fmt.Fprint(w, "\ufeff: keep-alive comment\n"+
+			"data: first\n"+
+			"data:second line\r\n"+
+			"id: 1\r\n\r\n"+
+			"event: heartbeat\rdata: ping\r\r"+
+			"data\n\n"+ // A field without a colon has an empty value
+			"id: 2\nretry: 25\nretry: soon\nunknown: field\n\n"+ // No data: nothing is dispatched
+			"data: incomplete")
+	}))
+	defer server.Close()
+
+	es := newEventSource(context.Background(), server.URL, server.Client(), nil)
+	recorder := &eventRecorder{}
+	recorder.attach(es)
+	if err := es.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer es.Close()
+
+	recorder.waitFor(t, "the stream to close", func(r *eventRecorder) bool {
+		return len(r.states) > 0 && r.states[len(r.states)-1] == StateClosed
+	})
+
+	want := []Event{
+		{ID: "1", Type: "message", Data: "first\nsecond line"},
+		{ID: "1", Type: "heartbeat", Data: "ping"},
+		{ID: "1", Type: "message", Data: ""},
+	}
+	if len(recorder.events) != len(want) {
+		t.Fatalf("Expected events %+v, got %+v", want, recorder.events)
+	}
+	for i := range want {
+		if recorder.events[i] != want[i] {
+			t.Errorf("Expected event %d to be %+v, got %+v", i, want[i], recorder.events[i])
+		}
+	}
+	if len(recorder.messages) != 2 || recorder.messages[0] != "first\nsecond line" {
+		t.Errorf("Expected only message events to reach OnMessage, got %q", recorder.messages)
+	}
+	if es.LastEventID() != "2" {
+		t.Errorf("Expected last event ID 2, got %q", es.LastEventID())
+	}
+	if delay := es.reconnectDelay(0); delay != 25*time.Millisecond {
+		t.Errorf("Expected the server-driven retry of 25ms, got %v", delay)
+	}
+}
+
+func TestEventSourceReconnectsWithLastEventID(t *testing.T) {
+	var mu sync.Mutex
+	var requests int
+	var lastEventIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		n := requests
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		mu.Unlock()
+
+		switch n {
+		case 1:
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "retry: 10\nid: 7\ndata: a\n\n") // Then the connection drops
+		case 2:
+			http.Error(w, "overloaded", http.StatusServiceUnavailable)
+		default:
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "id: 8\ndata: b\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	es := newEventSource(context.Background(), server.URL, server.Client(), nil)
+	recorder := &eventRecorder{}
+	recorder.attach(es)
+	var errs []error
+	es.OnError(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	})
+	if err := es.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	recorder.waitFor(t, "the event after reconnecting", func(r *eventRecorder) bool { return len(r.messages) == 2 })
+	es.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(lastEventIDs) != 3 || lastEventIDs[0] != "" || lastEventIDs[1] != "7" || lastEventIDs[2] != "7" {
+		t.Errorf("Expected reconnects to resume from event 7, got Last-Event-ID %q", lastEventIDs)
+	}
+	if len(errs) != 2 {
+		t.Errorf("Expected the drop and the failed attempt to be reported, got %v", errs)
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	wantStates := []ConnectionState{StateOpen, StateConnecting, StateOpen, StateClosed}
+	if fmt.Sprint(recorder.states) != fmt.Sprint(wantStates) {
+		t.Errorf("Expected states %v, got %v", wantStates, recorder.states)
+	}
+	if es.ReadyState() != StateClosed {
+		t.Errorf("Expected the closed state, got %v", es.ReadyState())
+	}
+}
+
+func TestEventSourceStopsOnFatalResponse(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"client error", func(w http.ResponseWriter, r *http.Request) { http.NotFound(w, r) }},
+		{"not an event stream", func(w http.ResponseWriter, r *http.Request) { fmt.Fprint(w, "<html></html>") }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+
+			es := newEventSource(context.Background(), server.URL, server.Client(), nil)
+			if err := es.Start(); err == nil {
+				t.Fatal("Expected Start to fail")
+			}
+			if es.ReadyState() != StateClosed {
+				t.Errorf("Expected the closed state, got %v", es.ReadyState())
+			}
+		})
+	}
+}
+
+func TestEventSourceReconnectBackoff(t *testing.T) {
+	es := newEventSource(context.Background(), "", nil, nil)
+	es.retry = time.Second
+	es.maxBackoff = 5 * time.Second
+
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		if delay := es.reconnectDelay(attempt); delay != want {
+			t.Errorf("Expected a delay of %v after %d failed attempts, got %v", want, attempt, delay)
+		}
+	}
+
+	// A server asking for immediate retries still gets backoff once attempts fail
+	es.retry = 0
+	if delay := es.reconnectDelay(0); delay != 0 {
+		t.Errorf("Expected an immediate first retry, got %v", delay)
+	}
+	if delay := es.reconnectDelay(2); delay != 4*minSSEBackoff {
+		t.Errorf("Expected a delay of %v after 2 failed attempts, got %v", 4*minSSEBackoff, delay)
+	}
+}
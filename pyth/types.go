@@ -2,6 +2,7 @@ package pyth
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -47,6 +48,44 @@ type PriceFeedMetadata struct {
 	Decimals      int       `json:"decimals"`
 	Status        string    `json:"status"`
 	LastUpdated   time.Time `json:"lastUpdated"`
+	// Attributes as Hermes sends them, e.g. display_symbol, asset_type, base and quote_currency
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// hermesAssetTypes maps the asset_type attributes Hermes sends to asset types
+var hermesAssetTypes = map[string]AssetType{
+	"crypto":                 AssetTypeCrypto,
+	"equity":                 AssetTypeEquity,
+	"fx":                     AssetTypeFX,
+	"metal":                  AssetTypeMetal,
+	"rates":                  AssetTypeRates,
+	"crypto redemption rate": AssetTypeCryptoRedemptionRate,
+}
+
+// UnmarshalJSON decodes feed metadata, also accepting the attributes object Hermes sends, which
+// fills Symbol, AssetType and Description when they are missing
+func (m *PriceFeedMetadata) UnmarshalJSON(data []byte) error {
+	type priceFeedMetadata PriceFeedMetadata
+	var decoded priceFeedMetadata
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*m = PriceFeedMetadata(decoded)
+	if m.Symbol == "" {
+		m.Symbol = m.Attributes["display_symbol"]
+	}
+	if m.AssetType == "" {
+		if assetType, exists := hermesAssetTypes[strings.ToLower(m.Attributes["asset_type"])]; exists {
+			m.AssetType = assetType
+		} else {
+			m.AssetType = AssetType(strings.ToLower(m.Attributes["asset_type"]))
+		}
+	}
+	if m.Description == "" {
+		m.Description = m.Attributes["description"]
+	}
+	return nil
 }
 
 // BinaryPriceUpdate represents a binary price update
@@ -115,12 +154,75 @@ type Metadata struct {
 	PrevPublishTime    int64 `json:"prev_publishTime"`
 }
 
+// UnmarshalJSON decodes a price feed, also accepting the snake_case ema_price Hermes sends, which
+// fills both EmaPrice and Ema
+func (f *PriceFeed) UnmarshalJSON(data []byte) error {
+	type priceFeed PriceFeed
+	var decoded struct {
+		priceFeed
+		SnakeEmaPrice *Price `json:"ema_price"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*f = PriceFeed(decoded.priceFeed)
+	if ema := decoded.SnakeEmaPrice; ema != nil {
+		f.EmaPrice = *ema
+		if f.Ema.Price == "" {
+			f.Ema = Ema{Price: ema.Price, Conf: ema.Conf, Expo: ema.Expo, PublishTime: ema.PublishTime}
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes a price, also accepting the snake_case publish_time Hermes sends
+func (p *Price) UnmarshalJSON(data []byte) error {
+	type price Price
+	var decoded struct {
+		price
+		SnakePublishTime *int64 `json:"publish_time"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*p = Price(decoded.price)
+	if decoded.SnakePublishTime != nil {
+		p.PublishTime = *decoded.SnakePublishTime
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes metadata, also accepting the snake_case field names Hermes sends
+func (m *Metadata) UnmarshalJSON(data []byte) error {
+	type metadata Metadata
+	var decoded struct {
+		metadata
+		SnakeProofAvailableTime *int64 `json:"proof_available_time"`
+		SnakePrevPublishTime    *int64 `json:"prev_publish_time"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*m = Metadata(decoded.metadata)
+	if decoded.SnakeProofAvailableTime != nil {
+		m.ProofAvailableTime = *decoded.SnakeProofAvailableTime
+	}
+	if decoded.SnakePrevPublishTime != nil {
+		m.PrevPublishTime = *decoded.SnakePrevPublishTime
+	}
+	return nil
+}
+
 // TwapsResponse represents TWAP (Time Weighted Average Price) response
 type TwapsResponse struct {
-	Type     string `json:"type"`
-	Encoding string `json:"encoding"`
-	Data     string `json:"data"`
-	Parsed   []Twap `json:"parsed,omitempty"`
+	Type     string      `json:"type"`
+	Encoding string      `json:"encoding"`
+	Data     string      `json:"data"`
+	Binary   *BinaryData `json:"binary,omitempty"`
+	Parsed   []Twap      `json:"parsed,omitempty"`
 }
 
 // ParsedTwapsUpdate represents parsed TWAP update data
@@ -128,9 +230,15 @@ type ParsedTwapsUpdate struct {
 	Twaps []Twap `json:"twaps"`
 }
 
-// Twap represents a single TWAP entry
+// Twap represents a single TWAP entry; Hermes sends the average as Twap over the window from
+// StartTimestamp to EndTimestamp
 type Twap struct {
-	ID          string `json:"id"`
+	ID             string `json:"id"`
+	StartTimestamp int64  `json:"start_timestamp"`
+	EndTimestamp   int64  `json:"end_timestamp"`
+	Twap           Price  `json:"twap"`
+	DownSlotsRatio string `json:"down_slots_ratio"` // Share of slots in the window without a price
+
 	Price       Price  `json:"price"`
 	Ema         Ema    `json:"ema"`
 	Conf        string `json:"conf"`
@@ -171,6 +279,9 @@ type HermesClientConfig struct {
 	HTTPRetries *int `json:"http_retries,omitempty"`
 	// Optional headers to be included in every request.
 	Headers map[string]string `json:"headers,omitempty"`
+	// Optional guardian sets. When set, price updates are only returned once their binary data is
+	// signed by a guardian quorum and matches the parsed prices.
+	Guardians *GuardianVerifier `json:"-"`
 }
 
 // GetPriceFeedsOptions represents options for getting price feeds
@@ -215,10 +326,15 @@ type GetLatestPublisherCapsOptions struct {
 	Parsed   *bool         `json:"parsed,omitempty"`
 }
 
-// EventSource represents a Server-Sent Events connection
+// EventSource represents a Server-Sent Events connection that reconnects, resuming from the
+// last event ID, until it is closed
 type EventSource interface {
 	OnMessage(handler func(data string))
+	OnEvent(handler func(event Event))
 	OnError(handler func(err error))
+	OnStateChange(handler func(state ConnectionState))
+	ReadyState() ConnectionState
+	LastEventID() string
 	Close() error
 }
 
@@ -0,0 +1,121 @@
+package pyth
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// GuardianSignature is one Wormhole guardian's signature of a VAA body
+type GuardianSignature struct {
+	GuardianIndex uint8
+	Signature     [65]byte // r, s, v
+}
+
+// VAA is a Wormhole Verified Action Approval: a message observed and signed by the guardian set
+type VAA struct {
+	Version          uint8
+	GuardianSetIndex uint32
+	Signatures       []GuardianSignature
+
+	// Body, the part the guardians sign
+	Timestamp        uint32
+	Nonce            uint32
+	EmitterChain     uint16
+	EmitterAddress   [32]byte
+	Sequence         uint64
+	ConsistencyLevel uint8
+	Payload          []byte
+
+	Body []byte // Raw body bytes, from Timestamp to the end of Payload
+}
+
+// SigningDigest returns the digest the guardians sign: the double keccak256 hash of the body
+func (v *VAA) SigningDigest() common.Hash {
+	return crypto.Keccak256Hash(crypto.Keccak256(v.Body))
+}
+
+// ParseVAA decodes a version 1 VAA. Signatures are not verified; see GuardianVerifier.
+func ParseVAA(data []byte) (*VAA, error) {
+	r := &byteReader{data: data}
+
+	vaa := &VAA{Version: r.uint8()}
+	if r.err == nil && vaa.Version != 1 {
+		return nil, fmt.Errorf("unsupported VAA version %d", vaa.Version)
+	}
+	vaa.GuardianSetIndex = r.uint32()
+
+	numSignatures := int(r.uint8())
+	for i := 0; i < numSignatures && r.err == nil; i++ {
+		var signature GuardianSignature
+		signature.GuardianIndex = r.uint8()
+		copy(signature.Signature[:], r.bytes(65))
+		vaa.Signatures = append(vaa.Signatures, signature)
+	}
+
+	bodyStart := r.pos
+	vaa.Timestamp = r.uint32()
+	vaa.Nonce = r.uint32()
+	vaa.EmitterChain = r.uint16()
+	copy(vaa.EmitterAddress[:], r.bytes(32))
+	vaa.Sequence = r.uint64()
+	vaa.ConsistencyLevel = r.uint8()
+	if r.err != nil {
+		return nil, fmt.Errorf("failed to parse VAA: %w", r.err)
+	}
+
+	vaa.Payload = data[r.pos:]
+	vaa.Body = data[bodyStart:]
+	return vaa, nil
+}
+
+// byteReader reads big-endian fields, remembering the first out-of-bounds read
+type byteReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+// bytes returns the next n bytes, or nil once the data is exhausted
+func (r *byteReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || len(r.data)-r.pos < n {
+		r.err = fmt.Errorf("unexpected end of data at offset %d reading %d bytes", r.pos, n)
+		return nil
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *byteReader) uint8() uint8 {
+	if b := r.bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *byteReader) uint16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *byteReader) uint32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *byteReader) uint64() uint64 {
+	if b := r.bytes(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
@@ -177,6 +177,11 @@ func getNetworkInfo(chainID string) NetworkInfo {
 			NameCoinr:    "ETH",
 			WrappedToken: "0x4200000000000000000000000000000000000006",
 		},
+		"8453": {
+			NameStd:      "Base Mainnet",
+			NameCoinr:    "ETH",
+			WrappedToken: "0x4200000000000000000000000000000000000006",
+		},
 		"250": {
 			NameStd:      "Fantom Mainnet",
 			NameCoinr:    "FTM",
@@ -251,6 +256,7 @@ func (c *ExtendedConfig) GetPriceFeedsForNetwork(networkID uint64) []PriceFeedIn
 					Address:  feed.Address,
 					Decimals: feed.Decimals,
 					Network:  networkName,
+					ChainID:  networkID,
 				})
 			}
 			break
@@ -262,11 +268,16 @@ func (c *ExtendedConfig) GetPriceFeedsForNetwork(networkID uint64) []PriceFeedIn
 
 // PriceFeedInfo represents information about a price feed
 type PriceFeedInfo struct {
-	Name     string
-	Address  string
-	Decimals int
-	Network  string
-	Symbol   string
+	Name      string
+	Address   string
+	Decimals  int
+	Network   string
+	Symbol    string
+	ChainID   uint64  // Network the feed is deployed on
+	Heartbeat int     // Maximum seconds between two on-chain rounds, 0 if unknown
+	Threshold float64 // Deviation threshold in percent, 0 if unknown
+	Base      string  // Feed Registry base asset, "" if the feed is not registry-resolved
+	Quote     string  // Feed Registry quote asset
 }
 
 // GetNetworkRPCs returns RPC endpoints for a specific network
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -20,6 +22,8 @@ type PriceFeedConfig struct {
 	Threshold          float64 `yaml:"threshold"`
 	Heartbeat          int     `yaml:"heartbeat"`
 	StalenessThreshold int     `yaml:"staleness_threshold"`
+	Base               string  `yaml:"base"`  // Feed Registry base asset (denomination name or token address)
+	Quote              string  `yaml:"quote"` // Feed Registry quote asset (denomination name or token address)
 }
 
 // PriceFeedFileConfig represents the structure of the YAML files
@@ -27,19 +31,33 @@ type PriceFeedFileConfig struct {
 	Feeds map[string]PriceFeedConfig `yaml:",inline"`
 }
 
+// NetworkFeedFileConfig represents the structure of a per-network feed file in conf/networks
+type NetworkFeedFileConfig struct {
+	ChainID      uint64                     `yaml:"chain_id"`
+	Name         string                     `yaml:"name"`
+	PythContract string                     `yaml:"pyth_contract"` // Pyth price feed contract of the network, optional
+	Feeds        map[string]PriceFeedConfig `yaml:"feeds"`
+}
+
 // PriceFeedManager manages price feed configurations from multiple YAML files
 type PriceFeedManager struct {
-	CryptoFeeds map[string]PriceFeedConfig
-	StockFeeds  map[string]PriceFeedConfig
-	NetworkID   uint64 // Default network ID (Arbitrum: 42161)
+	CryptoFeeds  map[string]PriceFeedConfig
+	StockFeeds   map[string]PriceFeedConfig
+	NetworkID    uint64                                // Default network ID of crytos.yaml and stocks.yaml (Arbitrum: 42161)
+	NetworkFeeds map[uint64]map[string]PriceFeedConfig // networkID -> feeds declared in conf/networks
+	NetworkNames map[uint64]string                     // networkID -> name declared in conf/networks
+	PythContract map[uint64]string                     // networkID -> Pyth contract address declared in conf/networks
 }
 
 // NewPriceFeedManager creates a new price feed manager
 func NewPriceFeedManager(networkID uint64) *PriceFeedManager {
 	return &PriceFeedManager{
-		CryptoFeeds: make(map[string]PriceFeedConfig),
-		StockFeeds:  make(map[string]PriceFeedConfig),
-		NetworkID:   networkID,
+		CryptoFeeds:  make(map[string]PriceFeedConfig),
+		StockFeeds:   make(map[string]PriceFeedConfig),
+		NetworkID:    networkID,
+		NetworkFeeds: make(map[uint64]map[string]PriceFeedConfig),
+		NetworkNames: make(map[uint64]string),
+		PythContract: make(map[uint64]string),
 	}
 }
 
@@ -57,6 +75,74 @@ func (pfm *PriceFeedManager) LoadConfig(configDir string) error {
 		return fmt.Errorf("failed to load stock feeds: %w", err)
 	}
 
+	// Load per-network feeds, if any
+	networksDir := filepath.Join(configDir, "networks")
+	if _, err := os.Stat(networksDir); err == nil {
+		if err := pfm.LoadNetworkConfigs(networksDir); err != nil {
+			return fmt.Errorf("failed to load network feeds: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// LoadNetworkConfigs loads every *.yaml file in a directory as a per-network feed file
+func (pfm *PriceFeedManager) LoadNetworkConfigs(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return fmt.Errorf("failed to list network config files in %s: %w", dir, err)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		if err := pfm.LoadNetworkConfig(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadNetworkConfig loads a per-network feed file. Feeds of a network declared in several
+// files are merged; a feed name declared twice for the same network is an error.
+func (pfm *PriceFeedManager) LoadNetworkConfig(filePath string) error {
+	fileContent, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+
+	var config NetworkFeedFileConfig
+	if err := yaml.Unmarshal(fileContent, &config); err != nil {
+		return fmt.Errorf("failed to parse YAML file %s: %w", filePath, err)
+	}
+	if config.ChainID == 0 {
+		return fmt.Errorf("network config file %s has no chain_id", filePath)
+	}
+
+	feeds, exists := pfm.NetworkFeeds[config.ChainID]
+	if !exists {
+		feeds = make(map[string]PriceFeedConfig)
+		pfm.NetworkFeeds[config.ChainID] = feeds
+	}
+	for name, feed := range config.Feeds {
+		if _, duplicate := feeds[name]; duplicate {
+			return fmt.Errorf("feed %s declared twice for network %d (%s)", name, config.ChainID, filePath)
+		}
+		feeds[name] = feed
+	}
+
+	if config.PythContract != "" {
+		if existing, exists := pfm.PythContract[config.ChainID]; exists && !strings.EqualFold(existing, config.PythContract) {
+			return fmt.Errorf("network %d has two Pyth contracts: %s and %s (%s)", config.ChainID, existing, config.PythContract, filePath)
+		}
+		pfm.PythContract[config.ChainID] = config.PythContract
+	}
+
+	if config.Name != "" {
+		pfm.NetworkNames[config.ChainID] = config.Name
+	} else if _, exists := pfm.NetworkNames[config.ChainID]; !exists {
+		pfm.NetworkNames[config.ChainID] = strconv.FormatUint(config.ChainID, 10)
+	}
+
 	return nil
 }
 
@@ -83,29 +169,8 @@ func (pfm *PriceFeedManager) loadConfigFile(filePath string, target *map[string]
 // GetAllFeeds returns all price feeds (crypto + stocks) as PriceFeedInfo slice
 func (pfm *PriceFeedManager) GetAllFeeds() []PriceFeedInfo {
 	var feeds []PriceFeedInfo
-
-	// Add crypto feeds
-	for name, config := range pfm.CryptoFeeds {
-		feeds = append(feeds, PriceFeedInfo{
-			Name:     name,
-			Address:  config.Proxy,
-			Decimals: config.Decimals,
-			Network:  "crypto",
-			Symbol:   config.Symbol,
-		})
-	}
-
-	// Add stock feeds
-	for name, config := range pfm.StockFeeds {
-		feeds = append(feeds, PriceFeedInfo{
-			Name:     name,
-			Address:  config.Proxy,
-			Decimals: config.Decimals,
-			Network:  "stocks",
-			Symbol:   config.Symbol,
-		})
-	}
-
+	feeds = append(feeds, pfm.GetCryptoFeeds()...)
+	feeds = append(feeds, pfm.GetStockFeeds()...)
 	return feeds
 }
 
@@ -113,13 +178,7 @@ func (pfm *PriceFeedManager) GetAllFeeds() []PriceFeedInfo {
 func (pfm *PriceFeedManager) GetCryptoFeeds() []PriceFeedInfo {
 	var feeds []PriceFeedInfo
 	for name, config := range pfm.CryptoFeeds {
-		feeds = append(feeds, PriceFeedInfo{
-			Name:     name,
-			Address:  config.Proxy,
-			Decimals: config.Decimals,
-			Network:  "crypto",
-			Symbol:   config.Symbol,
-		})
+		feeds = append(feeds, newPriceFeedInfo(name, "crypto", pfm.NetworkID, config))
 	}
 	return feeds
 }
@@ -128,23 +187,79 @@ func (pfm *PriceFeedManager) GetCryptoFeeds() []PriceFeedInfo {
 func (pfm *PriceFeedManager) GetStockFeeds() []PriceFeedInfo {
 	var feeds []PriceFeedInfo
 	for name, config := range pfm.StockFeeds {
-		feeds = append(feeds, PriceFeedInfo{
-			Name:     name,
-			Address:  config.Proxy,
-			Decimals: config.Decimals,
-			Network:  "stocks",
-			Symbol:   config.Symbol,
-		})
+		feeds = append(feeds, newPriceFeedInfo(name, "stocks", pfm.NetworkID, config))
 	}
 	return feeds
 }
 
-// GetFeedsForNetwork returns feeds for a specific network ID
+// GetFeedsForNetwork returns feeds for a specific network ID: the crypto and stock feeds for the
+// default network, plus the feeds declared for the network in conf/networks
 func (pfm *PriceFeedManager) GetFeedsForNetwork(networkID uint64) []PriceFeedInfo {
-	if networkID != pfm.NetworkID {
+	var feeds []PriceFeedInfo
+	if networkID == pfm.NetworkID {
+		feeds = append(feeds, pfm.GetAllFeeds()...)
+	}
+	for name, config := range pfm.NetworkFeeds[networkID] {
+		feeds = append(feeds, newPriceFeedInfo(name, pfm.NetworkNames[networkID], networkID, config))
+	}
+	if feeds == nil {
 		return []PriceFeedInfo{}
 	}
-	return pfm.GetAllFeeds()
+	return feeds
+}
+
+// GetNetworkIDs returns the IDs of all networks that have feeds configured, in ascending order
+func (pfm *PriceFeedManager) GetNetworkIDs() []uint64 {
+	var networkIDs []uint64
+	if len(pfm.CryptoFeeds) > 0 || len(pfm.StockFeeds) > 0 {
+		networkIDs = append(networkIDs, pfm.NetworkID)
+	}
+	for networkID, feeds := range pfm.NetworkFeeds {
+		if len(feeds) > 0 && networkID != pfm.NetworkID {
+			networkIDs = append(networkIDs, networkID)
+		}
+	}
+	sort.Slice(networkIDs, func(i, j int) bool { return networkIDs[i] < networkIDs[j] })
+	return networkIDs
+}
+
+// GetPythContracts returns the Pyth contract address of every network that declares one, by network ID
+func (pfm *PriceFeedManager) GetPythContracts() map[uint64]string {
+	contracts := make(map[uint64]string, len(pfm.PythContract))
+	for networkID, address := range pfm.PythContract {
+		contracts[networkID] = address
+	}
+	return contracts
+}
+
+// GetFeedsBySymbol returns the feeds of a symbol (e.g. "ETH/USD") on every configured network, by network ID
+func (pfm *PriceFeedManager) GetFeedsBySymbol(symbol string) map[uint64]PriceFeedInfo {
+	result := make(map[uint64]PriceFeedInfo)
+	for _, networkID := range pfm.GetNetworkIDs() {
+		for _, feed := range pfm.GetFeedsForNetwork(networkID) {
+			if strings.EqualFold(feed.Symbol, symbol) {
+				result[networkID] = feed
+				break
+			}
+		}
+	}
+	return result
+}
+
+// newPriceFeedInfo builds the PriceFeedInfo of a configured feed
+func newPriceFeedInfo(name string, network string, chainID uint64, config PriceFeedConfig) PriceFeedInfo {
+	return PriceFeedInfo{
+		Name:      name,
+		Address:   config.Proxy,
+		Decimals:  config.Decimals,
+		Network:   network,
+		Symbol:    config.Symbol,
+		ChainID:   chainID,
+		Heartbeat: config.Heartbeat,
+		Threshold: config.Threshold,
+		Base:      config.Base,
+		Quote:     config.Quote,
+	}
 }
 
 // CreateNetworkConfig creates a NetworkConfiguration from the price feed configs and extraRpcs.json
@@ -179,19 +294,8 @@ func (pfm *PriceFeedManager) CreateNetworkConfig() *NetworkConfiguration {
 			continue
 		}
 
-		// Get price feeds for this network (only if it matches our configured network)
-		feeds := make(map[string]string)
-		if chainIDUint == pfm.NetworkID {
-			// Add crypto feeds to approval source
-			for name, config := range pfm.CryptoFeeds {
-				feeds[name] = config.Proxy
-			}
-
-			// Add stock feeds to approval source
-			for name, config := range pfm.StockFeeds {
-				feeds[name] = config.Proxy
-			}
-		}
+		// Get price feeds configured for this network
+		feeds := pfm.approvalSource(chainIDUint)
 
 		// Create RPC config
 		networks = append(networks, RPCConfig{
@@ -215,39 +319,77 @@ func (pfm *PriceFeedManager) CreateNetworkConfig() *NetworkConfiguration {
 	}
 }
 
-// createNetworkConfigFromFeeds creates a NetworkConfiguration from the price feed configs (fallback method)
+// createNetworkConfigFromFeeds creates a NetworkConfiguration from the price feed configs (fallback method).
+// Each configured network uses its public RPC endpoints.
 func (pfm *PriceFeedManager) createNetworkConfigFromFeeds() *NetworkConfiguration {
-	// Create approval source map with all feeds
-	approvalSrc := make(map[string]string)
+	var networks []RPCConfig
+	for _, networkID := range pfm.GetNetworkIDs() {
+		endpoints, exists := defaultPublicRPCs[networkID]
+		if !exists {
+			continue
+		}
 
-	// Add crypto feeds to approval source
-	for name, config := range pfm.CryptoFeeds {
-		approvalSrc[name] = config.Proxy
+		chainID := strconv.FormatUint(networkID, 10)
+		networkInfo := getNetworkInfo(chainID)
+		networks = append(networks, RPCConfig{
+			NetworkID:    chainID,
+			NameStd:      networkInfo.NameStd,
+			NameCoinr:    networkInfo.NameCoinr,
+			WrappedToken: networkInfo.WrappedToken,
+			Endpoints:    endpoints,
+			ApprovalSrc:  pfm.approvalSource(networkID),
+		})
 	}
 
-	// Add stock feeds to approval source
-	for name, config := range pfm.StockFeeds {
-		approvalSrc[name] = config.Proxy
+	return &NetworkConfiguration{
+		Networks:  networks,
+		ClientUse: make(map[uint64]*EthereumClient),
 	}
+}
 
-	// Create RPC config for Arbitrum (default network)
-	rpcConfig := RPCConfig{
-		NetworkID:    strconv.FormatUint(pfm.NetworkID, 10),
-		NameStd:      "Arbitrum Mainnet",
-		NameCoinr:    "ARB",
-		WrappedToken: "0x82aF49447D8a07e3bd95BD0d56f35241523fBab1",
-		Endpoints: []string{
-			"https://arb1.arbitrum.io/rpc",
-			"https://arbitrum.publicnode.com",
-			"https://arbitrum-one.public.blastapi.io",
-		},
-		ApprovalSrc: approvalSrc,
+// approvalSource returns feed name -> proxy address for every feed with a proxy on a network
+func (pfm *PriceFeedManager) approvalSource(networkID uint64) map[string]string {
+	approvalSrc := make(map[string]string)
+	for _, feed := range pfm.GetFeedsForNetwork(networkID) {
+		if feed.Address != "" {
+			approvalSrc[feed.Name] = feed.Address
+		}
 	}
+	return approvalSrc
+}
 
-	return &NetworkConfiguration{
-		Networks:  []RPCConfig{rpcConfig},
-		ClientUse: make(map[uint64]*EthereumClient),
-	}
+// defaultPublicRPCs are the RPC endpoints used when extraRpcs.json is not available
+var defaultPublicRPCs = map[uint64][]string{
+	1: {
+		"https://ethereum.publicnode.com",
+		"https://eth.llamarpc.com",
+		"https://rpc.ankr.com/eth",
+	},
+	10: {
+		"https://mainnet.optimism.io",
+		"https://optimism.publicnode.com",
+	},
+	56: {
+		"https://bsc-dataseed.bnbchain.org",
+		"https://bsc.publicnode.com",
+	},
+	137: {
+		"https://polygon-rpc.com",
+		"https://polygon-bor.publicnode.com",
+	},
+	8453: {
+		"https://mainnet.base.org",
+		"https://base.publicnode.com",
+	},
+	42161: {
+		"https://arb1.arbitrum.io/rpc",
+		"https://arbitrum.publicnode.com",
+		"https://arbitrum-one.public.blastapi.io",
+	},
+	43114: {
+		"https://api.avax.network/ext/bc/C/rpc",
+		"https://avalanche-c-chain.publicnode.com",
+	},
 }
 
 // GetDefaultRPCCheckInterval returns the default RPC check interval
@@ -0,0 +1,79 @@
+package rpcscan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigWithNetworkFiles(t *testing.T) {
+	pfm := NewPriceFeedManager(42161)
+	if err := pfm.LoadConfig("../conf"); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	networkIDs := pfm.GetNetworkIDs()
+	for _, want := range []uint64{1, 10, 56, 137, 8453, 42161, 43114} {
+		found := false
+		for _, networkID := range networkIDs {
+			if networkID == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected network %d to have feeds, got networks %v", want, networkIDs)
+		}
+	}
+
+	ethFeeds := pfm.GetFeedsBySymbol("ETH/USD")
+	if len(ethFeeds) != len(networkIDs) {
+		t.Errorf("Expected ETH/USD on %d networks, got %d", len(networkIDs), len(ethFeeds))
+	}
+	if feed := ethFeeds[1]; feed.Address != "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419" || feed.ChainID != 1 {
+		t.Errorf("Unexpected Ethereum ETH/USD feed: %+v", feed)
+	}
+
+	// The default network keeps its crypto and stock feeds
+	if len(pfm.GetFeedsForNetwork(42161)) != len(pfm.GetAllFeeds()) {
+		t.Error("Expected Arbitrum feeds to match crytos.yaml and stocks.yaml")
+	}
+	if len(pfm.GetFeedsForNetwork(250)) != 0 {
+		t.Error("Expected no feeds for an unconfigured network")
+	}
+
+	// Every network with feeds declares its Pyth contract
+	pythContracts := pfm.GetPythContracts()
+	for _, networkID := range networkIDs {
+		if pythContracts[networkID] == "" {
+			t.Errorf("Expected a Pyth contract for network %d", networkID)
+		}
+	}
+}
+
+func TestLoadNetworkConfigRejectsDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	feed := "chain_id: 1\nname: ethereum\nfeeds:\n  eth:\n    symbol: ETH/USD\n    proxy: \"0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419\"\n    decimals: 8\n"
+	for _, name := range []string{"a.yaml", "b.yaml"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(feed), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pfm := NewPriceFeedManager(42161)
+	err := pfm.LoadNetworkConfigs(dir)
+	if err == nil || !strings.Contains(err.Error(), "declared twice") {
+		t.Fatalf("Expected duplicate feed error, got %v", err)
+	}
+}
+
+func TestLoadNetworkConfigRequiresChainID(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "unknown.yaml")
+	if err := os.WriteFile(path, []byte("name: unknown\nfeeds: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewPriceFeedManager(42161).LoadNetworkConfig(path); err == nil {
+		t.Fatal("Expected an error for a file without chain_id")
+	}
+}
@@ -93,10 +93,10 @@ make a loop function to check for each network the best RPC endpoint
 without crashing anything parameters the program can select any RPC by networkID at anytime
 */
 
-func checkLatencyCon(netID, endpoint_rpc string) LatencyConcurrentBox {
+func checkLatencyCon(ctx context.Context, netID, endpoint_rpc string) LatencyConcurrentBox {
 	start := time.Now()
 	value, _ := strconv.ParseUint(netID, 10, 64)
-	client, err := rpc.Dial(endpoint_rpc)
+	client, err := rpc.DialContext(ctx, endpoint_rpc)
 	if err != nil {
 		return LatencyConcurrentBox{
 			endpoint:  endpoint_rpc,
@@ -106,7 +106,7 @@ func checkLatencyCon(netID, endpoint_rpc string) LatencyConcurrentBox {
 	}
 	defer client.Close()
 	var result string
-	err = client.Call(&result, "web3_clientVersion")
+	err = client.CallContext(ctx, &result, "web3_clientVersion")
 	if err != nil {
 		return LatencyConcurrentBox{
 			networkId: value,
@@ -148,13 +148,13 @@ func Readendpts(conf *Config) *NetworkConfiguration {
 }
 
 // Get the best RPC endpoint for each network (deprecated - use getBestRPCEndpointsParallel instead)
-func getBestRPCEndpoints(netconf *NetworkConfiguration) (map[string]string, error) {
+func getBestRPCEndpoints(ctx context.Context, netconf *NetworkConfiguration) (map[string]string, error) {
 	bestEndpoints := make(map[string]string)
 	for _, network := range netconf.Networks {
 		bestEndpoint := ""
 		lowestLatency := time.Duration(1<<63 - 1) // Set to maximum duration
 		for _, endpoint := range network.Endpoints {
-			latency := checkLatencyCon(network.NetworkID, endpoint)
+			latency := checkLatencyCon(ctx, network.NetworkID, endpoint)
 			if latency.latency > 0 && latency.latency < lowestLatency {
 				lowestLatency = latency.latency
 				bestEndpoint = endpoint
@@ -170,7 +170,7 @@ func getBestRPCEndpoints(netconf *NetworkConfiguration) (map[string]string, erro
 	return bestEndpoints, nil
 }
 
-func getBestRPCEndpointsParallel(netconf *NetworkConfiguration, timeout time.Duration) (map[uint64]string, error) {
+func getBestRPCEndpointsParallel(ctx context.Context, netconf *NetworkConfiguration, timeout time.Duration) (map[uint64]string, error) {
 	bestEndpoints := make(map[uint64]string)
 	var mu sync.Mutex
 	var wg sync.WaitGroup
@@ -197,15 +197,15 @@ func getBestRPCEndpointsParallel(netconf *NetworkConfiguration, timeout time.Dur
 				go func(ep string) {
 					defer endpointWg.Done()
 
-					// Use context with timeout to prevent hanging
-					ctx, cancel := context.WithTimeout(context.Background(), timeout)
+					// Use context with timeout to prevent hanging; it also aborts the check itself
+					ctx, cancel := context.WithTimeout(ctx, timeout)
 					defer cancel()
 
 					// Create a channel to receive the result
 					resultChan := make(chan LatencyConcurrentBox, 1)
 
 					go func() {
-						resultChan <- checkLatencyCon(network.NetworkID, ep)
+						resultChan <- checkLatencyCon(ctx, network.NetworkID, ep)
 					}()
 
 					select {
@@ -262,6 +262,17 @@ func MonitorAllRPCEndpoints(conf *Config, netconf *NetworkConfiguration, interva
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
+	// Abort in-flight endpoint checks as soon as monitoring is stopped
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
 	for {
 		select {
 		case <-stopChan:
@@ -271,7 +282,7 @@ func MonitorAllRPCEndpoints(conf *Config, netconf *NetworkConfiguration, interva
 			start := time.Now()
 			log.Printf("Starting RPC endpoint monitoring at %s", start.Format(time.RFC3339))
 
-			bestEndpoints, err := getBestRPCEndpointsParallel(netconf, 5*time.Second)
+			bestEndpoints, err := getBestRPCEndpointsParallel(ctx, netconf, 5*time.Second)
 			if err != nil {
 				log.Printf("Error finding best RPC endpoints: %v", err)
 				continue
@@ -396,8 +407,9 @@ func (netconf *NetworkConfiguration) GetAllClients() map[uint64]*EthereumClient
 	return clients
 }
 
-// SwitchRPCEndpointImmediately switches to a different RPC endpoint for a specific network immediately
-func (netconf *NetworkConfiguration) SwitchRPCEndpointImmediately(networkID uint64) error {
+// SwitchRPCEndpointImmediately switches to a different RPC endpoint for a specific network immediately.
+// ctx bounds the latency checks of the candidate endpoints.
+func (netconf *NetworkConfiguration) SwitchRPCEndpointImmediately(ctx context.Context, networkID uint64) error {
 	netconf.mu.Lock()
 	defer netconf.mu.Unlock()
 
@@ -432,8 +444,12 @@ func (netconf *NetworkConfiguration) SwitchRPCEndpointImmediately(networkID uint
 			continue
 		}
 
+		if ctx.Err() != nil {
+			return fmt.Errorf("RPC switch for network %d cancelled: %v", networkID, ctx.Err())
+		}
+
 		// Test this endpoint
-		latency := checkLatencyCon(targetNetwork.NetworkID, endpoint)
+		latency := checkLatencyCon(ctx, targetNetwork.NetworkID, endpoint)
 		if latency.latency > 0 && latency.latency < bestLatency {
 			bestLatency = latency.latency
 			bestEndpoint = endpoint
@@ -13,8 +13,12 @@ import (
 type PriceSource string
 
 const (
-	SourceChainlink PriceSource = "chainlink"
-	SourcePyth      PriceSource = "pyth"
+	SourceChainlink        PriceSource = "chainlink"
+	SourcePyth             PriceSource = "pyth"
+	SourceChainlinkStreams PriceSource = "chainlink_streams"
+	SourcePythOnChain      PriceSource = "pyth_onchain"
+	SourcePythEMA          PriceSource = "pyth_ema"
+	SourcePythTWAP         PriceSource = "pyth_twap"
 )
 const (
 	OracleNetworkIDPyth      = 0
@@ -38,6 +42,17 @@ type PriceInfo interface {
 	GetPriceInSatoshi() (*big.Int, error) // Returns the price in satoshi format (1e8), adjusted by the exponent
 }
 
+// satoshiPrice converts a value scaled by 10^exponent to satoshi format (1e8): value * 10^exponent
+// * SatoshiScale. big.Int.Exp returns 1 for negative exponents, so those divide by 10^-exponent
+// instead, truncating toward zero.
+func satoshiPrice(value *big.Int, exponent int) *big.Int {
+	result := new(big.Int).Mul(value, big.NewInt(int64(safem.SatoshiScale)))
+	if exponent >= 0 {
+		return result.Mul(result, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil))
+	}
+	return result.Quo(result, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exponent)), nil))
+}
+
 // ChainlinkPrice implements PriceInfo for Chainlink data
 type ChainlinkPrice struct {
 	RoundID         *big.Int
@@ -48,7 +63,11 @@ type ChainlinkPrice struct {
 	Timestamp       time.Time
 	Exponent        int
 	NetworkID       uint64
-	FeedAddress     string // Store the feed address for identifier
+	FeedAddress     string   // Store the feed address for identifier
+	Untrusted       bool     // Set while the network's L2 sequencer is down or in its grace period
+	UntrustedReason string   // Why the price is untrusted, empty when trusted
+	BlockNumber     *big.Int // Block the round was read at, nil when read at the latest block
+	BlockHash       string   // Hash of BlockNumber, empty when read at the latest block
 }
 
 func (p *ChainlinkPrice) GetSource() PriceSource {
@@ -88,16 +107,7 @@ func (p *ChainlinkPrice) GetPriceInSatoshi() (*big.Int, error) {
 		return nil, fmt.Errorf("Answer is nil")
 	}
 
-	// Calculate adjustment factor: 10^exponent * SatoshiScale
-	// Exponent adjusts the decimal position, SatoshiScale converts to satoshi format
-	exponentFactor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(p.Exponent)), nil)
-	satoshiScaleBig := big.NewInt(int64(safem.SatoshiScale))
-	adjustment := new(big.Int).Mul(exponentFactor, satoshiScaleBig)
-
-	// Multiply Answer by adjustment to get satoshi value
-	result := new(big.Int).Mul(p.Answer, adjustment)
-
-	return result, nil
+	return satoshiPrice(p.Answer, p.Exponent), nil
 }
 
 // GetUint64SatoshiPrice returns the price in satoshi format as uint64
@@ -160,16 +170,7 @@ func (p *PythPrice) GetPriceInSatoshi() (*big.Int, error) {
 		return nil, fmt.Errorf("Price is nil")
 	}
 
-	// Calculate adjustment factor: 10^exponent * SatoshiScale
-	// Exponent adjusts the decimal position, SatoshiScale converts to satoshi format
-	exponentFactor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(p.Exponent)), nil)
-	satoshiScaleBig := big.NewInt(int64(safem.SatoshiScale))
-	adjustment := new(big.Int).Mul(exponentFactor, satoshiScaleBig)
-
-	// Multiply Price by adjustment to get satoshi value
-	result := new(big.Int).Mul(p.Price, adjustment)
-
-	return result, nil
+	return satoshiPrice(p.Price, p.Exponent), nil
 }
 
 // GetUint64SatoshiPrice returns the price in satoshi format as uint64
@@ -180,6 +181,212 @@ func (p *PythPrice) GetUint64SatoshiPrice() uint64 {
 	return priceInSatoshi.Uint64()
 }
 
+// StreamsPrice implements PriceInfo for Chainlink Data Streams reports
+type StreamsPrice struct {
+	FeedID                string    `json:"feedId"`                 // 0x-prefixed 32-byte feed ID
+	Schema                uint16    `json:"schema"`                 // Report schema version (3 = crypto, 4 = RWA)
+	Price                 *big.Int  `json:"price"`                  // Benchmark price (v3) or price (v4)
+	Bid                   *big.Int  `json:"bid,omitempty"`          // v3 only
+	Ask                   *big.Int  `json:"ask,omitempty"`          // v3 only
+	MarketStatus          uint32    `json:"marketStatus,omitempty"` // v4 only: 0 unknown, 1 closed, 2 open
+	Exponent              int       `json:"exponent"`
+	ValidFrom             time.Time `json:"validFrom"`
+	ObservationsTimestamp time.Time `json:"observationsTimestamp"`
+	ExpiresAt             time.Time `json:"expiresAt"`
+	Timestamp             time.Time `json:"timestamp"` // When the report was received
+	NetworkID             uint64    `json:"networkId"`
+}
+
+func (p *StreamsPrice) GetSource() PriceSource {
+	return SourceChainlinkStreams
+}
+
+func (p *StreamsPrice) GetNetworkID() uint64 {
+	return p.NetworkID
+}
+
+func (p *StreamsPrice) GetTimestamp() time.Time {
+	return p.ObservationsTimestamp
+}
+
+func (p *StreamsPrice) GetPrice() (*big.Int, int) {
+	return p.Price, p.Exponent
+}
+
+func (p *StreamsPrice) GetIdentifier() string {
+	return p.FeedID
+}
+
+// GetPriceInSatoshi returns the price in satoshi format (1e8), adjusted by the exponent
+// the same way as ChainlinkPrice and PythPrice
+func (p *StreamsPrice) GetPriceInSatoshi() (*big.Int, error) {
+	if p.Price == nil {
+		return nil, fmt.Errorf("Price is nil")
+	}
+
+	return satoshiPrice(p.Price, p.Exponent), nil
+}
+
+// GetUint64SatoshiPrice returns the price in satoshi format as uint64
+func (p *StreamsPrice) GetUint64SatoshiPrice() uint64 {
+	priceInSatoshi, _ := p.GetPriceInSatoshi()
+	return priceInSatoshi.Uint64()
+}
+
+// PythOnChainPrice implements PriceInfo for prices read from a Pyth contract on an EVM network,
+// i.e. the last update pushed there, as opposed to the latest Hermes price
+type PythOnChainPrice struct {
+	ID              string    `json:"id"` // Price feed ID, hex without 0x like Hermes
+	Symbol          string    `json:"symbol,omitempty"`
+	ContractAddress string    `json:"contractAddress"`
+	Price           *big.Int  `json:"price"`
+	Confidence      *big.Int  `json:"confidence"`
+	Exponent        int       `json:"exponent"`
+	PublishTime     int64     `json:"publishTime"`
+	EMA             *big.Int  `json:"ema,omitempty"`
+	EMAConfidence   *big.Int  `json:"ema_confidence,omitempty"`
+	Stale           bool      `json:"stale"`     // Set when getPriceNoOlderThan rejected the price as older than the allowed age
+	Timestamp       time.Time `json:"timestamp"` // When the price was read
+	NetworkID       uint64    `json:"networkId"`
+}
+
+func (p *PythOnChainPrice) GetSource() PriceSource {
+	return SourcePythOnChain
+}
+
+func (p *PythOnChainPrice) GetNetworkID() uint64 {
+	return p.NetworkID
+}
+
+func (p *PythOnChainPrice) GetTimestamp() time.Time {
+	return p.Timestamp
+}
+
+func (p *PythOnChainPrice) GetPrice() (*big.Int, int) {
+	return p.Price, p.Exponent
+}
+
+func (p *PythOnChainPrice) GetIdentifier() string {
+	return p.ID
+}
+
+// GetPriceInSatoshi returns the price in satoshi format (1e8), adjusted by the exponent
+// the same way as PythPrice
+func (p *PythOnChainPrice) GetPriceInSatoshi() (*big.Int, error) {
+	if p.Price == nil {
+		return nil, fmt.Errorf("Price is nil")
+	}
+
+	return satoshiPrice(p.Price, p.Exponent), nil
+}
+
+// GetUint64SatoshiPrice returns the price in satoshi format as uint64
+func (p *PythOnChainPrice) GetUint64SatoshiPrice() uint64 {
+	priceInSatoshi, _ := p.GetPriceInSatoshi()
+	return priceInSatoshi.Uint64()
+}
+
+// PythEMAPrice implements PriceInfo for the exponential moving average Pyth publishes with each
+// price, cached as its own series next to the spot PythPrice
+type PythEMAPrice struct {
+	ID          string    `json:"id"`
+	Symbol      string    `json:"symbol,omitempty"`
+	Price       *big.Int  `json:"price"`
+	Confidence  *big.Int  `json:"confidence"`
+	Exponent    int       `json:"exponent"`
+	PublishTime int64     `json:"publishTime"`
+	Timestamp   time.Time `json:"timestamp"`
+	NetworkID   uint64    `json:"networkId"`
+}
+
+func (p *PythEMAPrice) GetSource() PriceSource {
+	return SourcePythEMA
+}
+
+func (p *PythEMAPrice) GetNetworkID() uint64 {
+	return p.NetworkID
+}
+
+func (p *PythEMAPrice) GetTimestamp() time.Time {
+	return p.Timestamp
+}
+
+func (p *PythEMAPrice) GetPrice() (*big.Int, int) {
+	return p.Price, p.Exponent
+}
+
+func (p *PythEMAPrice) GetIdentifier() string {
+	return p.ID
+}
+
+// GetPriceInSatoshi returns the price in satoshi format (1e8), adjusted by the exponent
+// the same way as PythPrice
+func (p *PythEMAPrice) GetPriceInSatoshi() (*big.Int, error) {
+	if p.Price == nil {
+		return nil, fmt.Errorf("Price is nil")
+	}
+
+	return satoshiPrice(p.Price, p.Exponent), nil
+}
+
+// GetUint64SatoshiPrice returns the price in satoshi format as uint64
+func (p *PythEMAPrice) GetUint64SatoshiPrice() uint64 {
+	priceInSatoshi, _ := p.GetPriceInSatoshi()
+	return priceInSatoshi.Uint64()
+}
+
+// PythTWAPPrice implements PriceInfo for a Pyth time weighted average price over a window ending
+// at EndTime, as computed by Hermes
+type PythTWAPPrice struct {
+	ID             string        `json:"id"`
+	Symbol         string        `json:"symbol,omitempty"`
+	Window         time.Duration `json:"window"`
+	Price          *big.Int      `json:"price"`
+	Confidence     *big.Int      `json:"confidence"`
+	Exponent       int           `json:"exponent"`
+	StartTime      int64         `json:"startTime"`      // Unix time the window starts at
+	EndTime        int64         `json:"endTime"`        // Unix time the window ends at
+	DownSlotsRatio float64       `json:"downSlotsRatio"` // Share of slots in the window without a price
+	Timestamp      time.Time     `json:"timestamp"`
+	NetworkID      uint64        `json:"networkId"`
+}
+
+func (p *PythTWAPPrice) GetSource() PriceSource {
+	return SourcePythTWAP
+}
+
+func (p *PythTWAPPrice) GetNetworkID() uint64 {
+	return p.NetworkID
+}
+
+func (p *PythTWAPPrice) GetTimestamp() time.Time {
+	return p.Timestamp
+}
+
+func (p *PythTWAPPrice) GetPrice() (*big.Int, int) {
+	return p.Price, p.Exponent
+}
+
+func (p *PythTWAPPrice) GetIdentifier() string {
+	return p.ID
+}
+
+// GetPriceInSatoshi returns the price in satoshi format (1e8), adjusted by the exponent
+// the same way as PythPrice
+func (p *PythTWAPPrice) GetPriceInSatoshi() (*big.Int, error) {
+	if p.Price == nil {
+		return nil, fmt.Errorf("Price is nil")
+	}
+
+	return satoshiPrice(p.Price, p.Exponent), nil
+}
+
+// GetUint64SatoshiPrice returns the price in satoshi format as uint64
+func (p *PythTWAPPrice) GetUint64SatoshiPrice() uint64 {
+	priceInSatoshi, _ := p.GetPriceInSatoshi()
+	return priceInSatoshi.Uint64()
+}
+
 // PythPriceData represents price data from Pyth Network
 // This is a morphcore-specific type used in the oracle adapter
 type PythPriceData struct {
@@ -212,16 +419,7 @@ func (p *PythPriceData) GetPriceInSatoshi() (*big.Int, error) {
 		return nil, fmt.Errorf("failed to parse price string %s: %w", p.Price, err)
 	}
 
-	// Calculate adjustment factor: 10^exponent * SatoshiScale
-	// Exponent adjusts the decimal position, SatoshiScale converts to satoshi format
-	exponentFactor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(p.Exponent)), nil)
-	satoshiScaleBig := big.NewInt(int64(safem.SatoshiScale))
-	adjustment := new(big.Int).Mul(exponentFactor, satoshiScaleBig)
-
-	// Multiply price by adjustment to get satoshi value
-	result := new(big.Int).Mul(priceInt, adjustment)
-
-	return result, nil
+	return satoshiPrice(priceInt, p.Exponent), nil
 }
 
 // GetUint64SatoshiPrice returns the price in satoshi format as uint64
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestGetPriceInSatoshiNegativeExponents(t *testing.T) {
+	// $50,000.00 with 8 and 18 decimals; $0.00000001 with 18 decimals truncates to 1 satoshi
+	tests := []struct {
+		name     string
+		value    string
+		exponent int
+		want     string
+	}{
+		{"8 decimals", "5000000000000", -8, "5000000000000"},
+		{"18 decimals", "50000000000000000000000", -18, "5000000000000"},
+		{"18 decimals below a satoshi", "19999999999", -18, "1"},
+		{"positive exponent", "5", 2, "50000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, _ := new(big.Int).SetString(tt.value, 10)
+			prices := []interface {
+				GetPriceInSatoshi() (*big.Int, error)
+			}{
+				&ChainlinkPrice{Answer: value, Exponent: tt.exponent},
+				&PythPrice{Price: value, Exponent: tt.exponent},
+				&PythPriceData{Price: tt.value, Exponent: tt.exponent},
+				&StreamsPrice{Price: value, Exponent: tt.exponent},
+				&PythOnChainPrice{Price: value, Exponent: tt.exponent},
+				&PythEMAPrice{Price: value, Exponent: tt.exponent},
+				&PythTWAPPrice{Price: value, Exponent: tt.exponent},
+			}
+			for _, price := range prices {
+				got, err := price.GetPriceInSatoshi()
+				if err != nil {
+					t.Fatalf("%T: GetPriceInSatoshi failed: %v", price, err)
+				}
+				if got.String() != tt.want {
+					t.Errorf("%T: expected %s satoshi, got %s", price, tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestGetUint64SatoshiPrice(t *testing.T) {
+	price := &PythEMAPrice{Price: big.NewInt(250012345678), Exponent: -8}
+	if got := price.GetUint64SatoshiPrice(); got != 250012345678 {
+		t.Errorf("Expected 250012345678 satoshi, got %d", got)
+	}
+}